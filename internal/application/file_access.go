@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// WithAccessRepository enables attaching files to domain entities and
+// hackathon-member visibility
+func WithAccessRepository(accessRepo domain.FileAccessRepository) FileServiceOption {
+	return func(s *FileService) {
+		s.accessRepo = accessRepo
+	}
+}
+
+// AuthorizeRead returns domain.ErrFileAccessDenied unless the viewer may read the file
+func (s *FileService) AuthorizeRead(ctx context.Context, file *domain.File, viewer *domain.FileViewer) error {
+	switch {
+	case file.Visibility == "" || file.Visibility == domain.FileVisibilityPublic:
+		return nil
+	case viewer == nil:
+		return domain.ErrFileAccessDenied
+	case viewer.Admin || uploadedBy(file, viewer):
+		return nil
+	case file.Visibility == domain.FileVisibilityHackathonMembers && file.Attachment != nil && s.accessRepo != nil:
+		member, err := s.accessRepo.IsHackathonMember(ctx, *file.Attachment, viewer.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to check file access: %w", err)
+		}
+		if member {
+			return nil
+		}
+	}
+	return domain.ErrFileAccessDenied
+}
+
+// AuthorizeWrite returns domain.ErrFileAccessDenied unless the viewer may modify or
+// delete the file. Files without a recorded uploader can only be written by admins.
+// Files of a locked attachment, such as a closed submission, can only be written by
+// admins and otherwise fail with domain.ErrAttachmentLocked.
+func (s *FileService) AuthorizeWrite(ctx context.Context, file *domain.File, viewer *domain.FileViewer) error {
+	if viewer != nil && viewer.Admin {
+		return nil
+	}
+	if viewer == nil || !uploadedBy(file, viewer) {
+		return domain.ErrFileAccessDenied
+	}
+
+	if file.Attachment != nil && s.accessRepo != nil {
+		locked, err := s.accessRepo.AttachmentLocked(ctx, *file.Attachment)
+		if err != nil {
+			return fmt.Errorf("failed to check file access: %w", err)
+		}
+		if locked {
+			return domain.ErrAttachmentLocked
+		}
+	}
+	return nil
+}
+
+// loadAccess fills in the access attributes and scan verdict recorded for a
+// storage file. Without a metadata repository every file is public; with one,
+// files that have no row were not stored through the service and are admin-only.
+func (s *FileService) loadAccess(ctx context.Context, file *domain.File) error {
+	file.FileAccess = domain.FileAccess{Visibility: domain.FileVisibilityPublic}
+	if s.metadataRepo == nil {
+		return nil
+	}
+
+	recorded, err := s.metadataRepo.Get(ctx, file.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			file.FileAccess = untrackedAccess
+			return nil
+		}
+		return err
+	}
+	file.FileAccess = recorded.FileAccess
+	file.FileScan = recorded.FileScan
+	return nil
+}
+
+// resolveAccess validates the requested ownership of a new file. Attaching requires
+// the uploader to own the target entity unless they are an admin.
+func (s *FileService) resolveAccess(ctx context.Context, req *domain.FileAccessRequest) (domain.FileAccess, error) {
+	access := domain.FileAccess{Visibility: domain.FileVisibilityPublic}
+	if req == nil {
+		return access, nil
+	}
+
+	if req.Uploader != nil {
+		uploaderID := req.Uploader.UserID
+		access.UploaderID = &uploaderID
+	}
+
+	if req.Visibility != "" {
+		if !validVisibility(req.Visibility) {
+			return access, domain.ErrInvalidVisibility
+		}
+		access.Visibility = req.Visibility
+	}
+
+	if req.Attachment != nil {
+		if err := validateAttachment(req.Attachment); err != nil {
+			return access, err
+		}
+		if s.accessRepo != nil {
+			owners, err := s.accessRepo.AttachmentOwners(ctx, *req.Attachment)
+			if err != nil {
+				return access, err
+			}
+			if req.Uploader != nil && !req.Uploader.Admin && !containsUser(owners, req.Uploader.UserID) {
+				return access, domain.ErrFileAccessDenied
+			}
+		}
+		access.Attachment = req.Attachment
+	}
+
+	if access.Visibility == domain.FileVisibilityHackathonMembers && !hackathonAttachment(access.Attachment) {
+		return access, fmt.Errorf("%w: %s files must be attached to a hackathon, participant or submission", domain.ErrInvalidVisibility, access.Visibility)
+	}
+	if s.metadataRepo == nil && (access.Visibility != domain.FileVisibilityPublic || access.Attachment != nil) {
+		return access, fmt.Errorf("file metadata repository is required to restrict or attach files")
+	}
+
+	return access, nil
+}
+
+// listAccessFilter restricts listings to the files the viewer may see. Hackathon-member
+// files are only included when listing the files of an entity the viewer is a member of.
+func (s *FileService) listAccessFilter(ctx context.Context, viewer *domain.FileViewer, attachment *domain.FileAttachment) (*domain.FileAccessFilter, error) {
+	if viewer != nil && viewer.Admin {
+		return nil, nil
+	}
+
+	filter := &domain.FileAccessFilter{Visibilities: []string{domain.FileVisibilityPublic}}
+	if viewer == nil {
+		return filter, nil
+	}
+
+	uploaderID := viewer.UserID
+	filter.UploaderID = &uploaderID
+	if attachment != nil && s.accessRepo != nil {
+		member, err := s.accessRepo.IsHackathonMember(ctx, *attachment, viewer.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check file access: %w", err)
+		}
+		if member {
+			filter.Visibilities = append(filter.Visibilities, domain.FileVisibilityHackathonMembers)
+		}
+	}
+	return filter, nil
+}
+
+// untrackedAccess applies to files without a metadata row: private with no
+// uploader, so only admins may read or write them
+var untrackedAccess = domain.FileAccess{Visibility: domain.FileVisibilityPrivate}
+
+// validVisibility reports whether visibility is a known level
+func validVisibility(visibility string) bool {
+	switch visibility {
+	case domain.FileVisibilityPrivate, domain.FileVisibilityHackathonMembers, domain.FileVisibilityPublic:
+		return true
+	}
+	return false
+}
+
+// validateAttachment checks the attachment type and ID
+func validateAttachment(attachment *domain.FileAttachment) error {
+	switch attachment.Type {
+	case domain.AttachmentProfile, domain.AttachmentHackathon, domain.AttachmentContest, domain.AttachmentParticipant, domain.AttachmentSubmission:
+	default:
+		return domain.ErrInvalidAttachment
+	}
+	if attachment.ID == 0 {
+		return domain.ErrInvalidAttachment
+	}
+	return nil
+}
+
+// hackathonAttachment reports whether the attachment belongs to a hackathon
+func hackathonAttachment(attachment *domain.FileAttachment) bool {
+	if attachment == nil {
+		return false
+	}
+	switch attachment.Type {
+	case domain.AttachmentHackathon, domain.AttachmentParticipant, domain.AttachmentSubmission:
+		return true
+	}
+	return false
+}
+
+// uploadedBy reports whether the viewer uploaded the file
+func uploadedBy(file *domain.File, viewer *domain.FileViewer) bool {
+	return file.UploaderID != nil && *file.UploaderID == viewer.UserID
+}
+
+// containsUser reports whether userID is one of ids
+func containsUser(ids []uint, userID uint) bool {
+	for _, id := range ids {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
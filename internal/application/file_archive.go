@@ -0,0 +1,154 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+const (
+	// maxArchiveFiles bounds the number of files a single archive may contain
+	maxArchiveFiles = 1000
+	// archivePageSize is the page size used to list the files matched by a query
+	archivePageSize = 100
+)
+
+// ArchiveFiles resolves the files selected by an archive request that viewer may
+// download. Files requested by ID must all be readable and downloadable; files
+// matched by a query are limited to those the viewer can see and download.
+func (s *FileService) ArchiveFiles(ctx context.Context, viewer *domain.FileViewer, req *domain.ArchiveRequest) ([]*domain.File, error) {
+	byQuery := req.Prefix != "" || req.Attachment != nil
+	if (len(req.IDs) > 0) == byQuery {
+		return nil, domain.ErrInvalidArchiveRequest
+	}
+
+	var files []*domain.File
+	var err error
+	if byQuery {
+		files, err = s.archiveQuery(ctx, viewer, req)
+	} else {
+		files, err = s.archiveIDs(ctx, viewer, req.IDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, domain.ErrArchiveEmpty
+	}
+	return files, nil
+}
+
+// archiveIDs loads the files requested by ID, skipping duplicates
+func (s *FileService) archiveIDs(ctx context.Context, viewer *domain.FileViewer, ids []string) ([]*domain.File, error) {
+	seen := make(map[string]bool, len(ids))
+	files := make([]*domain.File, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if len(files) == maxArchiveFiles {
+			return nil, domain.ErrArchiveTooLarge
+		}
+
+		file, err := s.GetFile(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		if err := s.AuthorizeRead(ctx, file, viewer); err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		if err := file.DownloadError(); err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// archiveQuery lists the files matching the prefix and attachment of the request
+func (s *FileService) archiveQuery(ctx context.Context, viewer *domain.FileViewer, req *domain.ArchiveRequest) ([]*domain.File, error) {
+	var files []*domain.File
+	for offset := 0; ; offset += archivePageSize {
+		page, _, err := s.ListFiles(ctx, viewer, &domain.FileQuery{
+			Prefix:     req.Prefix,
+			Attachment: req.Attachment,
+			SortBy:     domain.FileSortName,
+			Limit:      archivePageSize,
+			Offset:     offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range page {
+			// Quarantined and unscanned files are left out rather than failing the export
+			if file.DownloadError() == nil {
+				files = append(files, file)
+			}
+		}
+		if len(files) > maxArchiveFiles {
+			return nil, domain.ErrArchiveTooLarge
+		}
+		if len(page) < archivePageSize {
+			return files, nil
+		}
+	}
+}
+
+// WriteArchive streams the content of files into w as a ZIP archive. Each file is
+// read from storage only while its entry is written, so the archive is never held
+// in memory. Entries are named by file ID.
+func (s *FileService) WriteArchive(ctx context.Context, files []*domain.File, w io.Writer) error {
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.writeArchiveEntry(ctx, archive, file); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// writeArchiveEntry copies the content of file into a new archive entry
+func (s *FileService) writeArchiveEntry(ctx context.Context, archive *zip.Writer, file *domain.File) error {
+	reader, err := s.OpenFileRange(ctx, file.ID, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer reader.Content.Close()
+
+	entry, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     file.ID,
+		Method:   archiveMethod(file.ContentType),
+		Modified: file.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", file.ID, err)
+	}
+	if _, err := io.Copy(entry, reader.Content); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", file.ID, err)
+	}
+	return nil
+}
+
+// archiveMethod stores content that is already compressed and deflates everything else
+func archiveMethod(contentType string) uint16 {
+	switch {
+	case strings.HasPrefix(contentType, "image/") && contentType != "image/svg+xml",
+		strings.HasPrefix(contentType, "video/"),
+		strings.HasPrefix(contentType, "audio/"),
+		contentType == "application/zip",
+		contentType == "application/gzip":
+		return zip.Store
+	}
+	return zip.Deflate
+}
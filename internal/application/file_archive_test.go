@@ -0,0 +1,97 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readArchive returns the content of each entry of a ZIP archive by name
+func readArchive(t *testing.T, data []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	entries := map[string]string{}
+	for _, entry := range archive.File {
+		reader, err := entry.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		require.NoError(t, err)
+		entries[entry.Name] = string(content)
+	}
+	return entries
+}
+
+func TestFileService_Archive(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := setupFileServiceWithMetadata(t)
+	owner := &domain.FileViewer{UserID: 1}
+	hackathon := &domain.FileAttachment{Type: domain.AttachmentHackathon, ID: 7}
+
+	create := func(name, content, visibility string, attachment *domain.FileAttachment) {
+		_, err := service.CreateFileFromReader(ctx, &domain.CreateFileStreamRequest{
+			Name:    name,
+			Content: strings.NewReader(content),
+			Access:  &domain.FileAccessRequest{Uploader: owner, Visibility: visibility, Attachment: attachment},
+		})
+		require.NoError(t, err)
+	}
+	create("submissions/a.txt", "alpha", "", hackathon)
+	create("submissions/b.txt", "bravo", domain.FileVisibilityPrivate, hackathon)
+	create("notes.txt", "notes", "", nil)
+
+	export := func(viewer *domain.FileViewer, req *domain.ArchiveRequest) (map[string]string, error) {
+		files, err := service.ArchiveFiles(ctx, viewer, req)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		require.NoError(t, service.WriteArchive(ctx, files, &buf))
+		return readArchive(t, buf.Bytes()), nil
+	}
+
+	t.Run("By ID", func(t *testing.T) {
+		entries, err := export(owner, &domain.ArchiveRequest{IDs: []string{"notes.txt", "submissions/a.txt", "notes.txt"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"notes.txt": "notes", "submissions/a.txt": "alpha"}, entries)
+	})
+
+	t.Run("By prefix", func(t *testing.T) {
+		entries, err := export(owner, &domain.ArchiveRequest{Prefix: "submissions/"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"submissions/a.txt": "alpha", "submissions/b.txt": "bravo"}, entries)
+	})
+
+	t.Run("By attachment only includes visible files", func(t *testing.T) {
+		entries, err := export(&domain.FileViewer{UserID: 2}, &domain.ArchiveRequest{Attachment: hackathon})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"submissions/a.txt": "alpha"}, entries)
+	})
+
+	t.Run("Files requested by ID must be readable", func(t *testing.T) {
+		_, err := export(&domain.FileViewer{UserID: 2}, &domain.ArchiveRequest{IDs: []string{"submissions/b.txt"}})
+		assert.ErrorIs(t, err, domain.ErrFileAccessDenied)
+
+		_, err = export(owner, &domain.ArchiveRequest{IDs: []string{"missing.txt"}})
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		_, err := export(owner, &domain.ArchiveRequest{})
+		assert.ErrorIs(t, err, domain.ErrInvalidArchiveRequest)
+
+		_, err = export(owner, &domain.ArchiveRequest{IDs: []string{"notes.txt"}, Prefix: "submissions/"})
+		assert.ErrorIs(t, err, domain.ErrInvalidArchiveRequest)
+
+		_, err = export(owner, &domain.ArchiveRequest{Prefix: "nothing/"})
+		assert.ErrorIs(t, err, domain.ErrArchiveEmpty)
+	})
+}
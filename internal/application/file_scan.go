@@ -0,0 +1,225 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// defaultQuarantinePrefix is where infected content is moved unless configured otherwise
+const defaultQuarantinePrefix = "quarantine/"
+
+// WithScanner scans all stored content with scanner, which may be nil to disable
+// scanning. Infected content is moved under quarantinePrefix and files cannot be
+// downloaded until they scanned clean.
+func WithScanner(scanner domain.FileScanner, quarantinePrefix string) FileServiceOption {
+	return func(s *FileService) {
+		s.scanner = scanner
+		if quarantinePrefix != "" {
+			s.quarantinePrefix = quarantinePrefix
+		}
+	}
+}
+
+// ScanFile scans the content of a file again and records the verdict, e.g. after
+// the scanner was unavailable during the upload
+func (s *FileService) ScanFile(ctx context.Context, id string) (*domain.File, error) {
+	if s.scanner == nil {
+		return nil, domain.ErrScanningUnsupported
+	}
+
+	file, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if file.ScanStatus == domain.ScanStatusInfected {
+		return file, nil
+	}
+
+	var rejection *domain.ScanRejection
+	err = s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		rejection, err = s.scanStored(ctx, file)
+		if err != nil {
+			return err
+		}
+		return s.recordUpdated(ctx, meta, id, file)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejection != nil {
+		return nil, rejection
+	}
+	return file, nil
+}
+
+// VersionScan returns the scan verdict of a stored version of a file. Versions
+// without a recorded verdict are reported as pending.
+func (s *FileService) VersionScan(ctx context.Context, id string, generation int64) (domain.FileScan, error) {
+	if !s.scansVersions() || s.metadataRepo == nil {
+		return domain.FileScan{}, nil
+	}
+
+	scan, err := s.metadataRepo.GetVersionScan(ctx, id, generation)
+	if errors.Is(err, domain.ErrVersionNotFound) {
+		return domain.FileScan{ScanStatus: domain.ScanStatusPending}, nil
+	}
+	if err != nil {
+		return domain.FileScan{}, err
+	}
+	return *scan, nil
+}
+
+// scansVersions reports whether scan verdicts are recorded per version, so that
+// earlier versions are only served if their own content passed the scan
+func (s *FileService) scansVersions() bool {
+	return s.scanner != nil && s.versions != nil
+}
+
+// recordVersionScan records the verdict of the live content of file against its generation
+func (s *FileService) recordVersionScan(ctx context.Context, meta domain.FileMetadataRepository, file *domain.File) error {
+	if !s.scansVersions() || file.ScanStatus == "" || file.Generation == 0 {
+		return nil
+	}
+	if err := meta.SaveVersionScan(ctx, file.ID, file.Generation, file.FileScan); err != nil {
+		return fmt.Errorf("failed to record version scan: %w", err)
+	}
+	return nil
+}
+
+// moveVersionScans carries the verdicts of a renamed file's versions over to newID.
+// Storage may renumber the versions when moving them but keeps their order, so
+// before, the versions listed ahead of the rename, are matched up oldest first.
+func (s *FileService) moveVersionScans(ctx context.Context, meta domain.FileMetadataRepository, id, newID string, before []*domain.FileVersion) error {
+	if meta == nil || !s.scansVersions() {
+		return nil
+	}
+	after, err := s.versions.ListVersions(ctx, newID)
+	if err != nil {
+		return fmt.Errorf("failed to list file versions: %w", err)
+	}
+
+	// Versions are listed newest first
+	for i, j := len(before)-1, len(after)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		scan, err := meta.GetVersionScan(ctx, id, before[i].Generation)
+		if errors.Is(err, domain.ErrVersionNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := meta.SaveVersionScan(ctx, newID, after[j].Generation, *scan); err != nil {
+			return fmt.Errorf("failed to record version scan: %w", err)
+		}
+	}
+	return meta.DeleteVersionScans(ctx, id)
+}
+
+// scanStored scans the stored content of file and records the verdict on it. Infected
+// content is quarantined, after which file describes what remains under its ID, and a
+// rejection is returned for the caller to report once the metadata is recorded.
+func (s *FileService) scanStored(ctx context.Context, file *domain.File) (*domain.ScanRejection, error) {
+	if s.scanner == nil {
+		return nil, nil
+	}
+
+	reader, err := s.fileRepo.NewRangeReader(ctx, file.ID, 0, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file for scanning: %w", err)
+	}
+	result, err := s.scanner.Scan(ctx, reader.Content)
+	reader.Content.Close()
+
+	now := time.Now()
+	file.FileScan = domain.FileScan{ScannedAt: &now}
+	switch {
+	case err != nil:
+		// Keep the upload but block downloads until a rescan succeeds
+		log.Printf("Failed to scan %s: %v", file.ID, err)
+		file.ScanStatus = domain.ScanStatusFailed
+		return nil, nil
+	case result.Clean:
+		file.ScanStatus = domain.ScanStatusClean
+		return nil, nil
+	}
+
+	file.ScanStatus = domain.ScanStatusInfected
+	file.ScanThreat = result.Threat
+	rejection := &domain.ScanRejection{FileID: file.ID, Threat: result.Threat}
+	if err := s.quarantine(ctx, file); err != nil {
+		return nil, err
+	}
+	return rejection, nil
+}
+
+// quarantine moves the infected content of file under the quarantine prefix. If
+// versioning kept the content it replaced, that content becomes live again and
+// file describes it; otherwise only the quarantined copy remains.
+func (s *FileService) quarantine(ctx context.Context, file *domain.File) error {
+	reader, err := s.fileRepo.NewRangeReader(ctx, file.ID, 0, -1)
+	if err != nil {
+		return fmt.Errorf("failed to open infected file: %w", err)
+	}
+	quarantined, err := s.fileRepo.CreateFromReader(ctx, &domain.CreateFileStreamRequest{
+		Name:        s.quarantinePrefix + file.ID,
+		ContentType: file.ContentType,
+		Content:     reader.Content,
+	})
+	reader.Content.Close()
+	if err != nil {
+		return fmt.Errorf("failed to quarantine file: %w", err)
+	}
+
+	if s.versions != nil {
+		restored, err := s.restoreReplaced(ctx, file)
+		if err != nil {
+			return err
+		}
+		if restored != nil {
+			// Without a verdict of its own the restored content keeps its recorded one
+			restored.FileAccess = file.FileAccess
+			*file = *restored
+			return nil
+		}
+	}
+
+	if err := s.fileRepo.Delete(ctx, file.ID); err != nil {
+		return fmt.Errorf("failed to remove infected file: %w", err)
+	}
+	file.Path = quarantined.Path
+	return nil
+}
+
+// restoreReplaced makes the content replaced by file live again and drops the
+// version file itself became. It returns nil if file replaced nothing.
+func (s *FileService) restoreReplaced(ctx context.Context, file *domain.File) (*domain.File, error) {
+	versions, err := s.versions.ListVersions(ctx, file.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file versions: %w", err)
+	}
+
+	for _, version := range versions {
+		if version.Live || version.Generation == file.Generation {
+			continue
+		}
+		restored, err := s.versions.RestoreVersion(ctx, file.ID, version.Generation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore replaced content: %w", err)
+		}
+		if err := s.versions.DeleteVersion(ctx, file.ID, file.Generation); err != nil && !errors.Is(err, domain.ErrVersionNotFound) {
+			return nil, fmt.Errorf("failed to delete infected version: %w", err)
+		}
+		return restored, nil
+	}
+	return nil, nil
+}
+
+// quarantined reports whether a storage object holds quarantined content
+func (s *FileService) quarantined(id string) bool {
+	return s.scanner != nil && strings.HasPrefix(id, s.quarantinePrefix)
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScanner reports content containing "EICAR" as infected and fails while unavailable is set
+type fakeScanner struct {
+	unavailable bool
+}
+
+func (s *fakeScanner) Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	if s.unavailable {
+		return nil, errors.New("scanner unavailable")
+	}
+	if bytes.Contains(data, []byte("EICAR")) {
+		return &domain.ScanResult{Threat: "Eicar-Test-Signature"}, nil
+	}
+	return &domain.ScanResult{Clean: true}, nil
+}
+
+func TestFileService_Scanning(t *testing.T) {
+	ctx := context.Background()
+	_, repo, db := setupFileServiceWithMetadata(t)
+	require.NoError(t, db.AutoMigrate(&database.FileVersionScan{}))
+	scanner := &fakeScanner{}
+	service := NewFileService(repo,
+		WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		WithVersioning(repo, domain.VersionRetention{MaxVersions: 10}),
+		WithScanner(scanner, ""),
+	)
+
+	create := func(name, content string) (*domain.File, error) {
+		return service.CreateFileFromReader(ctx, &domain.CreateFileStreamRequest{
+			Name:    name,
+			Content: strings.NewReader(content),
+		})
+	}
+
+	t.Run("Clean uploads are downloadable", func(t *testing.T) {
+		file, err := create("clean.txt", "hello")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusClean, file.ScanStatus)
+		assert.NotNil(t, file.ScannedAt)
+
+		file, err = service.GetFile(ctx, "clean.txt")
+		require.NoError(t, err)
+		assert.NoError(t, file.DownloadError())
+	})
+
+	t.Run("Infected uploads are quarantined", func(t *testing.T) {
+		_, err := create("virus.txt", "EICAR")
+		var rejection *domain.ScanRejection
+		require.True(t, errors.As(err, &rejection))
+		assert.Equal(t, "Eicar-Test-Signature", rejection.Threat)
+
+		exists, err := repo.Exists(ctx, "virus.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = repo.Exists(ctx, "quarantine/virus.txt")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		file, err := service.GetFile(ctx, "virus.txt")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusInfected, file.ScanStatus)
+		assert.ErrorIs(t, file.DownloadError(), domain.ErrFileQuarantined)
+
+		// Quarantined objects are neither addressable nor listed
+		_, err = service.GetFile(ctx, "quarantine/virus.txt")
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+		report, err := service.ReconcileMetadata(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, report.Added)
+		assert.Empty(t, report.Removed)
+
+		require.NoError(t, service.DeleteFile(ctx, "virus.txt"))
+		exists, err = repo.Exists(ctx, "quarantine/virus.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Infected updates restore the replaced content", func(t *testing.T) {
+		_, err := service.ReplaceFileContent(ctx, "clean.txt", &domain.CreateFileStreamRequest{
+			Content: strings.NewReader("EICAR"),
+		})
+		assert.ErrorIs(t, err, domain.ErrFileInfected)
+
+		content, err := service.GetFileContent(ctx, "clean.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(content.Content))
+
+		file, err := service.GetFile(ctx, "clean.txt")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusClean, file.ScanStatus)
+	})
+
+	t.Run("Scanner failures block downloads until rescanned", func(t *testing.T) {
+		scanner.unavailable = true
+		file, err := create("later.txt", "content")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusFailed, file.ScanStatus)
+		assert.ErrorIs(t, file.DownloadError(), domain.ErrFileNotScanned)
+
+		scanner.unavailable = false
+		file, err = service.ScanFile(ctx, "later.txt")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusClean, file.ScanStatus)
+
+		file, err = service.GetFile(ctx, "later.txt")
+		require.NoError(t, err)
+		assert.NoError(t, file.DownloadError())
+	})
+
+	t.Run("Renames keep the verdict", func(t *testing.T) {
+		file, err := service.UpdateFile(ctx, "later.txt", &domain.UpdateFileRequest{Name: "renamed.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusClean, file.ScanStatus)
+	})
+
+	t.Run("Versions keep their own verdict", func(t *testing.T) {
+		replace := func(id, content string) {
+			_, err := service.ReplaceFileContent(ctx, id, &domain.CreateFileStreamRequest{Content: strings.NewReader(content)})
+			require.NoError(t, err)
+		}
+		// verdicts returns the download errors of the versions of a file, oldest first
+		verdicts := func(id string) []error {
+			versions, err := service.ListFileVersions(ctx, id)
+			require.NoError(t, err)
+			errs := make([]error, len(versions))
+			for i, version := range versions {
+				scan, err := service.VersionScan(ctx, id, version.Generation)
+				require.NoError(t, err)
+				errs[len(versions)-1-i] = scan.DownloadError()
+			}
+			return errs
+		}
+
+		_, err := create("history.txt", "v1")
+		require.NoError(t, err)
+		scanner.unavailable = true
+		replace("history.txt", "v2")
+		scanner.unavailable = false
+		replace("history.txt", "v3")
+
+		file, err := service.GetFile(ctx, "history.txt")
+		require.NoError(t, err)
+		assert.NoError(t, file.DownloadError())
+		assert.Equal(t, []error{nil, domain.ErrFileNotScanned, nil}, verdicts("history.txt"),
+			"an unscanned earlier version stays blocked while the live one is clean")
+
+		_, err = service.UpdateFile(ctx, "history.txt", &domain.UpdateFileRequest{Name: "moved.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, []error{nil, domain.ErrFileNotScanned, nil}, verdicts("moved.txt"))
+
+		scan, err := service.VersionScan(ctx, "moved.txt", 1000)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ScanStatusPending, scan.ScanStatus, "versions without a verdict are not served")
+	})
+
+	t.Run("Quarantine prefix is reserved", func(t *testing.T) {
+		_, err := create("quarantine/evil.txt", "hello")
+		assert.ErrorIs(t, err, domain.ErrInvalidFileName)
+	})
+}
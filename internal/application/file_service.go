@@ -2,23 +2,71 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/TRu-S3/backend/internal/domain"
 )
 
+// defaultSignedURLTTL is how long signed URLs stay valid unless configured otherwise
+const defaultSignedURLTTL = 15 * time.Minute
+
 // FileService represents the application service for file operations
 type FileService struct {
-	fileRepo domain.FileRepository
+	fileRepo         domain.FileRepository
+	metadataRepo     domain.FileMetadataRepository
+	accessRepo       domain.FileAccessRepository
+	imageProcessor   domain.ImageProcessor
+	versions         domain.FileVersionRepository
+	retention        domain.VersionRetention
+	policy           domain.UploadPolicy
+	scanner          domain.FileScanner
+	quarantinePrefix string
+	uploadSessions   domain.UploadSessionRepository
+	uploadSessionTTL time.Duration
+	signedUploads    domain.SignedUploadRepository
+	signer           domain.URLSigner
+	signedURLTTL     time.Duration
+}
+
+// FileServiceOption configures optional FileService dependencies
+type FileServiceOption func(*FileService)
+
+// WithURLSigner enables signed URL issuance with URLs valid for ttl
+func WithURLSigner(signer domain.URLSigner, ttl time.Duration) FileServiceOption {
+	return func(s *FileService) {
+		s.signer = signer
+		if ttl > 0 {
+			s.signedURLTTL = ttl
+		}
+	}
+}
+
+// WithMetadataRepository keeps the file_metadata table in sync with storage and
+// serves listings from it
+func WithMetadataRepository(metadataRepo domain.FileMetadataRepository) FileServiceOption {
+	return func(s *FileService) {
+		s.metadataRepo = metadataRepo
+	}
 }
 
 // NewFileService creates a new FileService
-func NewFileService(fileRepo domain.FileRepository) *FileService {
-	return &FileService{
-		fileRepo: fileRepo,
+func NewFileService(fileRepo domain.FileRepository, opts ...FileServiceOption) *FileService {
+	s := &FileService{
+		fileRepo:         fileRepo,
+		signedURLTTL:     defaultSignedURLTTL,
+		quarantinePrefix: defaultQuarantinePrefix,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateFile creates a new file
@@ -33,6 +81,13 @@ func (s *FileService) CreateFile(ctx context.Context, req *domain.CreateFileRequ
 		req.ContentType = s.detectContentType(req.Name)
 	}
 
+	if err := s.checkType(req.ContentType, nil); err != nil {
+		return nil, err
+	}
+	if err := s.checkSize(ctx, nil, nil, 0, int64(len(req.Content))); err != nil {
+		return nil, err
+	}
+
 	// Check if file already exists
 	exists, err := s.fileRepo.Exists(ctx, req.Name)
 	if err != nil {
@@ -43,9 +98,89 @@ func (s *FileService) CreateFile(ctx context.Context, req *domain.CreateFileRequ
 	}
 
 	// Create the file
-	file, err := s.fileRepo.Create(ctx, req)
+	var file *domain.File
+	var rejection *domain.ScanRejection
+	err = s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		created, err := s.fileRepo.Create(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		file = created
+		if rejection, err = s.scanStored(ctx, created); err != nil {
+			return err
+		}
+		return s.recordCreated(ctx, meta, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejection != nil {
+		return nil, rejection
+	}
+
+	return file, nil
+}
+
+// CreateFileFromReader creates a new file, streaming its content into storage
+func (s *FileService) CreateFileFromReader(ctx context.Context, req *domain.CreateFileStreamRequest) (*domain.File, error) {
+	// Validate file name
+	if err := s.validateFileName(req.Name); err != nil {
+		return nil, err
+	}
+
+	// Set default content type if not provided
+	if req.ContentType == "" {
+		req.ContentType = s.detectContentType(req.Name)
+	}
+
+	access, err := s.resolveAccess(ctx, req.Access)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createFromReader(ctx, req, access)
+}
+
+// createFromReader stores a new file whose access has already been resolved
+func (s *FileService) createFromReader(ctx context.Context, req *domain.CreateFileStreamRequest, access domain.FileAccess) (*domain.File, error) {
+	if err := s.checkType(req.ContentType, access.Attachment); err != nil {
+		return nil, err
+	}
+
+	// Check if file already exists
+	exists, err := s.fileRepo.Exists(ctx, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check file existence: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrFileAlreadyExists
+	}
+
+	limited := *req
+	limited.Content, err = s.limitUpload(ctx, access.UploaderID, 0, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *domain.File
+	var rejection *domain.ScanRejection
+	err = s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		created, err := s.fileRepo.CreateFromReader(ctx, &limited)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		created.FileAccess = access
+		file = created
+		if rejection, err = s.scanStored(ctx, created); err != nil {
+			return err
+		}
+		return s.recordCreated(ctx, meta, created)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create file: %w", err)
+		return nil, err
+	}
+	if rejection != nil {
+		return nil, rejection
 	}
 
 	return file, nil
@@ -57,10 +192,23 @@ func (s *FileService) GetFile(ctx context.Context, id string) (*domain.File, err
 		return nil, domain.ErrInvalidFileName
 	}
 
+	if s.reserved(id) {
+		return nil, domain.ErrFileNotFound
+	}
+
 	file, err := s.fileRepo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrFileNotFound) && s.metadataRepo != nil {
+		// Quarantined files only remain as their metadata row
+		if recorded, getErr := s.metadataRepo.Get(ctx, id); getErr == nil && recorded.ScanStatus == domain.ScanStatusInfected {
+			return recorded, nil
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file: %w", err)
 	}
+	if err := s.loadAccess(ctx, file); err != nil {
+		return nil, fmt.Errorf("failed to get file access: %w", err)
+	}
 
 	return file, nil
 }
@@ -79,8 +227,25 @@ func (s *FileService) GetFileContent(ctx context.Context, id string) (*domain.Fi
 	return fileData, nil
 }
 
-// ListFiles retrieves a list of files based on query parameters
-func (s *FileService) ListFiles(ctx context.Context, query *domain.FileQuery) ([]*domain.File, error) {
+// OpenFileRange opens a reader over length bytes of file content starting at offset.
+// A negative length reads to the end of the file.
+func (s *FileService) OpenFileRange(ctx context.Context, id string, offset, length int64) (*domain.FileReader, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidFileName
+	}
+
+	reader, err := s.fileRepo.NewRangeReader(ctx, id, offset, length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file content: %w", err)
+	}
+
+	return reader, nil
+}
+
+// ListFiles retrieves a page of the files visible to viewer and the total number of matches.
+// Listings come from the metadata table when configured; storage listings only
+// support the prefix filter and report the page size as the total.
+func (s *FileService) ListFiles(ctx context.Context, viewer *domain.FileViewer, query *domain.FileQuery) ([]*domain.File, int64, error) {
 	// Set default limits
 	if query.Limit <= 0 {
 		query.Limit = 100
@@ -89,12 +254,48 @@ func (s *FileService) ListFiles(ctx context.Context, query *domain.FileQuery) ([
 		query.Limit = 1000
 	}
 
-	files, err := s.fileRepo.List(ctx, query)
+	if query.Attachment != nil {
+		if err := validateAttachment(query.Attachment); err != nil {
+			return nil, 0, err
+		}
+		if s.accessRepo != nil {
+			if _, err := s.accessRepo.AttachmentOwners(ctx, *query.Attachment); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	if s.metadataRepo != nil {
+		access, err := s.listAccessFilter(ctx, viewer, query.Attachment)
+		if err != nil {
+			return nil, 0, err
+		}
+		query.Access = access
+
+		files, total, err := s.metadataRepo.List(ctx, query)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list files: %w", err)
+		}
+		return files, total, nil
+	}
+
+	// Without the metadata table nothing can be attached
+	if query.Attachment != nil {
+		return []*domain.File{}, 0, nil
+	}
+
+	stored, err := s.fileRepo.List(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list files: %w", err)
+		return nil, 0, fmt.Errorf("failed to list files: %w", err)
+	}
+	files := make([]*domain.File, 0, len(stored))
+	for _, file := range stored {
+		if !s.reserved(file.ID) {
+			files = append(files, file)
+		}
 	}
 
-	return files, nil
+	return files, int64(len(files)), nil
 }
 
 // UpdateFile updates an existing file
@@ -103,53 +304,385 @@ func (s *FileService) UpdateFile(ctx context.Context, id string, req *domain.Upd
 		return nil, domain.ErrInvalidFileName
 	}
 
+	// New content is streamed through the same checks as a fresh upload
+	if req.Content != nil {
+		return s.ReplaceFileContent(ctx, id, &domain.CreateFileStreamRequest{
+			Name:        req.Name,
+			ContentType: req.ContentType,
+			Content:     req.Content,
+		})
+	}
+
 	// Validate new file name if provided
 	if req.Name != "" {
 		if err := s.validateFileName(req.Name); err != nil {
 			return nil, err
 		}
+		if err := s.checkRenameTarget(ctx, id, req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rename the file
+	var file *domain.File
+	err := s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		renamed := req.Name != "" && req.Name != id
+		var history []*domain.FileVersion
+		if renamed && meta != nil && s.scansVersions() {
+			var err error
+			if history, err = s.versions.ListVersions(ctx, id); err != nil {
+				return fmt.Errorf("failed to list file versions: %w", err)
+			}
+		}
+
+		updated, err := s.fileRepo.Update(ctx, id, req)
+		if err != nil {
+			return fmt.Errorf("failed to update file: %w", err)
+		}
+		file = updated
+		if renamed {
+			if err := s.moveVersionScans(ctx, meta, id, updated.ID, history); err != nil {
+				return err
+			}
+		}
+		return s.recordUpdated(ctx, meta, id, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.pruneVersions(ctx, file.ID)
+
+	return file, nil
+}
+
+// ReplaceFileContent streams new content into an existing file, optionally renaming it
+func (s *FileService) ReplaceFileContent(ctx context.Context, id string, req *domain.CreateFileStreamRequest) (*domain.File, error) {
+	if id == "" {
+		return nil, domain.ErrInvalidFileName
 	}
 
-	// Set default content type if not provided but content is provided
-	if req.Content != nil && req.ContentType == "" {
-		if req.Name != "" {
+	existingFile, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing file: %w", err)
+	}
+
+	if req.Name == "" {
+		req.Name = id
+	}
+	if err := s.validateFileName(req.Name); err != nil {
+		return nil, err
+	}
+	if err := s.checkRenameTarget(ctx, id, req.Name); err != nil {
+		return nil, err
+	}
+	if req.ContentType == "" {
+		if req.Name != id {
 			req.ContentType = s.detectContentType(req.Name)
 		} else {
-			// Get existing file to determine content type
-			existingFile, err := s.fileRepo.GetByID(ctx, id)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get existing file: %w", err)
-			}
 			req.ContentType = s.detectContentType(existingFile.Name)
 		}
 	}
+	if err := s.checkType(req.ContentType, existingFile.Attachment); err != nil {
+		return nil, err
+	}
+	limited := *req
+	limited.Content, err = s.limitUpload(ctx, existingFile.UploaderID, existingFile.Size, req.Content)
+	if err != nil {
+		return nil, err
+	}
 
-	// Update the file
-	file, err := s.fileRepo.Update(ctx, id, req)
+	var file *domain.File
+	var rejection *domain.ScanRejection
+	err = s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		// Move the history along with a renamed file so the old content stays restorable
+		if req.Name != id && s.versions != nil {
+			var history []*domain.FileVersion
+			if meta != nil && s.scansVersions() {
+				if history, err = s.versions.ListVersions(ctx, id); err != nil {
+					return fmt.Errorf("failed to list file versions: %w", err)
+				}
+			}
+			if _, err := s.versions.RenameFile(ctx, id, req.Name); err != nil {
+				return fmt.Errorf("failed to rename file: %w", err)
+			}
+			if err := s.moveVersionScans(ctx, meta, id, req.Name, history); err != nil {
+				return err
+			}
+		}
+
+		updated, err := s.fileRepo.CreateFromReader(ctx, &limited)
+		if err != nil {
+			return fmt.Errorf("failed to update file: %w", err)
+		}
+		file = updated
+
+		// Delete old file if renamed
+		if req.Name != id && s.versions == nil {
+			if err := s.fileRepo.Delete(ctx, id); err != nil {
+				return fmt.Errorf("failed to delete old file: %w", err)
+			}
+		}
+		if rejection, err = s.scanStored(ctx, updated); err != nil {
+			return err
+		}
+		return s.recordUpdated(ctx, meta, id, updated)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update file: %w", err)
+		return nil, err
+	}
+	s.pruneVersions(ctx, file.ID)
+	if rejection != nil {
+		return nil, rejection
 	}
 
 	return file, nil
 }
 
-// DeleteFile deletes a file by its ID
+// IssueDownloadURL issues a signed URL for downloading a file directly from storage
+func (s *FileService) IssueDownloadURL(ctx context.Context, id string) (*domain.SignedURL, error) {
+	if s.signer == nil {
+		return nil, domain.ErrSignedURLUnsupported
+	}
+	if id == "" {
+		return nil, domain.ErrInvalidFileName
+	}
+
+	exists, err := s.fileRepo.Exists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check file existence: %w", err)
+	}
+	if !exists {
+		return nil, domain.ErrFileNotFound
+	}
+
+	signedURL, err := s.signer.SignURL(ctx, &domain.SignedURLRequest{
+		FileID:    id,
+		Method:    http.MethodGet,
+		ExpiresAt: time.Now().Add(s.signedURLTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue download URL: %w", err)
+	}
+
+	return signedURL, nil
+}
+
+// DeleteFile deletes a file by its ID together with its earlier versions
 func (s *FileService) DeleteFile(ctx context.Context, id string) error {
 	if id == "" {
 		return domain.ErrInvalidFileName
 	}
 
-	err := s.fileRepo.Delete(ctx, id)
+	// The row is only removed if the object could be deleted
+	return s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		objectID := id
+		if meta != nil {
+			// Quarantined files only have their quarantined copy left
+			if row, err := meta.Get(ctx, id); err == nil && row.ScanStatus == domain.ScanStatusInfected {
+				objectID = s.quarantinePrefix + id
+			}
+			if err := meta.Delete(ctx, id); err != nil {
+				return fmt.Errorf("failed to delete file metadata: %w", err)
+			}
+			if s.scansVersions() {
+				if err := meta.DeleteVersionScans(ctx, id); err != nil {
+					return err
+				}
+			}
+		}
+		if err := s.fileRepo.Delete(ctx, objectID); err != nil {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReconcileReport lists the metadata rows repaired by ReconcileMetadata
+type ReconcileReport struct {
+	Added   []string `json:"added"`
+	Updated []string `json:"updated"`
+	Removed []string `json:"removed"`
+}
+
+// ReconcileMetadata repairs drift between storage and the metadata table: rows are
+// added for untracked objects, refreshed when attributes differ and removed when
+// their object no longer exists. With a scanner configured, added and refreshed rows
+// are marked pending until scanned. With dryRun the report is computed without changes.
+func (s *FileService) ReconcileMetadata(ctx context.Context, dryRun bool) (*ReconcileReport, error) {
+	if s.metadataRepo == nil {
+		return nil, fmt.Errorf("file metadata repository is not configured")
+	}
+
+	stored, err := s.fileRepo.List(ctx, &domain.FileQuery{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stored files: %w", err)
+	}
+	recorded, err := s.metadataRepo.ListAll(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to delete file: %w", err)
+		return nil, err
+	}
+
+	recordedByID := make(map[string]*domain.File, len(recorded))
+	for _, file := range recorded {
+		recordedByID[file.ID] = file
+	}
+
+	report := &ReconcileReport{Added: []string{}, Updated: []string{}, Removed: []string{}}
+	var upserts []*domain.File
+	for _, file := range stored {
+		if s.reserved(file.ID) {
+			continue
+		}
+		row, ok := recordedByID[file.ID]
+		delete(recordedByID, file.ID)
+		switch {
+		case !ok:
+			// Objects written outside the service stay admin-only until an admin shares them
+			report.Added = append(report.Added, file.ID)
+			file.FileAccess = untrackedAccess
+		case row.Path != file.Path || row.Size != file.Size || row.ContentType != file.ContentType ||
+			row.ETag != file.ETag || row.Generation != file.Generation:
+			report.Updated = append(report.Updated, file.ID)
+			file.FileAccess = row.FileAccess
+		default:
+			continue
+		}
+		// Content that changed outside the service still needs to be scanned
+		if s.scanner != nil {
+			file.ScanStatus = domain.ScanStatusPending
+		}
+		upserts = append(upserts, file)
+	}
+	for id, row := range recordedByID {
+		// Quarantined files have no object under their own ID
+		if row.ScanStatus == domain.ScanStatusInfected {
+			continue
+		}
+		report.Removed = append(report.Removed, id)
+	}
+	sort.Strings(report.Removed)
+
+	if dryRun {
+		return report, nil
+	}
+
+	err = s.metadataRepo.Transaction(ctx, func(meta domain.FileMetadataRepository) error {
+		for _, file := range upserts {
+			if err := meta.Save(ctx, file); err != nil {
+				return err
+			}
+		}
+		for _, id := range report.Removed {
+			if err := meta.Delete(ctx, id); err != nil {
+				return err
+			}
+			if s.scansVersions() {
+				if err := meta.DeleteVersionScans(ctx, id); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// withMetadata runs a storage change inside a metadata transaction so that the
+// table only changes when storage succeeded. Without a metadata repository, fn
+// receives nil and runs on its own.
+func (s *FileService) withMetadata(ctx context.Context, fn func(meta domain.FileMetadataRepository) error) error {
+	if s.metadataRepo == nil {
+		return fn(nil)
+	}
+	return s.metadataRepo.Transaction(ctx, fn)
+}
+
+// recordCreated saves the metadata of a newly created file, removing the object
+// again if the row cannot be written so storage and table do not drift
+func (s *FileService) recordCreated(ctx context.Context, meta domain.FileMetadataRepository, file *domain.File) error {
+	if meta == nil {
+		return nil
+	}
+	// Recheck the quota since concurrent uploads may have used it up meanwhile
+	if err := s.checkSize(ctx, meta, file.UploaderID, 0, file.Size); err != nil {
+		if delErr := s.fileRepo.Delete(ctx, file.ID); delErr != nil {
+			log.Printf("Failed to remove rejected file %s: %v", file.ID, delErr)
+		}
+		return err
 	}
+	if err := meta.Save(ctx, file); err != nil {
+		if delErr := s.fileRepo.Delete(ctx, file.ID); delErr != nil {
+			log.Printf("Failed to remove untracked file %s: %v", file.ID, delErr)
+		}
+		return fmt.Errorf("failed to record file metadata: %w", err)
+	}
+	return s.recordVersionScan(ctx, meta, file)
+}
 
+// recordUpdated saves the metadata of an updated file and drops the row of its old ID.
+// The access attributes recorded under the old ID carry over to the new content; a
+// file that had no row stays admin-only.
+func (s *FileService) recordUpdated(ctx context.Context, meta domain.FileMetadataRepository, oldID string, file *domain.File) error {
+	if meta == nil {
+		return nil
+	}
+
+	file.FileAccess = untrackedAccess
+	previous, err := meta.Get(ctx, oldID)
+	switch {
+	case err == nil:
+		file.FileAccess = previous.FileAccess
+		// Content that was not scanned, e.g. after a rename, keeps its verdict
+		if file.ScanStatus == "" {
+			file.FileScan = previous.FileScan
+		}
+	case !errors.Is(err, domain.ErrFileNotFound):
+		return err
+	}
+
+	if oldID != file.ID {
+		if err := meta.Delete(ctx, oldID); err != nil {
+			return fmt.Errorf("failed to delete file metadata: %w", err)
+		}
+	}
+	if err := meta.Save(ctx, file); err != nil {
+		return fmt.Errorf("failed to record file metadata: %w", err)
+	}
+	return s.recordVersionScan(ctx, meta, file)
+}
+
+// checkRenameTarget returns ErrFileAlreadyExists if renaming id to name would replace
+// another file, including quarantined files that only have a metadata row left
+func (s *FileService) checkRenameTarget(ctx context.Context, id, name string) error {
+	if name == id {
+		return nil
+	}
+	exists, err := s.fileRepo.Exists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check file existence: %w", err)
+	}
+	if exists {
+		return domain.ErrFileAlreadyExists
+	}
+	if s.metadataRepo != nil {
+		_, err := s.metadataRepo.Get(ctx, name)
+		switch {
+		case err == nil:
+			return domain.ErrFileAlreadyExists
+		case !errors.Is(err, domain.ErrFileNotFound):
+			return err
+		}
+	}
 	return nil
 }
 
 // validateFileName validates the file name
 func (s *FileService) validateFileName(name string) error {
-	if name == "" {
+	if name == "" || s.reserved(name) {
 		return domain.ErrInvalidFileName
 	}
 
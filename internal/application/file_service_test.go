@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupFileServiceWithMetadata(t *testing.T) (*FileService, *infrastructure.LocalFileRepository, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}))
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	service := NewFileService(repo, WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)))
+	return service, repo, db
+}
+
+func countFileMetadata(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Model(&database.FileMetadata{}).Count(&count).Error)
+	return count
+}
+
+func TestFileService_KeepsMetadataInSync(t *testing.T) {
+	ctx := context.Background()
+	service, _, db := setupFileServiceWithMetadata(t)
+
+	_, err := service.CreateFile(ctx, &domain.CreateFileRequest{Name: "a.txt", Content: []byte("hello")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), countFileMetadata(t, db))
+
+	_, err = service.UpdateFile(ctx, "a.txt", &domain.UpdateFileRequest{Name: "b.txt", Content: strings.NewReader("hello again")})
+	require.NoError(t, err)
+
+	files, total, err := service.ListFiles(ctx, nil, &domain.FileQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, files, 1)
+	assert.Equal(t, "b.txt", files[0].ID)
+	assert.Equal(t, int64(11), files[0].Size)
+
+	require.NoError(t, service.DeleteFile(ctx, "b.txt"))
+	assert.Equal(t, int64(0), countFileMetadata(t, db))
+
+	// A failed storage delete leaves the row in place
+	_, err = service.CreateFile(ctx, &domain.CreateFileRequest{Name: "c.txt", Content: []byte("c")})
+	require.NoError(t, err)
+	assert.Error(t, service.DeleteFile(ctx, "missing.txt"))
+	assert.Equal(t, int64(1), countFileMetadata(t, db))
+}
+
+func TestFileService_ReconcileMetadata(t *testing.T) {
+	ctx := context.Background()
+	service, repo, db := setupFileServiceWithMetadata(t)
+
+	_, err := service.CreateFile(ctx, &domain.CreateFileRequest{Name: "kept.txt", Content: []byte("kept")})
+	require.NoError(t, err)
+	_, err = service.CreateFile(ctx, &domain.CreateFileRequest{Name: "changed.txt", Content: []byte("v1")})
+	require.NoError(t, err)
+	_, err = service.CreateFile(ctx, &domain.CreateFileRequest{Name: "gone.txt", Content: []byte("gone")})
+	require.NoError(t, err)
+
+	// Drift: storage is modified behind the service's back
+	_, err = repo.Create(ctx, &domain.CreateFileRequest{Name: "untracked.txt", Content: []byte("new"), ContentType: "text/plain"})
+	require.NoError(t, err)
+	_, err = repo.Update(ctx, "changed.txt", &domain.UpdateFileRequest{Content: strings.NewReader("version 2")})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, "gone.txt"))
+
+	expected := &ReconcileReport{
+		Added:   []string{"untracked.txt"},
+		Updated: []string{"changed.txt"},
+		Removed: []string{"gone.txt"},
+	}
+
+	t.Run("Dry run reports without changes", func(t *testing.T) {
+		report, err := service.ReconcileMetadata(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, expected, report)
+		assert.Equal(t, int64(3), countFileMetadata(t, db))
+	})
+
+	t.Run("Repairs drift", func(t *testing.T) {
+		report, err := service.ReconcileMetadata(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, expected, report)
+
+		files, _, err := service.ListFiles(ctx, &domain.FileViewer{Admin: true}, &domain.FileQuery{})
+		require.NoError(t, err)
+		ids := make([]string, 0, len(files))
+		for _, file := range files {
+			ids = append(ids, file.ID)
+		}
+		assert.Equal(t, []string{"changed.txt", "kept.txt", "untracked.txt"}, ids)
+		assert.Equal(t, int64(9), files[0].Size)
+		assert.Equal(t, domain.FileVisibilityPrivate, files[2].Visibility, "objects written outside the service are admin-only")
+
+		files, _, err = service.ListFiles(ctx, nil, &domain.FileQuery{})
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
+	t.Run("Second run is a no-op", func(t *testing.T) {
+		report, err := service.ReconcileMetadata(ctx, false)
+		require.NoError(t, err)
+		assert.Empty(t, report.Added)
+		assert.Empty(t, report.Updated)
+		assert.Empty(t, report.Removed)
+	})
+}
+
+func TestFileService_Versions(t *testing.T) {
+	ctx := context.Background()
+	service, repo, db := setupFileServiceWithMetadata(t)
+	WithVersioning(repo, domain.VersionRetention{MaxVersions: 2})(service)
+
+	file, err := service.CreateFileFromReader(ctx, &domain.CreateFileStreamRequest{Name: "a.txt", Content: strings.NewReader("v1")})
+	require.NoError(t, err)
+	written := []*domain.File{file}
+	for _, content := range []string{"v2", "v3", "v4"} {
+		file, err := service.ReplaceFileContent(ctx, "a.txt", &domain.CreateFileStreamRequest{Content: strings.NewReader(content)})
+		require.NoError(t, err)
+		written = append(written, file)
+	}
+
+	t.Run("Retention keeps the newest replaced versions", func(t *testing.T) {
+		versions, err := service.ListFileVersions(ctx, "a.txt")
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		assert.Equal(t, written[3].Generation, versions[0].Generation)
+		assert.Equal(t, written[2].Generation, versions[1].Generation)
+		assert.Equal(t, written[1].Generation, versions[2].Generation)
+	})
+
+	t.Run("Restore records the new generation", func(t *testing.T) {
+		restored, err := service.RestoreFileVersion(ctx, "a.txt", written[1].Generation)
+		require.NoError(t, err)
+
+		var row database.FileMetadata
+		require.NoError(t, db.Where("name = ?", "a.txt").First(&row).Error)
+		assert.Equal(t, restored.Generation, row.Generation)
+
+		_, err = service.RestoreFileVersion(ctx, "a.txt", written[0].Generation)
+		assert.ErrorIs(t, err, domain.ErrVersionNotFound)
+	})
+
+	t.Run("Max age", func(t *testing.T) {
+		WithVersioning(repo, domain.VersionRetention{MaxAge: time.Nanosecond})(service)
+		time.Sleep(time.Millisecond)
+		pruned, err := service.PruneFileVersions(ctx, "a.txt")
+		require.NoError(t, err)
+		assert.Equal(t, 2, pruned)
+
+		versions, err := service.ListFileVersions(ctx, "a.txt")
+		require.NoError(t, err)
+		assert.Len(t, versions, 1)
+	})
+
+	t.Run("Unsupported without a version repository", func(t *testing.T) {
+		_, err := NewFileService(repo).ListFileVersions(ctx, "a.txt")
+		assert.ErrorIs(t, err, domain.ErrVersioningUnsupported)
+	})
+}
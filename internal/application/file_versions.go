@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// WithVersioning enables listing, downloading and restoring earlier versions of
+// files. Replaced versions beyond the retention policy are pruned whenever a file changes.
+func WithVersioning(versions domain.FileVersionRepository, retention domain.VersionRetention) FileServiceOption {
+	return func(s *FileService) {
+		s.versions = versions
+		s.retention = retention
+	}
+}
+
+// ListFileVersions lists the live and replaced versions of a file, newest first
+func (s *FileService) ListFileVersions(ctx context.Context, id string) ([]*domain.FileVersion, error) {
+	if s.versions == nil {
+		return nil, domain.ErrVersioningUnsupported
+	}
+	if id == "" {
+		return nil, domain.ErrInvalidFileName
+	}
+
+	versions, err := s.versions.ListVersions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file versions: %w", err)
+	}
+	return versions, nil
+}
+
+// OpenFileVersion opens a reader over length bytes of a specific version starting at offset.
+// A negative length reads to the end of the version.
+func (s *FileService) OpenFileVersion(ctx context.Context, id string, generation, offset, length int64) (*domain.FileReader, error) {
+	if s.versions == nil {
+		return nil, domain.ErrVersioningUnsupported
+	}
+	if id == "" {
+		return nil, domain.ErrInvalidFileName
+	}
+
+	reader, err := s.versions.NewVersionReader(ctx, id, generation, offset, length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file version: %w", err)
+	}
+	return reader, nil
+}
+
+// RestoreFileVersion makes a copy of an earlier version the live content of a file.
+// The content it replaces is kept as a version.
+func (s *FileService) RestoreFileVersion(ctx context.Context, id string, generation int64) (*domain.File, error) {
+	if s.versions == nil {
+		return nil, domain.ErrVersioningUnsupported
+	}
+	if id == "" {
+		return nil, domain.ErrInvalidFileName
+	}
+
+	var file *domain.File
+	var rejection *domain.ScanRejection
+	err := s.withMetadata(ctx, func(meta domain.FileMetadataRepository) error {
+		restored, err := s.versions.RestoreVersion(ctx, id, generation)
+		if err != nil {
+			return fmt.Errorf("failed to restore file version: %w", err)
+		}
+		file = restored
+		if rejection, err = s.scanStored(ctx, restored); err != nil {
+			return err
+		}
+		return s.recordUpdated(ctx, meta, id, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.pruneVersions(ctx, id)
+	if rejection != nil {
+		return nil, rejection
+	}
+
+	return file, nil
+}
+
+// PruneFileVersions deletes the replaced versions of a file that fall outside the
+// retention policy and returns how many were deleted
+func (s *FileService) PruneFileVersions(ctx context.Context, id string) (int, error) {
+	if s.versions == nil {
+		return 0, domain.ErrVersioningUnsupported
+	}
+
+	versions, err := s.versions.ListVersions(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list file versions: %w", err)
+	}
+
+	pruned := 0
+	kept := 0
+	now := time.Now()
+	for _, version := range versions {
+		if version.Live {
+			continue
+		}
+		expired := s.retention.MaxAge > 0 && version.ReplacedAt != nil && now.Sub(*version.ReplacedAt) > s.retention.MaxAge
+		if !expired && (s.retention.MaxVersions <= 0 || kept < s.retention.MaxVersions) {
+			kept++
+			continue
+		}
+
+		if err := s.versions.DeleteVersion(ctx, id, version.Generation); err != nil && !errors.Is(err, domain.ErrVersionNotFound) {
+			return pruned, fmt.Errorf("failed to delete file version: %w", err)
+		}
+		if s.scansVersions() && s.metadataRepo != nil {
+			if err := s.metadataRepo.DeleteVersionScans(ctx, id, version.Generation); err != nil {
+				return pruned, err
+			}
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// pruneVersions applies the retention policy after a file changed. Failures are
+// only logged since the change itself succeeded.
+func (s *FileService) pruneVersions(ctx context.Context, id string) {
+	if s.versions == nil {
+		return
+	}
+	if _, err := s.PruneFileVersions(ctx, id); err != nil {
+		log.Printf("Failed to prune versions of %s: %v", id, err)
+	}
+}
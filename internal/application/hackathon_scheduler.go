@@ -0,0 +1,26 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// HackathonScheduler moves hackathons through upcoming, ongoing and completed as
+// their start and end dates pass
+type HackathonScheduler struct {
+	repo domain.HackathonScheduleRepository
+	now  func() time.Time
+}
+
+// NewHackathonScheduler creates a new HackathonScheduler
+func NewHackathonScheduler(repo domain.HackathonScheduleRepository) *HackathonScheduler {
+	return &HackathonScheduler{repo: repo, now: time.Now}
+}
+
+// AdvanceStatuses applies every status transition that is due and returns them.
+// It is safe to call from several instances at once.
+func (s *HackathonScheduler) AdvanceStatuses(ctx context.Context) ([]*domain.HackathonStatusTransition, error) {
+	return s.repo.AdvanceStatuses(ctx, s.now())
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// WithImageProcessor enables image uploads with thumbnails
+func WithImageProcessor(processor domain.ImageProcessor) FileServiceOption {
+	return func(s *FileService) {
+		s.imageProcessor = processor
+	}
+}
+
+// UploadImage validates an image by its content and stores it, re-encoded without
+// metadata, together with its thumbnails in a new directory under req.Dir
+func (s *FileService) UploadImage(ctx context.Context, req *domain.UploadImageRequest) (*domain.StoredImage, error) {
+	if s.imageProcessor == nil {
+		return nil, fmt.Errorf("image processor is not configured")
+	}
+
+	access, err := s.resolveAccess(ctx, req.Access)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := s.imageProcessor.Process(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every upload gets its own directory so replaced images never collide
+	dir := path.Join(req.Dir, strconv.FormatInt(time.Now().UnixNano(), 36))
+	stored := &domain.StoredImage{Thumbnails: make(map[string]*domain.File, len(processed.Thumbnails))}
+	var created []string
+
+	store := func(name string, content []byte) (*domain.File, error) {
+		file, err := s.createFromReader(ctx, &domain.CreateFileStreamRequest{
+			Name:        path.Join(dir, name+processed.Extension),
+			ContentType: processed.ContentType,
+			Content:     bytes.NewReader(content),
+		}, access)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, file.ID)
+		return file, nil
+	}
+
+	if stored.Original, err = store("original", processed.Original); err == nil {
+		for _, thumbnail := range processed.Thumbnails {
+			size := strconv.Itoa(thumbnail.Size)
+			if stored.Thumbnails[size], err = store(size, thumbnail.Content); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		// Do not leave a partial set of renditions behind
+		if cleanupErr := s.DeleteFiles(ctx, created...); cleanupErr != nil {
+			return nil, errors.Join(err, cleanupErr)
+		}
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// DeleteFiles deletes the given files, ignoring those that no longer exist
+func (s *FileService) DeleteFiles(ctx context.Context, ids ...string) error {
+	var errs []error
+	for _, id := range ids {
+		if err := s.DeleteFile(ctx, id); err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
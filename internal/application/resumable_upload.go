@@ -0,0 +1,357 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// uploadPartsPrefix is where the chunks of resumable uploads are stored until they are assembled
+const uploadPartsPrefix = ".uploads/"
+
+// defaultUploadSessionTTL is how long an idle resumable upload is kept unless configured otherwise
+const defaultUploadSessionTTL = 24 * time.Hour
+
+// WithUploadSessions enables resumable uploads. Sessions expire after ttl without
+// receiving a chunk.
+func WithUploadSessions(sessions domain.UploadSessionRepository, ttl time.Duration) FileServiceOption {
+	return func(s *FileService) {
+		s.uploadSessions = sessions
+		s.uploadSessionTTL = ttl
+		if ttl <= 0 {
+			s.uploadSessionTTL = defaultUploadSessionTTL
+		}
+	}
+}
+
+// CreateUploadSession starts a resumable upload. The declared length is checked
+// against the upload policy up front so clients do not send content that would be
+// rejected once assembled.
+func (s *FileService) CreateUploadSession(ctx context.Context, req *domain.CreateUploadSessionRequest) (*domain.UploadSession, error) {
+	if s.uploadSessions == nil {
+		return nil, domain.ErrUploadSessionsUnsupported
+	}
+	if err := s.validateFileName(req.Name); err != nil {
+		return nil, err
+	}
+	if req.Length < 0 {
+		return nil, domain.ErrInvalidUploadLength
+	}
+	if req.ContentType == "" {
+		req.ContentType = s.detectContentType(req.Name)
+	}
+
+	access, err := s.resolveAccess(ctx, req.Access)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkType(req.ContentType, access.Attachment); err != nil {
+		return nil, err
+	}
+	if err := s.checkSize(ctx, s.metadataRepo, access.UploaderID, 0, req.Length); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.fileRepo.Exists(ctx, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check file existence: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrFileAlreadyExists
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, err
+	}
+	session := &domain.UploadSession{
+		ID:          id,
+		FileName:    req.Name,
+		ContentType: req.ContentType,
+		Length:      req.Length,
+		ExpiresAt:   time.Now().Add(s.uploadSessionTTL),
+		FileAccess:  access,
+	}
+	if err := s.uploadSessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetUploadSession retrieves a resumable upload of the viewer
+func (s *FileService) GetUploadSession(ctx context.Context, id string, viewer *domain.FileViewer) (*domain.UploadSession, error) {
+	if s.uploadSessions == nil {
+		return nil, domain.ErrUploadSessionsUnsupported
+	}
+	session, err := s.uploadSessions.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUploadSession(session, viewer); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// AppendUploadChunk stores the next chunk of a resumable upload. offset must match
+// the bytes received so far; chunks going past the declared length are rejected
+// without being stored. The session is locked while the chunk is written so
+// concurrent requests cannot store overlapping chunks.
+func (s *FileService) AppendUploadChunk(ctx context.Context, id string, viewer *domain.FileViewer, offset int64, content io.Reader) (*domain.UploadSession, error) {
+	if s.uploadSessions == nil {
+		return nil, domain.ErrUploadSessionsUnsupported
+	}
+
+	var session *domain.UploadSession
+	err := s.uploadSessions.Transaction(ctx, func(repo domain.UploadSessionRepository) error {
+		var err error
+		session, err = repo.GetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkUploadSession(session, viewer); err != nil {
+			return err
+		}
+		if offset != session.Offset {
+			return domain.ErrUploadOffsetMismatch
+		}
+
+		name := uploadPartName(session.ID, offset)
+		part, err := s.fileRepo.CreateFromReader(ctx, &domain.CreateFileStreamRequest{
+			Name:        name,
+			ContentType: "application/octet-stream",
+			Content: &limitedUpload{
+				r:         content,
+				remaining: session.Length - session.Offset,
+				rejection: domain.ErrUploadExceedsLength,
+			},
+		})
+		if err != nil {
+			if errors.Is(err, domain.ErrUploadExceedsLength) {
+				return err
+			}
+			return fmt.Errorf("failed to store upload chunk: %w", err)
+		}
+		if part.Size == 0 {
+			return s.fileRepo.Delete(ctx, name)
+		}
+
+		session.Offset += part.Size
+		session.ExpiresAt = time.Now().Add(s.uploadSessionTTL)
+		if err := repo.Update(ctx, session); err != nil {
+			if delErr := s.fileRepo.Delete(ctx, name); delErr != nil {
+				log.Printf("Failed to remove upload chunk %s: %v", name, delErr)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FinalizeUpload assembles a complete resumable upload into a file. The file goes
+// through the same policy and scanning as a direct upload. The session ends once
+// the file was stored or rejected; other failures keep it so finalizing can be retried.
+func (s *FileService) FinalizeUpload(ctx context.Context, id string, viewer *domain.FileViewer) (*domain.File, error) {
+	session, err := s.GetUploadSession(ctx, id, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Complete() {
+		return nil, domain.ErrUploadIncomplete
+	}
+
+	parts, err := s.uploadParts(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &uploadPartsReader{ctx: ctx, repo: s.fileRepo, parts: parts}
+	defer content.Close()
+	file, err := s.createFromReader(ctx, &domain.CreateFileStreamRequest{
+		Name:        session.FileName,
+		ContentType: session.ContentType,
+		Content:     content,
+	}, session.FileAccess)
+
+	var uploadRejection *domain.UploadRejection
+	var scanRejection *domain.ScanRejection
+	if err == nil || errors.As(err, &uploadRejection) || errors.As(err, &scanRejection) {
+		if discardErr := s.discardUploadSession(ctx, session); discardErr != nil {
+			log.Printf("Failed to discard upload session %s: %v", session.ID, discardErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// CancelUpload discards a resumable upload and the chunks received so far
+func (s *FileService) CancelUpload(ctx context.Context, id string, viewer *domain.FileViewer) error {
+	if s.uploadSessions == nil {
+		return domain.ErrUploadSessionsUnsupported
+	}
+	session, err := s.uploadSessions.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !uploadOwnedBy(session, viewer) {
+		return domain.ErrFileAccessDenied
+	}
+	return s.discardUploadSession(ctx, session)
+}
+
+// ExpireUploadSessions discards the resumable uploads that expired and returns how many
+func (s *FileService) ExpireUploadSessions(ctx context.Context) (int, error) {
+	if s.uploadSessions == nil {
+		return 0, domain.ErrUploadSessionsUnsupported
+	}
+
+	expired, err := s.uploadSessions.ListExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	discarded := 0
+	for _, session := range expired {
+		if err := s.discardUploadSession(ctx, session); err != nil {
+			return discarded, err
+		}
+		discarded++
+	}
+	return discarded, nil
+}
+
+// uploadParts lists the stored chunks of a complete session in order, making sure
+// they cover the declared length without gaps
+func (s *FileService) uploadParts(ctx context.Context, session *domain.UploadSession) ([]string, error) {
+	stored, err := s.fileRepo.List(ctx, &domain.FileQuery{Prefix: uploadPartsPrefix + session.ID + "/"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upload chunks: %w", err)
+	}
+
+	parts := make([]string, 0, len(stored))
+	var next int64
+	for _, part := range stored {
+		if part.ID != uploadPartName(session.ID, next) {
+			return nil, fmt.Errorf("%w: missing chunk at offset %d", domain.ErrUploadIncomplete, next)
+		}
+		parts = append(parts, part.ID)
+		next += part.Size
+	}
+	if next != session.Length {
+		return nil, fmt.Errorf("%w: received %d of %d bytes", domain.ErrUploadIncomplete, next, session.Length)
+	}
+	return parts, nil
+}
+
+// discardUploadSession removes the chunks and the record of a session
+func (s *FileService) discardUploadSession(ctx context.Context, session *domain.UploadSession) error {
+	stored, err := s.fileRepo.List(ctx, &domain.FileQuery{Prefix: uploadPartsPrefix + session.ID + "/"})
+	if err != nil {
+		return fmt.Errorf("failed to list upload chunks: %w", err)
+	}
+	for _, part := range stored {
+		if err := s.fileRepo.Delete(ctx, part.ID); err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+			return fmt.Errorf("failed to delete upload chunk: %w", err)
+		}
+	}
+	return s.uploadSessions.Delete(ctx, session.ID)
+}
+
+// reserved reports whether a storage object is internal to the service rather than a file
+func (s *FileService) reserved(id string) bool {
+	return s.quarantined(id) || strings.HasPrefix(id, uploadPartsPrefix)
+}
+
+// checkUploadSession returns why the viewer may not continue the session, or nil if they may
+func checkUploadSession(session *domain.UploadSession, viewer *domain.FileViewer) error {
+	if !uploadOwnedBy(session, viewer) {
+		return domain.ErrFileAccessDenied
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return domain.ErrUploadExpired
+	}
+	return nil
+}
+
+// uploadOwnedBy reports whether the viewer started the session or is an admin.
+// Sessions without an uploader are open to anyone.
+func uploadOwnedBy(session *domain.UploadSession, viewer *domain.FileViewer) bool {
+	if session.UploaderID == nil {
+		return true
+	}
+	return viewer != nil && (viewer.Admin || *session.UploaderID == viewer.UserID)
+}
+
+// uploadPartName names the chunk starting at offset so that chunks list in order
+func uploadPartName(sessionID string, offset int64) string {
+	return fmt.Sprintf("%s%s/%020d", uploadPartsPrefix, sessionID, offset)
+}
+
+// newUploadID returns a random, unguessable session ID
+func newUploadID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate upload ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// uploadPartsReader reads the chunks of an upload one after another, opening
+// each only when the previous one is exhausted
+type uploadPartsReader struct {
+	ctx     context.Context
+	repo    domain.FileRepository
+	parts   []string
+	current io.ReadCloser
+}
+
+// Read implements io.Reader
+func (r *uploadPartsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.parts) == 0 {
+				return 0, io.EOF
+			}
+			reader, err := r.repo.NewRangeReader(r.ctx, r.parts[0], 0, -1)
+			if err != nil {
+				return 0, fmt.Errorf("failed to open upload chunk: %w", err)
+			}
+			r.current = reader.Content
+			r.parts = r.parts[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// Close releases the chunk being read
+func (r *uploadPartsReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_ResumableUploads(t *testing.T) {
+	ctx := context.Background()
+	_, repo, db := setupFileServiceWithMetadata(t)
+	require.NoError(t, db.AutoMigrate(&database.UploadSession{}))
+	sessions := infrastructure.NewGormUploadSessionRepository(db)
+	service := NewFileService(repo,
+		WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		WithUploadPolicy(domain.UploadPolicy{MaxFileSize: 16}),
+		WithUploadSessions(sessions, time.Hour),
+	)
+	owner := &domain.FileViewer{UserID: 1}
+
+	start := func(name string, length int64) *domain.UploadSession {
+		session, err := service.CreateUploadSession(ctx, &domain.CreateUploadSessionRequest{
+			Name:   name,
+			Length: length,
+			Access: &domain.FileAccessRequest{Uploader: owner},
+		})
+		require.NoError(t, err)
+		return session
+	}
+	appendChunk := func(id string, offset int64, content string) (*domain.UploadSession, error) {
+		return service.AppendUploadChunk(ctx, id, owner, offset, strings.NewReader(content))
+	}
+
+	t.Run("Chunks assemble into a file", func(t *testing.T) {
+		session := start("large.txt", 11)
+		assert.Equal(t, "text/plain", session.ContentType)
+		assert.Len(t, session.ID, 32)
+
+		session, err := appendChunk(session.ID, 0, "hello ")
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), session.Offset)
+
+		_, err = service.FinalizeUpload(ctx, session.ID, owner)
+		assert.ErrorIs(t, err, domain.ErrUploadIncomplete)
+
+		session, err = appendChunk(session.ID, 6, "world")
+		require.NoError(t, err)
+		assert.True(t, session.Complete())
+
+		file, err := service.FinalizeUpload(ctx, session.ID, owner)
+		require.NoError(t, err)
+		assert.Equal(t, "large.txt", file.ID)
+		require.NotNil(t, file.UploaderID)
+		assert.Equal(t, uint(1), *file.UploaderID)
+
+		content, err := service.GetFileContent(ctx, "large.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(content.Content))
+
+		// The session and its chunks are gone
+		_, err = service.GetUploadSession(ctx, session.ID, owner)
+		assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+		parts, err := repo.List(ctx, &domain.FileQuery{Prefix: uploadPartsPrefix})
+		require.NoError(t, err)
+		assert.Empty(t, parts)
+	})
+
+	t.Run("Chunks must continue at the current offset", func(t *testing.T) {
+		session := start("offsets.txt", 4)
+		_, err := appendChunk(session.ID, 2, "cd")
+		assert.ErrorIs(t, err, domain.ErrUploadOffsetMismatch)
+
+		_, err = appendChunk(session.ID, 0, "abcde")
+		assert.ErrorIs(t, err, domain.ErrUploadExceedsLength)
+
+		session, err = service.GetUploadSession(ctx, session.ID, owner)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), session.Offset)
+	})
+
+	t.Run("Sessions belong to their uploader", func(t *testing.T) {
+		session := start("private.txt", 4)
+		other := &domain.FileViewer{UserID: 2}
+		_, err := service.AppendUploadChunk(ctx, session.ID, other, 0, strings.NewReader("data"))
+		assert.ErrorIs(t, err, domain.ErrFileAccessDenied)
+		assert.ErrorIs(t, service.CancelUpload(ctx, session.ID, other), domain.ErrFileAccessDenied)
+
+		_, err = service.GetUploadSession(ctx, session.ID, &domain.FileViewer{UserID: 2, Admin: true})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Declared lengths are checked against the policy", func(t *testing.T) {
+		_, err := service.CreateUploadSession(ctx, &domain.CreateUploadSessionRequest{Name: "huge.bin", Length: 17})
+		assert.ErrorIs(t, err, domain.ErrFileTooLarge)
+
+		_, err = service.CreateUploadSession(ctx, &domain.CreateUploadSessionRequest{Name: ".uploads/x", Length: 1})
+		assert.ErrorIs(t, err, domain.ErrInvalidFileName)
+
+		_, err = service.CreateUploadSession(ctx, &domain.CreateUploadSessionRequest{Name: "large.txt", Length: 1})
+		assert.ErrorIs(t, err, domain.ErrFileAlreadyExists)
+	})
+
+	t.Run("Expired sessions are discarded", func(t *testing.T) {
+		session := start("abandoned.txt", 8)
+		_, err := appendChunk(session.ID, 0, "part")
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&database.UploadSession{}).Where("id = ?", session.ID).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		_, err = appendChunk(session.ID, 4, "more")
+		assert.ErrorIs(t, err, domain.ErrUploadExpired)
+
+		expired, err := service.ExpireUploadSessions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		_, err = service.GetUploadSession(ctx, session.ID, owner)
+		assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+		exists, err := repo.Exists(ctx, uploadPartName(session.ID, 0))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Chunks are not listed as files", func(t *testing.T) {
+		session := start("hidden.txt", 8)
+		_, err := appendChunk(session.ID, 0, "part")
+		require.NoError(t, err)
+
+		_, err = service.GetFile(ctx, uploadPartName(session.ID, 0))
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+		report, err := service.ReconcileMetadata(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, report.Added)
+
+		require.NoError(t, service.CancelUpload(ctx, session.ID, owner))
+		_, err = service.GetUploadSession(ctx, session.ID, owner)
+		assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+	})
+}
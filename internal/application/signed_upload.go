@@ -0,0 +1,249 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// WithSignedUploads enables uploads through signed URLs, tracking each issued URL
+// until its upload is completed
+func WithSignedUploads(uploads domain.SignedUploadRepository) FileServiceOption {
+	return func(s *FileService) {
+		s.signedUploads = uploads
+	}
+}
+
+// IssueUploadURL issues a signed URL for uploading a new file directly to storage.
+// The declared type and size are checked against the upload policy up front and
+// bound to the URL. Uploads not completed within a further URL lifetime after the
+// URL expired are discarded by ExpireSignedUploads.
+func (s *FileService) IssueUploadURL(ctx context.Context, req *domain.CreateSignedUploadRequest) (*domain.SignedURL, error) {
+	if s.signer == nil || s.signedUploads == nil {
+		return nil, domain.ErrSignedURLUnsupported
+	}
+	if err := s.validateFileName(req.Name); err != nil {
+		return nil, err
+	}
+	if req.Length <= 0 {
+		return nil, domain.ErrInvalidUploadLength
+	}
+	if req.ContentType == "" {
+		req.ContentType = s.detectContentType(req.Name)
+	}
+
+	access, err := s.resolveAccess(ctx, req.Access)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkType(req.ContentType, access.Attachment); err != nil {
+		return nil, err
+	}
+	if err := s.checkSize(ctx, s.metadataRepo, access.UploaderID, 0, req.Length); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.fileRepo.Exists(ctx, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check file existence: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrFileAlreadyExists
+	}
+
+	// An expired upload of the same name no longer blocks the file
+	if pending, err := s.signedUploads.Get(ctx, req.Name); err == nil && time.Now().After(pending.ExpiresAt) {
+		if err := s.discardSignedUpload(ctx, pending); err != nil {
+			return nil, err
+		}
+	}
+
+	signedURL, err := s.signer.SignURL(ctx, &domain.SignedURLRequest{
+		FileID:      req.Name,
+		Method:      http.MethodPut,
+		ContentType: req.ContentType,
+		MaxSize:     req.Length,
+		ExpiresAt:   time.Now().Add(s.signedURLTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue upload URL: %w", err)
+	}
+
+	upload := &domain.SignedUpload{
+		FileName:    req.Name,
+		ContentType: req.ContentType,
+		Length:      req.Length,
+		ExpiresAt:   signedURL.ExpiresAt.Add(s.signedURLTTL),
+		FileAccess:  access,
+	}
+	if err := s.signedUploads.Create(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	return signedURL, nil
+}
+
+// StoreSignedUpload stores content sent to a signed upload URL served by this
+// service. Each URL can be used once; the content must not exceed the declared
+// size and goes through the same policy and scanning as a direct upload.
+func (s *FileService) StoreSignedUpload(ctx context.Context, req *domain.CreateFileStreamRequest) (*domain.File, error) {
+	if s.signedUploads == nil {
+		return nil, domain.ErrSignedURLUnsupported
+	}
+	upload, err := s.signedUploads.Get(ctx, req.Name)
+	if err != nil {
+		if errors.Is(err, domain.ErrUploadNotFound) {
+			return nil, domain.ErrSignedUploadUsed
+		}
+		return nil, err
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, domain.ErrUploadExpired
+	}
+	if err := s.signedUploads.MarkUsed(ctx, upload.FileName, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// The URL is used up whether or not the content is accepted
+	defer func() {
+		if err := s.signedUploads.Delete(ctx, upload.FileName); err != nil {
+			log.Printf("Failed to remove signed upload %s: %v", upload.FileName, err)
+		}
+	}()
+
+	return s.createFromReader(ctx, &domain.CreateFileStreamRequest{
+		Name:        upload.FileName,
+		ContentType: upload.ContentType,
+		Content: &limitedUpload{
+			r:         req.Content,
+			remaining: upload.Length,
+			rejection: domain.ErrUploadExceedsLength,
+		},
+	}, upload.FileAccess)
+}
+
+// RecordFile completes a signed upload for the user the URL was issued to. Content
+// written straight to storage is recorded in the metadata table with the access
+// declared when the URL was issued, after the policy checks it could not get while
+// it was uploaded. Repeated callbacks by the uploader return the recorded file.
+func (s *FileService) RecordFile(ctx context.Context, id string, viewer *domain.FileViewer) (*domain.File, error) {
+	if s.signedUploads == nil {
+		return nil, domain.ErrSignedURLUnsupported
+	}
+	upload, err := s.signedUploads.Get(ctx, id)
+	if errors.Is(err, domain.ErrUploadNotFound) {
+		if file, getErr := s.GetFile(ctx, id); getErr == nil && viewer != nil && uploadedBy(file, viewer) {
+			return file, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !issuedTo(upload, viewer) {
+		return nil, domain.ErrFileAccessDenied
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, domain.ErrUploadExpired
+	}
+
+	file, err := s.GetFile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.metadataRepo != nil && file.UploaderID == nil {
+		// Only content sent through this upload's URL can be claimed
+		if !storedThrough(upload, file) {
+			return nil, domain.ErrFileAccessDenied
+		}
+		if err := s.checkRecorded(ctx, file, upload); err != nil {
+			s.endSignedUpload(ctx, upload, err)
+			return nil, err
+		}
+		file.FileAccess = upload.FileAccess
+
+		// Content written straight to storage has not been scanned yet
+		var rejection *domain.ScanRejection
+		if file.ScanStatus == "" {
+			if rejection, err = s.scanStored(ctx, file); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.metadataRepo.Save(ctx, file); err != nil {
+			return nil, fmt.Errorf("failed to record file metadata: %w", err)
+		}
+		if rejection != nil {
+			s.endSignedUpload(ctx, upload, rejection)
+			return nil, rejection
+		}
+	}
+
+	s.endSignedUpload(ctx, upload, nil)
+	return file, nil
+}
+
+// ExpireSignedUploads discards the signed uploads that were not completed in time,
+// together with content sent through their URLs, and returns how many
+func (s *FileService) ExpireSignedUploads(ctx context.Context) (int, error) {
+	if s.signedUploads == nil {
+		return 0, domain.ErrSignedURLUnsupported
+	}
+
+	expired, err := s.signedUploads.ListExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	discarded := 0
+	for _, upload := range expired {
+		if err := s.discardSignedUpload(ctx, upload); err != nil {
+			return discarded, err
+		}
+		discarded++
+	}
+	return discarded, nil
+}
+
+// discardSignedUpload removes the record of an upload and the content stored
+// through its URL, which is unrecorded and newer than the URL
+func (s *FileService) discardSignedUpload(ctx context.Context, upload *domain.SignedUpload) error {
+	file, err := s.GetFile(ctx, upload.FileName)
+	switch {
+	case errors.Is(err, domain.ErrFileNotFound):
+	case err != nil:
+		return err
+	case storedThrough(upload, file):
+		if err := s.DeleteFile(ctx, file.ID); err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+			return fmt.Errorf("failed to delete uncompleted upload: %w", err)
+		}
+	}
+	return s.signedUploads.Delete(ctx, upload.FileName)
+}
+
+// issuedTo reports whether the upload URL was issued to the viewer
+func issuedTo(upload *domain.SignedUpload, viewer *domain.FileViewer) bool {
+	return upload.UploaderID != nil && viewer != nil && *upload.UploaderID == viewer.UserID
+}
+
+// storedThrough reports whether the file holds content sent through the upload's
+// URL: it is not recorded yet and was created after the URL was issued
+func storedThrough(upload *domain.SignedUpload, file *domain.File) bool {
+	return file.UploaderID == nil && !file.CreatedAt.Before(upload.CreatedAt)
+}
+
+// endSignedUpload removes the record of an upload once it was recorded or its
+// content rejected; other failures keep it so completing can be retried
+func (s *FileService) endSignedUpload(ctx context.Context, upload *domain.SignedUpload, err error) {
+	var uploadRejection *domain.UploadRejection
+	var scanRejection *domain.ScanRejection
+	if err != nil && !errors.As(err, &uploadRejection) && !errors.As(err, &scanRejection) {
+		return
+	}
+	if err := s.signedUploads.Delete(ctx, upload.FileName); err != nil {
+		log.Printf("Failed to remove signed upload %s: %v", upload.FileName, err)
+	}
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_SignedUploads(t *testing.T) {
+	ctx := context.Background()
+	_, repo, db := setupFileServiceWithMetadata(t)
+	require.NoError(t, db.AutoMigrate(&database.SignedUpload{}))
+	service := NewFileService(repo,
+		WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		WithUploadPolicy(domain.UploadPolicy{MaxFileSize: 16}),
+		WithURLSigner(infrastructure.NewHMACURLSigner([]byte("test-secret"), "http://example.com"), time.Minute),
+		WithSignedUploads(infrastructure.NewGormSignedUploadRepository(db)),
+	)
+	owner := &domain.FileViewer{UserID: 1}
+	other := &domain.FileViewer{UserID: 2}
+
+	issue := func(name string, length int64, visibility string) error {
+		_, err := service.IssueUploadURL(ctx, &domain.CreateSignedUploadRequest{
+			Name:   name,
+			Length: length,
+			Access: &domain.FileAccessRequest{Uploader: owner, Visibility: visibility},
+		})
+		return err
+	}
+	// writeDirectly stores content the way a client using a native signed URL would
+	writeDirectly := func(name, content string) {
+		_, err := repo.Create(ctx, &domain.CreateFileRequest{Name: name, Content: []byte(content), ContentType: "text/plain"})
+		require.NoError(t, err)
+	}
+
+	t.Run("Declared size is checked when the URL is issued", func(t *testing.T) {
+		var rejection *domain.UploadRejection
+		assert.ErrorAs(t, issue("large.txt", 17, ""), &rejection)
+		assert.ErrorIs(t, issue("empty.txt", 0, ""), domain.ErrInvalidUploadLength)
+
+		require.NoError(t, issue("pending.txt", 4, ""))
+		assert.ErrorIs(t, issue("pending.txt", 4, ""), domain.ErrFileAlreadyExists)
+	})
+
+	t.Run("URLs served by the service store content once", func(t *testing.T) {
+		require.NoError(t, issue("once.txt", 5, ""))
+
+		_, err := service.StoreSignedUpload(ctx, &domain.CreateFileStreamRequest{Name: "once.txt", Content: strings.NewReader("too long")})
+		assert.ErrorIs(t, err, domain.ErrUploadExceedsLength)
+		_, err = service.StoreSignedUpload(ctx, &domain.CreateFileStreamRequest{Name: "once.txt", Content: strings.NewReader("hello")})
+		assert.ErrorIs(t, err, domain.ErrSignedUploadUsed)
+
+		require.NoError(t, issue("once.txt", 5, ""))
+		file, err := service.StoreSignedUpload(ctx, &domain.CreateFileStreamRequest{Name: "once.txt", Content: strings.NewReader("hello")})
+		require.NoError(t, err)
+		require.NotNil(t, file.UploaderID)
+		assert.Equal(t, uint(1), *file.UploaderID)
+	})
+
+	t.Run("Completion records the declared access", func(t *testing.T) {
+		require.NoError(t, issue("direct.txt", 5, domain.FileVisibilityPrivate))
+		writeDirectly("direct.txt", "hello")
+
+		_, err := service.RecordFile(ctx, "direct.txt", other)
+		assert.ErrorIs(t, err, domain.ErrFileAccessDenied, "only the user the URL was issued to completes it")
+		_, err = service.RecordFile(ctx, "direct.txt", nil)
+		assert.ErrorIs(t, err, domain.ErrFileAccessDenied)
+
+		file, err := service.RecordFile(ctx, "direct.txt", owner)
+		require.NoError(t, err)
+		require.NotNil(t, file.UploaderID)
+		assert.Equal(t, uint(1), *file.UploaderID)
+		assert.Equal(t, domain.FileVisibilityPrivate, file.Visibility)
+
+		_, err = service.RecordFile(ctx, "direct.txt", owner)
+		assert.NoError(t, err, "repeated callbacks return the recorded file")
+		_, err = service.RecordFile(ctx, "direct.txt", other)
+		assert.Error(t, err)
+	})
+
+	t.Run("Content not sent through the URL is neither claimed nor deleted", func(t *testing.T) {
+		require.NoError(t, issue("legacy.txt", 5, ""))
+		writeDirectly("legacy.txt", "older")
+		require.NoError(t, db.Model(&database.SignedUpload{}).Where("file_name = ?", "legacy.txt").
+			Update("created_at", time.Now().Add(time.Hour)).Error)
+
+		_, err := service.RecordFile(ctx, "legacy.txt", owner)
+		assert.ErrorIs(t, err, domain.ErrFileAccessDenied)
+		exists, err := repo.Exists(ctx, "legacy.txt")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Uploads never completed are discarded", func(t *testing.T) {
+		require.NoError(t, issue("abandoned.txt", 5, ""))
+		writeDirectly("abandoned.txt", "hello")
+		require.NoError(t, db.Model(&database.SignedUpload{}).Where("file_name IN ?", []string{"abandoned.txt", "pending.txt", "legacy.txt"}).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		discarded, err := service.ExpireSignedUploads(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, discarded)
+
+		exists, err := repo.Exists(ctx, "abandoned.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+		_, err = service.RecordFile(ctx, "abandoned.txt", owner)
+		assert.ErrorIs(t, err, domain.ErrUploadNotFound)
+
+		exists, err = repo.Exists(ctx, "legacy.txt")
+		require.NoError(t, err)
+		assert.True(t, exists, "content older than the upload is kept")
+
+		exists, err = repo.Exists(ctx, "direct.txt")
+		require.NoError(t, err)
+		assert.True(t, exists, "completed uploads are kept")
+	})
+}
@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// WithUploadPolicy limits the size and type of uploaded files and the total
+// storage each user may use
+func WithUploadPolicy(policy domain.UploadPolicy) FileServiceOption {
+	return func(s *FileService) {
+		s.policy = policy
+	}
+}
+
+// StorageUsage reports how much storage the files uploaded by a user take up
+func (s *FileService) StorageUsage(ctx context.Context, userID uint) (*domain.StorageUsage, error) {
+	if s.metadataRepo == nil {
+		return nil, errors.New("storage usage requires the file metadata repository")
+	}
+
+	used, count, err := s.metadataRepo.UploaderUsage(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute storage usage: %w", err)
+	}
+
+	usage := &domain.StorageUsage{
+		UserID:      userID,
+		UsedBytes:   used,
+		FileCount:   count,
+		QuotaBytes:  s.policy.UserQuota,
+		MaxFileSize: s.policy.MaxFileSize,
+	}
+	if s.policy.UserQuota > 0 {
+		remaining := max(s.policy.UserQuota-used, 0)
+		usage.RemainingBytes = &remaining
+	}
+	return usage, nil
+}
+
+// checkType rejects content types the policy does not allow for the attachment
+func (s *FileService) checkType(contentType string, attachment *domain.FileAttachment) error {
+	attachmentType := domain.AttachmentNone
+	if attachment != nil {
+		attachmentType = attachment.Type
+	}
+	if s.policy.AllowsType(attachmentType, contentType) {
+		return nil
+	}
+	return &domain.UploadRejection{
+		Reason:       domain.ErrFileTypeNotAllowed,
+		ContentType:  contentType,
+		AllowedTypes: s.policy.AllowedTypes[attachmentType],
+	}
+}
+
+// uploadLimit returns the largest upload the policy allows for uploaderID together
+// with the rejection reported beyond it. replacedSize is the size of the uploader's
+// file being replaced, which no longer counts against the quota. A negative limit
+// means unlimited.
+func (s *FileService) uploadLimit(ctx context.Context, meta domain.FileMetadataRepository, uploaderID *uint, replacedSize int64) (int64, *domain.UploadRejection, error) {
+	limit := int64(-1)
+	var rejection *domain.UploadRejection
+	if s.policy.MaxFileSize > 0 {
+		limit = s.policy.MaxFileSize
+		rejection = &domain.UploadRejection{Reason: domain.ErrFileTooLarge, Limit: s.policy.MaxFileSize}
+	}
+
+	if s.policy.UserQuota > 0 && uploaderID != nil && meta != nil {
+		used, _, err := meta.UploaderUsage(ctx, *uploaderID)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to compute storage usage: %w", err)
+		}
+		used = max(used-replacedSize, 0)
+		if remaining := max(s.policy.UserQuota-used, 0); limit < 0 || remaining < limit {
+			limit = remaining
+			rejection = &domain.UploadRejection{Reason: domain.ErrQuotaExceeded, Limit: s.policy.UserQuota, Used: used}
+		}
+	}
+	return limit, rejection, nil
+}
+
+// checkSize rejects uploads of a known size beyond the upload limit
+func (s *FileService) checkSize(ctx context.Context, meta domain.FileMetadataRepository, uploaderID *uint, replacedSize, size int64) error {
+	limit, rejection, err := s.uploadLimit(ctx, meta, uploaderID, replacedSize)
+	if err != nil {
+		return err
+	}
+	if limit >= 0 && size > limit {
+		return rejection
+	}
+	return nil
+}
+
+// limitUpload wraps streamed content so that reading past the upload limit fails
+// with the rejection, aborting the write before the whole stream is stored
+func (s *FileService) limitUpload(ctx context.Context, uploaderID *uint, replacedSize int64, content io.Reader) (io.Reader, error) {
+	limit, rejection, err := s.uploadLimit(ctx, s.metadataRepo, uploaderID, replacedSize)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return content, nil
+	}
+	return &limitedUpload{r: content, remaining: limit, rejection: rejection}, nil
+}
+
+// limitedUpload fails with rejection once more than remaining bytes were read
+type limitedUpload struct {
+	r         io.Reader
+	remaining int64
+	rejection error
+}
+
+// Read implements io.Reader
+func (l *limitedUpload) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		return 0, l.rejection
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// checkRecorded applies the policy to a file written directly to storage through a
+// signed upload, which could not be checked while it was uploaded. Rejected files
+// are deleted again if they were stored through the upload's URL.
+func (s *FileService) checkRecorded(ctx context.Context, file *domain.File, upload *domain.SignedUpload) error {
+	err := s.checkType(file.ContentType, upload.Attachment)
+	if err == nil {
+		err = s.checkSize(ctx, s.metadataRepo, upload.UploaderID, 0, file.Size)
+	}
+	var rejection *domain.UploadRejection
+	if errors.As(err, &rejection) && storedThrough(upload, file) {
+		if delErr := s.fileRepo.Delete(ctx, file.ID); delErr != nil {
+			log.Printf("Failed to remove rejected file %s: %v", file.ID, delErr)
+		}
+	}
+	return err
+}
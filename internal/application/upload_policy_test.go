@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_UploadPolicy(t *testing.T) {
+	ctx := context.Background()
+	_, repo, db := setupFileServiceWithMetadata(t)
+	service := NewFileService(repo,
+		WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		WithUploadPolicy(domain.UploadPolicy{
+			MaxFileSize:  10,
+			UserQuota:    12,
+			AllowedTypes: map[string][]string{domain.AttachmentNone: {"text/*", "application/pdf"}},
+		}),
+	)
+
+	alice := &domain.FileViewer{UserID: 1}
+	upload := func(name, contentType, content string) (*domain.File, error) {
+		return service.CreateFileFromReader(ctx, &domain.CreateFileStreamRequest{
+			Name:        name,
+			ContentType: contentType,
+			Content:     strings.NewReader(content),
+			Access:      &domain.FileAccessRequest{Uploader: alice},
+		})
+	}
+	rejection := func(t *testing.T, err error, reason error) *domain.UploadRejection {
+		var rejected *domain.UploadRejection
+		require.True(t, errors.As(err, &rejected), "expected an upload rejection, got %v", err)
+		assert.ErrorIs(t, err, reason)
+		return rejected
+	}
+
+	t.Run("Rejects files above the size limit", func(t *testing.T) {
+		_, err := upload("big.txt", "text/plain", "0123456789a")
+		rejected := rejection(t, err, domain.ErrFileTooLarge)
+		assert.Equal(t, int64(10), rejected.Limit)
+
+		exists, err := repo.Exists(ctx, "big.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Rejects disallowed types", func(t *testing.T) {
+		_, err := upload("run.sh", "application/x-sh", "echo")
+		rejected := rejection(t, err, domain.ErrFileTypeNotAllowed)
+		assert.Equal(t, []string{"text/*", "application/pdf"}, rejected.AllowedTypes)
+
+		_, err = upload("notes.txt", "text/plain; charset=utf-8", "notes")
+		require.NoError(t, err)
+	})
+
+	t.Run("Rejects uploads beyond the quota", func(t *testing.T) {
+		_, err := upload("more.txt", "text/plain", "0123456789")
+		rejected := rejection(t, err, domain.ErrQuotaExceeded)
+		assert.Equal(t, int64(12), rejected.Limit)
+		assert.Equal(t, int64(5), rejected.Used)
+
+		// Replacing a file only counts the difference against the quota
+		_, err = service.ReplaceFileContent(ctx, "notes.txt", &domain.CreateFileStreamRequest{
+			ContentType: "text/plain",
+			Content:     strings.NewReader("0123456789"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Reports storage usage", func(t *testing.T) {
+		usage, err := service.StorageUsage(ctx, alice.UserID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), usage.UsedBytes)
+		assert.Equal(t, int64(1), usage.FileCount)
+		require.NotNil(t, usage.RemainingBytes)
+		assert.Equal(t, int64(2), *usage.RemainingBytes)
+
+		usage, err = service.StorageUsage(ctx, 2)
+		require.NoError(t, err)
+		assert.Zero(t, usage.UsedBytes)
+	})
+}
@@ -5,6 +5,20 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+)
+
+// Supported file storage backends
+const (
+	StorageBackendGCS   = "gcs"
+	StorageBackendLocal = "local"
+)
+
+// Supported content scanners
+const (
+	FileScannerNone   = ""
+	FileScannerNoop   = "noop"
+	FileScannerClamAV = "clamav"
 )
 
 // Config holds application configuration
@@ -13,6 +27,35 @@ type Config struct {
 	Port    string
 	GinMode string
 
+	// Storage Configuration
+	StorageBackend  string
+	LocalStorageDir string
+	PublicBaseURL   string
+	SignedURLSecret string
+	SignedURLTTL    time.Duration
+
+	// File Versioning Configuration
+	FileVersionMaxCount int
+	FileVersionMaxAge   time.Duration
+
+	// Upload Policy Configuration
+	UploadMaxFileSize  int64
+	UserStorageQuota   int64
+	UploadAllowedTypes map[string][]string
+
+	// Resumable Upload Configuration
+	UploadSessionTTL             time.Duration
+	UploadSessionCleanupInterval time.Duration
+
+	// Hackathon Scheduling Configuration
+	HackathonStatusInterval time.Duration
+
+	// Content Scanning Configuration
+	FileScanner          string
+	ClamAVAddress        string
+	ClamAVTimeout        time.Duration
+	FileQuarantinePrefix string
+
 	// GCP Configuration
 	GCSBucketName                string
 	GCSFolder                    string
@@ -30,6 +73,15 @@ type Config struct {
 	DBMaxIdleConns         int
 	CloudSQLConnectionName string
 	UseCloudSQLProxy       bool
+	AutoMigrate            bool
+
+	// Authentication Configuration
+	GoogleClientID string
+	GoogleJWKSURL  string
+	SessionSecret  string
+	CheckInSecret  string
+	SessionTTL     time.Duration
+	AdminEmails    []string
 }
 
 // Load loads configuration from environment variables
@@ -39,6 +91,35 @@ func Load() *Config {
 		Port:    getEnvWithDefault("PORT", "8080"),
 		GinMode: getEnvWithDefault("GIN_MODE", "debug"),
 
+		// Storage Configuration
+		StorageBackend:  getEnvWithDefault("STORAGE_BACKEND", StorageBackendGCS),
+		LocalStorageDir: getEnvWithDefault("LOCAL_STORAGE_DIR", "./data/storage"),
+		PublicBaseURL:   getEnvWithDefault("PUBLIC_BASE_URL", "http://localhost:"+getEnvWithDefault("PORT", "8080")),
+		SignedURLSecret: os.Getenv("SIGNED_URL_SECRET"),
+		SignedURLTTL:    getEnvDurationWithDefault("SIGNED_URL_TTL", 15*time.Minute),
+
+		// File Versioning Configuration
+		FileVersionMaxCount: getEnvIntWithDefault("FILE_VERSION_MAX_COUNT", 10),
+		FileVersionMaxAge:   getEnvDurationWithDefault("FILE_VERSION_MAX_AGE", 0),
+
+		// Upload Policy Configuration
+		UploadMaxFileSize:  getEnvInt64WithDefault("UPLOAD_MAX_FILE_SIZE", 100<<20),
+		UserStorageQuota:   getEnvInt64WithDefault("USER_STORAGE_QUOTA", 1<<30),
+		UploadAllowedTypes: getEnvTypeMapWithDefault("UPLOAD_ALLOWED_TYPES", nil),
+
+		// Resumable Upload Configuration
+		UploadSessionTTL:             getEnvDurationWithDefault("UPLOAD_SESSION_TTL", 24*time.Hour),
+		UploadSessionCleanupInterval: getEnvDurationWithDefault("UPLOAD_SESSION_CLEANUP_INTERVAL", time.Hour),
+
+		// Hackathon Scheduling Configuration
+		HackathonStatusInterval: getEnvDurationWithDefault("HACKATHON_STATUS_INTERVAL", time.Minute),
+
+		// Content Scanning Configuration
+		FileScanner:          os.Getenv("FILE_SCANNER"),
+		ClamAVAddress:        getEnvWithDefault("CLAMAV_ADDRESS", "localhost:3310"),
+		ClamAVTimeout:        getEnvDurationWithDefault("CLAMAV_TIMEOUT", 2*time.Minute),
+		FileQuarantinePrefix: getEnvWithDefault("FILE_QUARANTINE_PREFIX", "quarantine/"),
+
 		// GCP Configuration
 		GCSBucketName:                getEnvWithDefault("GCS_BUCKET_NAME", "202506-zenn-ai-agent-hackathon"),
 		GCSFolder:                    getEnvWithDefault("GCS_FOLDER", "test"),
@@ -56,6 +137,15 @@ func Load() *Config {
 		DBMaxIdleConns:         getEnvIntWithDefault("DB_MAX_IDLE_CONNS", 5),
 		CloudSQLConnectionName: os.Getenv("CLOUD_SQL_CONNECTION_NAME"),
 		UseCloudSQLProxy:       getEnvBoolWithDefault("USE_CLOUD_SQL_PROXY", false),
+		AutoMigrate:            getEnvBoolWithDefault("AUTO_MIGRATE", true),
+
+		// Authentication Configuration
+		GoogleClientID: os.Getenv("GOOGLE_CLIENT_ID"),
+		GoogleJWKSURL:  getEnvWithDefault("GOOGLE_JWKS_URL", "https://www.googleapis.com/oauth2/v3/certs"),
+		SessionSecret:  os.Getenv("SESSION_SECRET"),
+		CheckInSecret:  os.Getenv("CHECKIN_SECRET"),
+		SessionTTL:     getEnvDurationWithDefault("SESSION_TTL", 24*time.Hour),
+		AdminEmails:    getEnvListWithDefault("ADMIN_EMAILS", nil),
 	}
 }
 
@@ -102,9 +192,48 @@ func (c *Config) Validate() error {
 		errors = append(errors, "PORT must be a valid number")
 	}
 
-	// Validate GCP configuration
-	if c.GCSBucketName == "" {
-		errors = append(errors, "GCS_BUCKET_NAME is required")
+	// Validate storage configuration
+	switch c.StorageBackend {
+	case StorageBackendGCS:
+		if c.GCSBucketName == "" {
+			errors = append(errors, "GCS_BUCKET_NAME is required")
+		}
+	case StorageBackendLocal:
+		if c.LocalStorageDir == "" {
+			errors = append(errors, "LOCAL_STORAGE_DIR is required when STORAGE_BACKEND is local")
+		}
+	default:
+		errors = append(errors, "STORAGE_BACKEND must be one of: gcs, local")
+	}
+	if c.SignedURLTTL <= 0 {
+		errors = append(errors, "SIGNED_URL_TTL must be a positive duration")
+	}
+	if c.UploadMaxFileSize < 0 {
+		errors = append(errors, "UPLOAD_MAX_FILE_SIZE cannot be negative")
+	}
+	if c.UserStorageQuota < 0 {
+		errors = append(errors, "USER_STORAGE_QUOTA cannot be negative")
+	}
+	if c.UploadSessionTTL <= 0 {
+		errors = append(errors, "UPLOAD_SESSION_TTL must be a positive duration")
+	}
+	if c.UploadSessionCleanupInterval <= 0 {
+		errors = append(errors, "UPLOAD_SESSION_CLEANUP_INTERVAL must be a positive duration")
+	}
+	if c.HackathonStatusInterval <= 0 {
+		errors = append(errors, "HACKATHON_STATUS_INTERVAL must be a positive duration")
+	}
+	switch c.FileScanner {
+	case FileScannerNone, FileScannerNoop:
+	case FileScannerClamAV:
+		if c.ClamAVAddress == "" {
+			errors = append(errors, "CLAMAV_ADDRESS is required when FILE_SCANNER is clamav")
+		}
+	default:
+		errors = append(errors, "FILE_SCANNER must be one of: noop, clamav")
+	}
+	if c.FileScanner != FileScannerNone && !strings.HasSuffix(c.FileQuarantinePrefix, "/") {
+		errors = append(errors, "FILE_QUARANTINE_PREFIX must end with /")
 	}
 
 	// Validate database configuration
@@ -144,6 +273,25 @@ func (c *Config) Validate() error {
 		errors = append(errors, "DB_MAX_IDLE_CONNS cannot be greater than DB_MAX_OPEN_CONNS")
 	}
 
+	// Validate authentication configuration
+	if c.IsProduction() {
+		if c.GoogleClientID == "" {
+			errors = append(errors, "GOOGLE_CLIENT_ID is required in release mode")
+		}
+		if c.SessionSecret == "" {
+			errors = append(errors, "SESSION_SECRET is required in release mode")
+		}
+	}
+	if c.SessionSecret != "" && len(c.SessionSecret) < 32 {
+		errors = append(errors, "SESSION_SECRET must be at least 32 characters")
+	}
+	if c.CheckInSecret != "" && len(c.CheckInSecret) < 32 {
+		errors = append(errors, "CHECKIN_SECRET must be at least 32 characters")
+	}
+	if c.SessionTTL <= 0 {
+		errors = append(errors, "SESSION_TTL must be a positive duration")
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration validation failed: %s", strings.Join(errors, "; "))
 	}
@@ -179,6 +327,16 @@ func getEnvIntWithDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvInt64WithDefault gets environment variable as 64-bit integer with default value
+func getEnvInt64WithDefault(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvBoolWithDefault gets environment variable as boolean with default value
 func getEnvBoolWithDefault(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -188,3 +346,50 @@ func getEnvBoolWithDefault(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+// getEnvDurationWithDefault gets environment variable as duration with default value
+func getEnvDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvListWithDefault gets a comma-separated environment variable as a list with default value
+func getEnvListWithDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvTypeMapWithDefault gets environment variable of the form
+// "key=type,type;key=type" as a map of lists with default value
+func getEnvTypeMapWithDefault(key string, defaultValue map[string][]string) map[string][]string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	types := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		name, list, ok := strings.Cut(entry, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			continue
+		}
+		for _, item := range strings.Split(list, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				types[name] = append(types[name], item)
+			}
+		}
+	}
+	return types
+}
\ No newline at end of file
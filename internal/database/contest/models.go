@@ -3,9 +3,20 @@ package contest
 import (
 	"time"
 
+	userDB "github.com/TRu-S3/backend/internal/database/user"
 	"gorm.io/gorm"
 )
 
+// Contest roles applicants apply for, each recruited up to its quota
+const (
+	RoleBackend  = "backend"
+	RoleFrontend = "frontend"
+	RoleAI       = "ai"
+)
+
+// Roles lists every contest role
+var Roles = []string{RoleBackend, RoleFrontend, RoleAI}
+
 // Contest represents a programming contest
 type Contest struct {
 	ID                  uint      `gorm:"primarykey" json:"id"`
@@ -22,6 +33,9 @@ type Contest struct {
 	Description         string    `json:"description"`
 	CreatedAt           time.Time `json:"created_at"`
 	UpdatedAt           time.Time `json:"updated_at"`
+
+	ApplicationsOpen bool           `gorm:"-" json:"applications_open"`         // whether the application deadline is still ahead
+	RemainingSlots   map[string]int `gorm:"-" json:"remaining_slots,omitempty"` // quota left per role after accepted applications
 }
 
 // TableName returns the table name for the Contest model
@@ -29,9 +43,46 @@ func (Contest) TableName() string {
 	return "contests"
 }
 
+// Quota returns how many applicants the contest recruits for role
+func (c *Contest) Quota(role string) int {
+	switch role {
+	case RoleBackend:
+		return c.BackendQuota
+	case RoleFrontend:
+		return c.FrontendQuota
+	case RoleAI:
+		return c.AIQuota
+	}
+	return 0
+}
+
+// ContestApplication represents a user's application to fill a role of a contest.
+// A user has at most one pending or accepted application per contest.
+type ContestApplication struct {
+	ID        uint       `gorm:"primarykey" json:"id"`
+	ContestID uint       `gorm:"not null;index:idx_contest_applications_contest_role_status;uniqueIndex:idx_contest_applications_active,where:status IN ('pending'\\,'accepted')" json:"contest_id"`
+	UserID    uint       `gorm:"not null;index;uniqueIndex:idx_contest_applications_active" json:"user_id"`
+	Role      string     `gorm:"not null;type:varchar(20);index:idx_contest_applications_contest_role_status" json:"role"`
+	Message   string     `gorm:"type:text" json:"message"`
+	Status    string     `gorm:"not null;type:varchar(20);default:pending;index:idx_contest_applications_contest_role_status" json:"status"`
+	DecidedAt *time.Time `json:"decided_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// Foreign key relationships
+	Contest *Contest     `gorm:"foreignKey:ContestID;constraint:OnDelete:CASCADE" json:"contest,omitempty"`
+	User    *userDB.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// TableName returns the table name for the ContestApplication model
+func (ContestApplication) TableName() string {
+	return "contest_applications"
+}
+
 // Models returns all contest-related models for migration
 var Models = []interface{}{
 	&Contest{},
+	&ContestApplication{},
 }
 
 // AutoMigrate performs auto-migration for contest models
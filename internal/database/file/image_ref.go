@@ -0,0 +1,44 @@
+package file
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// ImageRef references a stored image and its thumbnails by file ID.
+// It is stored as a JSON document in a text column.
+type ImageRef struct {
+	FileID     string            `json:"file_id"`
+	Thumbnails map[string]string `json:"thumbnails"` // size in pixels -> file ID
+}
+
+// FileIDs returns the IDs of the original and every thumbnail
+func (r *ImageRef) FileIDs() []string {
+	ids := []string{r.FileID}
+	for _, id := range r.Thumbnails {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Value implements driver.Valuer
+func (r ImageRef) Value() (driver.Value, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (r *ImageRef) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("unsupported ImageRef value type %T", value)
+	}
+}
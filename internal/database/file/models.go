@@ -8,18 +8,69 @@ import (
 
 // FileMetadata represents file metadata stored in database
 type FileMetadata struct {
-	ID          uint      `gorm:"primarykey" json:"id"`
-	Name        string    `gorm:"unique;not null" json:"name"`
-	Path        string    `gorm:"not null" json:"path"`
-	Size        int64     `gorm:"not null" json:"size"`
-	ContentType string    `gorm:"not null" json:"content_type"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uint       `gorm:"primarykey" json:"id"`
+	Name           string     `gorm:"unique;not null" json:"name"`
+	Path           string     `gorm:"not null" json:"path"`
+	Size           int64      `gorm:"not null;index" json:"size"`
+	ContentType    string     `gorm:"not null;index" json:"content_type"`
+	ETag           string     `gorm:"column:etag;size:255;not null;default:''" json:"etag"`
+	Generation     int64      `gorm:"not null;default:0" json:"generation"`
+	UploaderID     *uint      `gorm:"index" json:"uploader_id"`
+	Visibility     string     `gorm:"size:20;not null;default:public" json:"visibility"`
+	AttachableType string     `gorm:"size:20;not null;default:'';index:idx_file_metadata_attachable" json:"attachable_type"`
+	AttachableID   uint       `gorm:"not null;default:0;index:idx_file_metadata_attachable" json:"attachable_id"`
+	ScanStatus     string     `gorm:"size:20;not null;default:'';index" json:"scan_status"`
+	ScanThreat     string     `gorm:"size:255;not null;default:''" json:"scan_threat"`
+	ScannedAt      *time.Time `json:"scanned_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// UploadSession represents a resumable upload in progress
+type UploadSession struct {
+	ID             string    `gorm:"primarykey;size:64" json:"id"`
+	FileName       string    `gorm:"not null" json:"file_name"`
+	ContentType    string    `gorm:"not null;default:''" json:"content_type"`
+	UploadLength   int64     `gorm:"not null" json:"upload_length"`
+	UploadOffset   int64     `gorm:"not null;default:0" json:"upload_offset"`
+	UploaderID     *uint     `gorm:"index" json:"uploader_id"`
+	Visibility     string    `gorm:"size:20;not null;default:public" json:"visibility"`
+	AttachableType string    `gorm:"size:20;not null;default:''" json:"attachable_type"`
+	AttachableID   uint      `gorm:"not null;default:0" json:"attachable_id"`
+	ExpiresAt      time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// SignedUpload represents an upload URL issued for a new file that was not completed yet
+type SignedUpload struct {
+	FileName       string     `gorm:"primarykey" json:"file_name"`
+	ContentType    string     `gorm:"not null;default:''" json:"content_type"`
+	UploadLength   int64      `gorm:"not null" json:"upload_length"`
+	UploaderID     *uint      `gorm:"index" json:"uploader_id"`
+	Visibility     string     `gorm:"size:20;not null;default:public" json:"visibility"`
+	AttachableType string     `gorm:"size:20;not null;default:''" json:"attachable_type"`
+	AttachableID   uint       `gorm:"not null;default:0" json:"attachable_id"`
+	UsedAt         *time.Time `json:"used_at"`
+	ExpiresAt      time.Time  `gorm:"not null;index" json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// FileVersionScan records the content scan verdict of one stored version of a file
+type FileVersionScan struct {
+	FileName   string     `gorm:"primarykey" json:"file_name"`
+	Generation int64      `gorm:"primarykey;autoIncrement:false" json:"generation"`
+	ScanStatus string     `gorm:"size:20;not null" json:"scan_status"`
+	ScanThreat string     `gorm:"size:255;not null;default:''" json:"scan_threat"`
+	ScannedAt  *time.Time `json:"scanned_at"`
 }
 
 // Models returns all file-related models for migration
 var Models = []interface{}{
 	&FileMetadata{},
+	&UploadSession{},
+	&SignedUpload{},
+	&FileVersionScan{},
 }
 
 // AutoMigrate performs auto-migration for file models
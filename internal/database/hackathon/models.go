@@ -3,6 +3,8 @@ package hackathon
 import (
 	"time"
 
+	fileDB "github.com/TRu-S3/backend/internal/database/file"
+	userDB "github.com/TRu-S3/backend/internal/database/user"
 	"gorm.io/gorm"
 )
 
@@ -15,43 +17,234 @@ type Hackathon struct {
 	EndDate              time.Time              `gorm:"not null" json:"end_date"`
 	RegistrationStart    time.Time              `gorm:"not null" json:"registration_start"`
 	RegistrationDeadline time.Time              `gorm:"not null" json:"registration_deadline"`
+	SubmissionDeadline   *time.Time             `json:"submission_deadline"`
+	ResultsPublishedAt   *time.Time             `json:"results_published_at"`
 	MaxParticipants      int                    `gorm:"default:0" json:"max_participants"`
 	Location             string                 `gorm:"type:varchar(255)" json:"location"`
 	Organizer            string                 `gorm:"not null;type:varchar(255)" json:"organizer"`
 	ContactEmail         string                 `gorm:"type:varchar(255)" json:"contact_email"`
 	PrizeInfo            string                 `gorm:"type:text" json:"prize_info"`
 	Rules                string                 `gorm:"type:text" json:"rules"`
-	TechStack            string                 `gorm:"type:text" json:"tech_stack"` // JSON string
+	TechStack            []userDB.Tag           `gorm:"many2many:hackathon_tags;constraint:OnDelete:CASCADE" json:"tech_stack"`
 	Status               string                 `gorm:"default:upcoming;type:varchar(50)" json:"status"`
 	IsPublic             bool                   `gorm:"default:true" json:"is_public"`
-	BannerURL            string                 `gorm:"type:text" json:"banner_url"`
+	Banner               *fileDB.ImageRef       `gorm:"type:text" json:"banner"`
 	WebsiteURL           string                 `gorm:"type:text" json:"website_url"`
+	OwnerID              *uint                  `gorm:"index" json:"owner_id"`
+	SeriesID             *uint                  `gorm:"uniqueIndex:idx_hackathons_series_edition_stage" json:"series_id"`
+	Edition              *int                   `gorm:"uniqueIndex:idx_hackathons_series_edition_stage" json:"edition"`
+	StageID              *uint                  `gorm:"uniqueIndex:idx_hackathons_series_edition_stage;index" json:"stage_id"`
 	CreatedAt            time.Time              `json:"created_at"`
 	UpdatedAt            time.Time              `json:"updated_at"`
 	Participants         []HackathonParticipant `gorm:"foreignKey:HackathonID" json:"participants,omitempty"`
+	Series               *HackathonSeries       `gorm:"foreignKey:SeriesID;constraint:OnDelete:SET NULL" json:"series,omitempty"`
+	Stage                *SeriesStage           `gorm:"foreignKey:StageID;constraint:OnDelete:SET NULL" json:"stage,omitempty"`
+}
+
+// HackathonSeries groups the recurring editions of a hackathon. Each edition runs
+// through the series' stages, e.g. an online qualifier followed by an offline final.
+type HackathonSeries struct {
+	ID          uint          `gorm:"primarykey" json:"id"`
+	Name        string        `gorm:"not null;type:varchar(255)" json:"name"`
+	Description string        `gorm:"type:text" json:"description"`
+	OwnerID     *uint         `gorm:"index" json:"owner_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Stages      []SeriesStage `gorm:"foreignKey:SeriesID" json:"stages,omitempty"`
+
+	// Foreign key constraints
+	Owner *userDB.User `gorm:"foreignKey:OwnerID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// TableName specifies the table name for HackathonSeries
+func (HackathonSeries) TableName() string {
+	return "hackathon_series"
+}
+
+// SeriesStage is a round of every edition of a series, held in Position order
+type SeriesStage struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	SeriesID    uint      `gorm:"not null;uniqueIndex:idx_series_stages_series_position" json:"series_id"`
+	Name        string    `gorm:"not null;type:varchar(100)" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	Position    int       `gorm:"not null;uniqueIndex:idx_series_stages_series_position" json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Foreign key constraints
+	Series HackathonSeries `gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// HackathonStatusTransition records a change of a hackathon's status
+type HackathonStatusTransition struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	HackathonID uint      `gorm:"not null;index" json:"hackathon_id"`
+	FromStatus  string    `gorm:"not null;type:varchar(50)" json:"from_status"`
+	ToStatus    string    `gorm:"not null;type:varchar(50)" json:"to_status"`
+	Source      string    `gorm:"not null;type:varchar(20)" json:"source"` // scheduler or manual
+	ActorID     *uint     `json:"actor_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Foreign key constraints
+	Hackathon Hackathon `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 // HackathonParticipant represents a participant in a hackathon
 type HackathonParticipant struct {
-	ID               uint      `gorm:"primarykey" json:"id"`
-	HackathonID      uint      `gorm:"not null" json:"hackathon_id"`
-	UserID           uint      `gorm:"not null" json:"user_id"`
-	TeamName         string    `gorm:"type:varchar(255)" json:"team_name"`
-	Role             string    `gorm:"type:varchar(100)" json:"role"`
-	RegistrationDate time.Time `gorm:"autoCreateTime" json:"registration_date"`
-	Status           string    `gorm:"default:registered;type:varchar(50)" json:"status"`
-	Notes            string    `gorm:"type:text" json:"notes"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               uint       `gorm:"primarykey" json:"id"`
+	HackathonID      uint       `gorm:"not null" json:"hackathon_id"`
+	UserID           uint       `gorm:"not null" json:"user_id"`
+	TeamID           *uint      `gorm:"index" json:"team_id"`
+	Role             string     `gorm:"type:varchar(100)" json:"role"`
+	RegistrationDate time.Time  `gorm:"autoCreateTime" json:"registration_date"`
+	Status           string     `gorm:"default:registered;type:varchar(50)" json:"status"`
+	Notes            string     `gorm:"type:text" json:"notes"`
+	CheckedInAt      *time.Time `json:"checked_in_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	WaitlistPosition int        `gorm:"-" json:"waitlist_position,omitempty"` // 1-based queue position while waitlisted
+
+	// Foreign key constraints
+	Hackathon Hackathon    `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"hackathon,omitempty"`
+	Team      *Team        `gorm:"foreignKey:TeamID;constraint:OnDelete:SET NULL" json:"team,omitempty"`
+	User      *userDB.User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// Team represents a team of participants in a hackathon
+type Team struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	HackathonID uint      `gorm:"not null;uniqueIndex:idx_teams_hackathon_name_key" json:"hackathon_id"`
+	Name        string    `gorm:"not null;type:varchar(255)" json:"name"`
+	NameKey     string    `gorm:"not null;type:varchar(255);uniqueIndex:idx_teams_hackathon_name_key" json:"-"` // normalized name, unique per hackathon
+	Description string    `gorm:"type:text" json:"description"`
+	LeaderID    uint      `gorm:"not null;index" json:"leader_id"`
+	MaxSize     int       `gorm:"not null;default:5" json:"max_size"`
+	InviteCode  string    `gorm:"not null;type:varchar(32);uniqueIndex" json:"invite_code,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Foreign key constraints
+	Hackathon Hackathon              `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"-"`
+	Members   []HackathonParticipant `gorm:"foreignKey:TeamID" json:"members,omitempty"`
+}
+
+// Team invitation states
+const (
+	TeamInvitationPending   = "pending"
+	TeamInvitationAccepted  = "accepted"
+	TeamInvitationDeclined  = "declined"
+	TeamInvitationCancelled = "cancelled"
+)
+
+// TeamInvitation represents an invitation for a user to join a team
+type TeamInvitation struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	TeamID      uint       `gorm:"not null;index" json:"team_id"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	InvitedBy   uint       `gorm:"not null" json:"invited_by"`
+	Status      string     `gorm:"not null;default:pending;type:varchar(20)" json:"status"`
+	RespondedAt *time.Time `json:"responded_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Foreign key constraints
+	Team Team `gorm:"foreignKey:TeamID;constraint:OnDelete:CASCADE" json:"team,omitempty"`
+}
+
+// Submission is the project a team submits to a hackathon. Files are attached
+// through the file service with the "submission" attachment type.
+type Submission struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	HackathonID   uint      `gorm:"not null;index" json:"hackathon_id"`
+	TeamID        uint      `gorm:"not null;uniqueIndex" json:"team_id"`
+	Title         string    `gorm:"not null;type:varchar(255)" json:"title"`
+	Description   string    `gorm:"type:text" json:"description"`
+	RepositoryURL string    `gorm:"type:text" json:"repository_url"`
+	DemoURL       string    `gorm:"type:text" json:"demo_url"`
+	SubmittedBy   uint      `gorm:"not null" json:"submitted_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Files []fileDB.FileMetadata `gorm:"-" json:"files"`
+
+	// Foreign key constraints
+	Hackathon Hackathon `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"-"`
+	Team      *Team     `gorm:"foreignKey:TeamID;constraint:OnDelete:CASCADE" json:"team,omitempty"`
+}
+
+// HackathonJudge assigns a user as a judge of a hackathon
+type HackathonJudge struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	HackathonID uint      `gorm:"not null;uniqueIndex:idx_hackathon_judges_hackathon_user" json:"hackathon_id"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_hackathon_judges_hackathon_user" json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Foreign key constraints
+	Hackathon Hackathon    `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"-"`
+	User      *userDB.User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// JudgingCriterion is a weighted aspect submissions are scored on, from 0 to MaxScore
+type JudgingCriterion struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	HackathonID uint      `gorm:"not null;index" json:"hackathon_id"`
+	Name        string    `gorm:"not null;type:varchar(100)" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	Weight      float64   `gorm:"not null;default:1" json:"weight"`
+	MaxScore    int       `gorm:"not null;default:10" json:"max_score"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Foreign key constraints
+	Hackathon Hackathon `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for JudgingCriterion
+func (JudgingCriterion) TableName() string {
+	return "judging_criteria"
+}
+
+// ScoreSheet holds one judge's scores for one submission
+type ScoreSheet struct {
+	ID           uint             `gorm:"primarykey" json:"id"`
+	SubmissionID uint             `gorm:"not null;uniqueIndex:idx_score_sheets_submission_judge" json:"submission_id"`
+	JudgeID      uint             `gorm:"not null;uniqueIndex:idx_score_sheets_submission_judge;index" json:"judge_id"` // user ID of the judge
+	Comment      string           `gorm:"type:text" json:"comment"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	Scores       []CriterionScore `gorm:"foreignKey:ScoreSheetID" json:"scores"`
+
+	// Foreign key constraints
+	Submission Submission   `gorm:"foreignKey:SubmissionID;constraint:OnDelete:CASCADE" json:"-"`
+	Judge      *userDB.User `gorm:"foreignKey:JudgeID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CriterionScore is the score a score sheet gives for one criterion
+type CriterionScore struct {
+	ID           uint    `gorm:"primarykey" json:"-"`
+	ScoreSheetID uint    `gorm:"not null;uniqueIndex:idx_criterion_scores_sheet_criterion" json:"-"`
+	CriterionID  uint    `gorm:"not null;uniqueIndex:idx_criterion_scores_sheet_criterion;index" json:"criterion_id"`
+	Score        float64 `gorm:"not null" json:"score"`
 
 	// Foreign key constraints
-	Hackathon Hackathon `gorm:"foreignKey:HackathonID;constraint:OnDelete:CASCADE" json:"hackathon,omitempty"`
+	ScoreSheet ScoreSheet       `gorm:"foreignKey:ScoreSheetID;constraint:OnDelete:CASCADE" json:"-"`
+	Criterion  JudgingCriterion `gorm:"foreignKey:CriterionID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 // Models returns all hackathon-related models for migration
 var Models = []interface{}{
+	&HackathonSeries{},
+	&SeriesStage{},
 	&Hackathon{},
 	&HackathonParticipant{},
+	&Team{},
+	&TeamInvitation{},
+	&HackathonStatusTransition{},
+	&Submission{},
+	&HackathonJudge{},
+	&JudgingCriterion{},
+	&ScoreSheet{},
+	&CriterionScore{},
 }
 
 // AutoMigrate performs auto-migration for hackathon models
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS bookmarks;
+DROP TABLE IF EXISTS matchings;
+DROP TABLE IF EXISTS profiles;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS hackathon_participants;
+DROP TABLE IF EXISTS hackathons;
+DROP TABLE IF EXISTS contests;
+DROP TABLE IF EXISTS file_metadata;
@@ -0,0 +1,130 @@
+-- Baseline schema matching the models previously created by GORM AutoMigrate.
+-- Uses IF NOT EXISTS so databases created by AutoMigrate adopt it unchanged.
+
+CREATE TABLE IF NOT EXISTS file_metadata (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    path TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    content_type TEXT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT uni_file_metadata_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS contests (
+    id BIGSERIAL PRIMARY KEY,
+    backend_quota BIGINT NOT NULL DEFAULT 0,
+    frontend_quota BIGINT NOT NULL DEFAULT 0,
+    ai_quota BIGINT NOT NULL DEFAULT 0,
+    application_deadline TIMESTAMPTZ NOT NULL,
+    purpose TEXT NOT NULL,
+    message TEXT NOT NULL,
+    author_id BIGINT NOT NULL,
+    start_time TIMESTAMPTZ,
+    end_time TIMESTAMPTZ,
+    title TEXT,
+    description TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS hackathons (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL,
+    registration_start TIMESTAMPTZ NOT NULL,
+    registration_deadline TIMESTAMPTZ NOT NULL,
+    max_participants BIGINT DEFAULT 0,
+    location VARCHAR(255),
+    organizer VARCHAR(255) NOT NULL,
+    contact_email VARCHAR(255),
+    prize_info TEXT,
+    rules TEXT,
+    tech_stack TEXT,
+    status VARCHAR(50) DEFAULT 'upcoming',
+    is_public BOOLEAN DEFAULT true,
+    banner_url TEXT,
+    website_url TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS hackathon_participants (
+    id BIGSERIAL PRIMARY KEY,
+    hackathon_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    team_name VARCHAR(255),
+    role VARCHAR(100),
+    registration_date TIMESTAMPTZ,
+    status VARCHAR(50) DEFAULT 'registered',
+    notes TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_hackathons_participants FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    gmail VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS profiles (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    tag_id BIGINT,
+    bio TEXT,
+    age BIGINT,
+    location VARCHAR(100),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_users_profile FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_profiles_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS matchings (
+    id BIGSERIAL PRIMARY KEY,
+    user1_id BIGINT NOT NULL,
+    user2_id BIGINT NOT NULL,
+    status TEXT DEFAULT 'pending',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_matchings_user1 FOREIGN KEY (user1_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_matchings_user2 FOREIGN KEY (user2_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    bookmarked_user_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_users_bookmarks FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bookmarks_bookmarked_user FOREIGN KEY (bookmarked_user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_gmail ON users(gmail);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags(name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_profiles_user_id ON profiles(user_id);
+
+CREATE INDEX IF NOT EXISTS idx_file_metadata_name ON file_metadata(name);
+CREATE INDEX IF NOT EXISTS idx_file_metadata_path ON file_metadata(path);
+CREATE INDEX IF NOT EXISTS idx_file_metadata_created_at ON file_metadata(created_at);
+CREATE INDEX IF NOT EXISTS idx_contests_application_deadline ON contests(application_deadline);
+CREATE INDEX IF NOT EXISTS idx_contests_created_at ON contests(created_at);
+CREATE INDEX IF NOT EXISTS idx_hackathons_start_date ON hackathons(start_date);
+CREATE INDEX IF NOT EXISTS idx_hackathons_status ON hackathons(status);
+CREATE INDEX IF NOT EXISTS idx_hackathon_participants_hackathon_id ON hackathon_participants(hackathon_id);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_id ON bookmarks(user_id);
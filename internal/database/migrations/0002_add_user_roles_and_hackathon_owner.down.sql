@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_hackathons_owner_id;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS owner_id;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS owner_id BIGINT;
+CREATE INDEX IF NOT EXISTS idx_hackathons_owner_id ON hackathons(owner_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_file_metadata_size;
+DROP INDEX IF EXISTS idx_file_metadata_content_type;
+
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS generation;
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS etag;
@@ -0,0 +1,5 @@
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS etag VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS generation BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_file_metadata_content_type ON file_metadata(content_type);
+CREATE INDEX IF NOT EXISTS idx_file_metadata_size ON file_metadata(size);
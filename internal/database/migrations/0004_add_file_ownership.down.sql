@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_file_metadata_attachable;
+DROP INDEX IF EXISTS idx_file_metadata_uploader_id;
+
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS attachable_id;
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS attachable_type;
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS visibility;
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS uploader_id;
@@ -0,0 +1,7 @@
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS uploader_id BIGINT;
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS attachable_type VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS attachable_id BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_file_metadata_uploader_id ON file_metadata(uploader_id);
+CREATE INDEX IF NOT EXISTS idx_file_metadata_attachable ON file_metadata(attachable_type, attachable_id);
@@ -0,0 +1,4 @@
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS banner_url TEXT;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS banner;
+
+ALTER TABLE profiles DROP COLUMN IF EXISTS avatar;
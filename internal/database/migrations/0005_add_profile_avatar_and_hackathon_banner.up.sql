@@ -0,0 +1,4 @@
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS avatar TEXT;
+
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS banner TEXT;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS banner_url;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_file_metadata_scan_status;
+
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS scanned_at;
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS scan_threat;
+ALTER TABLE file_metadata DROP COLUMN IF EXISTS scan_status;
@@ -0,0 +1,5 @@
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS scan_threat VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE file_metadata ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_file_metadata_scan_status ON file_metadata(scan_status);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT '',
+    upload_length BIGINT NOT NULL,
+    upload_offset BIGINT NOT NULL DEFAULT 0,
+    uploader_id BIGINT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    attachable_type VARCHAR(20) NOT NULL DEFAULT '',
+    attachable_id BIGINT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_uploader_id ON upload_sessions(uploader_id);
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires_at ON upload_sessions(expires_at);
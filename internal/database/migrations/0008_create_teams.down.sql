@@ -0,0 +1,13 @@
+ALTER TABLE hackathon_participants ADD COLUMN IF NOT EXISTS team_name VARCHAR(255);
+
+UPDATE hackathon_participants p
+SET team_name = t.name
+FROM teams t
+WHERE t.id = p.team_id;
+
+DROP INDEX IF EXISTS idx_hackathon_participants_team_id;
+ALTER TABLE hackathon_participants DROP CONSTRAINT IF EXISTS fk_hackathon_participants_team;
+ALTER TABLE hackathon_participants DROP COLUMN IF EXISTS team_id;
+
+DROP TABLE IF EXISTS team_invitations;
+DROP TABLE IF EXISTS teams;
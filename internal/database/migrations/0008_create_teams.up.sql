@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS teams (
+    id BIGSERIAL PRIMARY KEY,
+    hackathon_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    name_key VARCHAR(255) NOT NULL,
+    description TEXT,
+    leader_id BIGINT NOT NULL,
+    max_size BIGINT NOT NULL DEFAULT 5,
+    invite_code VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_teams_hackathon FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teams_hackathon_name_key ON teams(hackathon_id, name_key);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teams_invite_code ON teams(invite_code);
+CREATE INDEX IF NOT EXISTS idx_teams_leader_id ON teams(leader_id);
+
+CREATE TABLE IF NOT EXISTS team_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    invited_by BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_team_invitations_team FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_invitations_team_id ON team_invitations(team_id);
+CREATE INDEX IF NOT EXISTS idx_team_invitations_user_id ON team_invitations(user_id);
+
+ALTER TABLE hackathon_participants ADD COLUMN IF NOT EXISTS team_id BIGINT;
+ALTER TABLE hackathon_participants ADD CONSTRAINT fk_hackathon_participants_team
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_hackathon_participants_team_id ON hackathon_participants(team_id);
+
+-- Turn free-text team names into teams. Names differing only in case or surrounding
+-- whitespace become one team, led by its earliest registrant.
+INSERT INTO teams (hackathon_id, name, name_key, leader_id, max_size, invite_code, created_at, updated_at)
+SELECT DISTINCT ON (hackathon_id, LOWER(TRIM(team_name)))
+    hackathon_id,
+    TRIM(team_name),
+    LOWER(TRIM(team_name)),
+    user_id,
+    GREATEST(5, COUNT(*) OVER (PARTITION BY hackathon_id, LOWER(TRIM(team_name)))),
+    SUBSTRING(MD5(RANDOM()::TEXT || id::TEXT) FOR 10),
+    NOW(),
+    NOW()
+FROM hackathon_participants
+WHERE team_name IS NOT NULL AND TRIM(team_name) <> ''
+ORDER BY hackathon_id, LOWER(TRIM(team_name)), registration_date, id;
+
+UPDATE hackathon_participants p
+SET team_id = t.id
+FROM teams t
+WHERE t.hackathon_id = p.hackathon_id
+  AND t.name_key = LOWER(TRIM(p.team_name));
+
+DROP INDEX IF EXISTS idx_hackathon_participants_team_name;
+ALTER TABLE hackathon_participants DROP COLUMN IF EXISTS team_name;
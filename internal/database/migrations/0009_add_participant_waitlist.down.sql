@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_hackathon_participants_hackathon_status;
+DROP TABLE IF EXISTS notifications;
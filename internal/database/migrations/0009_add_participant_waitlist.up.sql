@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    message TEXT,
+    resource_type VARCHAR(50),
+    resource_id BIGINT,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_notifications_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id);
+
+-- Capacity checks and waitlist lookups filter participants by hackathon and status
+CREATE INDEX IF NOT EXISTS idx_hackathon_participants_hackathon_status ON hackathon_participants(hackathon_id, status);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_hackathons_status_dates;
+DROP TABLE IF EXISTS hackathon_status_transitions;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS hackathon_status_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    hackathon_id BIGINT NOT NULL,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    actor_id BIGINT,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_hackathon_status_transitions_hackathon FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_hackathon_status_transitions_hackathon_id ON hackathon_status_transitions(hackathon_id);
+
+-- The scheduler looks up hackathons by status and schedule
+CREATE INDEX IF NOT EXISTS idx_hackathons_status_dates ON hackathons(status, start_date, end_date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS submissions;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS submission_deadline;
@@ -0,0 +1,19 @@
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS submissions (
+    id BIGSERIAL PRIMARY KEY,
+    hackathon_id BIGINT NOT NULL,
+    team_id BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    repository_url TEXT,
+    demo_url TEXT,
+    submitted_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_submissions_hackathon FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_submissions_team FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_submissions_hackathon_id ON submissions(hackathon_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_submissions_team_id ON submissions(team_id);
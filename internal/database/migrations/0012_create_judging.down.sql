@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS criterion_scores;
+DROP TABLE IF EXISTS score_sheets;
+DROP TABLE IF EXISTS judging_criteria;
+DROP TABLE IF EXISTS hackathon_judges;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS results_published_at;
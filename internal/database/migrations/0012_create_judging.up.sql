@@ -0,0 +1,52 @@
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS results_published_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS hackathon_judges (
+    id BIGSERIAL PRIMARY KEY,
+    hackathon_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_hackathon_judges_hackathon FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_hackathon_judges_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hackathon_judges_hackathon_user ON hackathon_judges(hackathon_id, user_id);
+
+CREATE TABLE IF NOT EXISTS judging_criteria (
+    id BIGSERIAL PRIMARY KEY,
+    hackathon_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    max_score BIGINT NOT NULL DEFAULT 10,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_judging_criteria_hackathon FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_judging_criteria_hackathon_id ON judging_criteria(hackathon_id);
+
+CREATE TABLE IF NOT EXISTS score_sheets (
+    id BIGSERIAL PRIMARY KEY,
+    submission_id BIGINT NOT NULL,
+    judge_id BIGINT NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_score_sheets_submission FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_score_sheets_judge FOREIGN KEY (judge_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_score_sheets_submission_judge ON score_sheets(submission_id, judge_id);
+CREATE INDEX IF NOT EXISTS idx_score_sheets_judge_id ON score_sheets(judge_id);
+
+CREATE TABLE IF NOT EXISTS criterion_scores (
+    id BIGSERIAL PRIMARY KEY,
+    score_sheet_id BIGINT NOT NULL,
+    criterion_id BIGINT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    CONSTRAINT fk_criterion_scores_score_sheet FOREIGN KEY (score_sheet_id) REFERENCES score_sheets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_criterion_scores_criterion FOREIGN KEY (criterion_id) REFERENCES judging_criteria(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_criterion_scores_sheet_criterion ON criterion_scores(score_sheet_id, criterion_id);
+CREATE INDEX IF NOT EXISTS idx_criterion_scores_criterion_id ON criterion_scores(criterion_id);
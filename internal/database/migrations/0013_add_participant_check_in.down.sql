@@ -0,0 +1 @@
+ALTER TABLE hackathon_participants DROP COLUMN IF EXISTS checked_in_at;
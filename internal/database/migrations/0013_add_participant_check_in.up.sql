@@ -0,0 +1 @@
+ALTER TABLE hackathon_participants ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMPTZ;
@@ -0,0 +1,10 @@
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS tech_stack TEXT;
+
+UPDATE hackathons h SET tech_stack = (
+    SELECT json_agg(t.name ORDER BY t.name)::text
+    FROM hackathon_tags ht
+    JOIN tags t ON t.id = ht.tag_id
+    WHERE ht.hackathon_id = h.id
+);
+
+DROP TABLE IF EXISTS hackathon_tags;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS hackathon_tags (
+    hackathon_id BIGINT NOT NULL,
+    tag_id BIGINT NOT NULL,
+    PRIMARY KEY (hackathon_id, tag_id),
+    CONSTRAINT fk_hackathon_tags_hackathon FOREIGN KEY (hackathon_id) REFERENCES hackathons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_hackathon_tags_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_hackathon_tags_tag_id ON hackathon_tags(tag_id);
+
+-- Carry over existing tech stacks, stored either as JSON arrays of names or as
+-- comma separated lists, matching tags case-insensitively
+CREATE TEMPORARY TABLE hackathon_tech_stack_items ON COMMIT DROP AS
+SELECT DISTINCT h.id AS hackathon_id, LEFT(BTRIM(item, E' \t\r\n"'), 50) AS name
+FROM hackathons h
+CROSS JOIN LATERAL regexp_split_to_table(BTRIM(h.tech_stack, E' \t\r\n[]'), ',') AS item
+WHERE h.tech_stack IS NOT NULL;
+
+DELETE FROM hackathon_tech_stack_items WHERE name = '';
+
+INSERT INTO tags (name, created_at, updated_at)
+SELECT DISTINCT ON (LOWER(i.name)) i.name, NOW(), NOW()
+FROM hackathon_tech_stack_items i
+WHERE NOT EXISTS (SELECT 1 FROM tags t WHERE LOWER(t.name) = LOWER(i.name))
+ORDER BY LOWER(i.name), i.name
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO hackathon_tags (hackathon_id, tag_id)
+SELECT DISTINCT i.hackathon_id, (SELECT MIN(t.id) FROM tags t WHERE LOWER(t.name) = LOWER(i.name))
+FROM hackathon_tech_stack_items i
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE hackathons DROP COLUMN IF EXISTS tech_stack;
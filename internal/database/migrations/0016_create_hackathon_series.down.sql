@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_hackathons_stage_id;
+DROP INDEX IF EXISTS idx_hackathons_series_edition_stage;
+ALTER TABLE hackathons DROP CONSTRAINT IF EXISTS fk_hackathons_stage;
+ALTER TABLE hackathons DROP CONSTRAINT IF EXISTS fk_hackathons_series;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS stage_id;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS edition;
+ALTER TABLE hackathons DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS series_stages;
+DROP TABLE IF EXISTS hackathon_series;
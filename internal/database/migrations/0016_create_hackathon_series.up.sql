@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS hackathon_series (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    owner_id BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_hackathon_series_owner FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_hackathon_series_owner_id ON hackathon_series(owner_id);
+
+CREATE TABLE IF NOT EXISTS series_stages (
+    id BIGSERIAL PRIMARY KEY,
+    series_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    position BIGINT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_series_stages_series FOREIGN KEY (series_id) REFERENCES hackathon_series(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_series_stages_series_position ON series_stages(series_id, position);
+
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS series_id BIGINT;
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS edition BIGINT;
+ALTER TABLE hackathons ADD COLUMN IF NOT EXISTS stage_id BIGINT;
+ALTER TABLE hackathons ADD CONSTRAINT fk_hackathons_series FOREIGN KEY (series_id) REFERENCES hackathon_series(id) ON DELETE SET NULL;
+ALTER TABLE hackathons ADD CONSTRAINT fk_hackathons_stage FOREIGN KEY (stage_id) REFERENCES series_stages(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_hackathons_series_edition_stage ON hackathons(series_id, edition, stage_id);
+CREATE INDEX IF NOT EXISTS idx_hackathons_stage_id ON hackathons(stage_id);
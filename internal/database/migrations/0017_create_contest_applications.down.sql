@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_contest_applications_active;
+DROP INDEX IF EXISTS idx_contest_applications_user_id;
+DROP INDEX IF EXISTS idx_contest_applications_contest_role_status;
+DROP TABLE IF EXISTS contest_applications;
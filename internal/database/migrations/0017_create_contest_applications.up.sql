@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS contest_applications (
+    id BIGSERIAL PRIMARY KEY,
+    contest_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    message TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decided_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_contest_applications_contest FOREIGN KEY (contest_id) REFERENCES contests(id) ON DELETE CASCADE,
+    CONSTRAINT fk_contest_applications_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_contest_applications_contest_role_status ON contest_applications(contest_id, role, status);
+CREATE INDEX IF NOT EXISTS idx_contest_applications_user_id ON contest_applications(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contest_applications_active ON contest_applications(contest_id, user_id) WHERE status IN ('pending', 'accepted');
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_users_google_subject;
+ALTER TABLE users DROP COLUMN IF EXISTS google_subject;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS signed_uploads (
+    file_name TEXT PRIMARY KEY,
+    content_type TEXT NOT NULL DEFAULT '',
+    upload_length BIGINT NOT NULL,
+    uploader_id BIGINT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    attachable_type VARCHAR(20) NOT NULL DEFAULT '',
+    attachable_id BIGINT NOT NULL DEFAULT 0,
+    used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_signed_uploads_uploader_id ON signed_uploads(uploader_id);
+CREATE INDEX IF NOT EXISTS idx_signed_uploads_expires_at ON signed_uploads(expires_at);
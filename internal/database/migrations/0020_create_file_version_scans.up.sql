@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS file_version_scans (
+    file_name TEXT NOT NULL,
+    generation BIGINT NOT NULL,
+    scan_status VARCHAR(20) NOT NULL,
+    scan_threat VARCHAR(255) NOT NULL DEFAULT '',
+    scanned_at TIMESTAMPTZ,
+    PRIMARY KEY (file_name, generation)
+);
+
+-- The live content of existing files keeps the verdict recorded on its metadata row
+INSERT INTO file_version_scans (file_name, generation, scan_status, scan_threat, scanned_at)
+SELECT name, generation, scan_status, scan_threat, scanned_at
+FROM file_metadata
+WHERE scan_status <> '' AND generation <> 0
+ON CONFLICT DO NOTHING;
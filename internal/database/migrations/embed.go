@@ -0,0 +1,13 @@
+// Package migrations embeds the versioned SQL schema migrations applied by database.Migrator.
+//
+// Files are named <version>_<name>.up.sql / <version>_<name>.down.sql. Applied
+// migrations are checksummed, so never edit a migration once it has shipped;
+// add a new version instead.
+package migrations
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed *.sql
+var FS embed.FS
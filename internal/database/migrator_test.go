@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/TRu-S3/backend/internal/database/migrations"
+)
+
+func testMigrationFS() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_create_widgets.up.sql":     {Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+		"0001_create_widgets.down.sql":   {Data: []byte("DROP TABLE widgets;")},
+		"0002_add_widget_color.up.sql":   {Data: []byte("ALTER TABLE widgets ADD COLUMN color TEXT;")},
+		"0002_add_widget_color.down.sql": {Data: []byte("ALTER TABLE widgets DROP COLUMN color;")},
+	}
+}
+
+func setupMigratorTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	// Every connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("Sorted by version", func(t *testing.T) {
+		loaded, err := LoadMigrations(testMigrationFS())
+		require.NoError(t, err)
+		require.Len(t, loaded, 2)
+		assert.Equal(t, int64(1), loaded[0].Version)
+		assert.Equal(t, "create_widgets", loaded[0].Name)
+		assert.Len(t, loaded[0].Checksum, 64)
+		assert.Equal(t, int64(2), loaded[1].Version)
+	})
+
+	t.Run("Missing down file", func(t *testing.T) {
+		fsys := testMigrationFS()
+		delete(fsys, "0002_add_widget_color.down.sql")
+		_, err := LoadMigrations(fsys)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid file name", func(t *testing.T) {
+		fsys := testMigrationFS()
+		fsys["widgets.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+		_, err := LoadMigrations(fsys)
+		assert.Error(t, err)
+	})
+
+	t.Run("Embedded migrations are valid", func(t *testing.T) {
+		loaded, err := LoadMigrations(migrations.FS)
+		require.NoError(t, err)
+		assert.NotEmpty(t, loaded)
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Up, status and down", func(t *testing.T) {
+		db := setupMigratorTestDB(t)
+		migrator, err := NewMigrator(db, testMigrationFS())
+		require.NoError(t, err)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, 2)
+		assert.True(t, db.Migrator().HasColumn("widgets", "color"))
+
+		applied, err = migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+
+		reverted, err := migrator.Down(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, reverted, 1)
+		assert.Equal(t, int64(2), reverted[0].Version)
+		assert.False(t, db.Migrator().HasColumn("widgets", "color"))
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		assert.True(t, statuses[0].Applied)
+		assert.NotNil(t, statuses[0].AppliedAt)
+		assert.False(t, statuses[1].Applied)
+	})
+
+	t.Run("Checksum mismatch", func(t *testing.T) {
+		db := setupMigratorTestDB(t)
+		migrator, err := NewMigrator(db, testMigrationFS())
+		require.NoError(t, err)
+		_, err = migrator.Up(ctx)
+		require.NoError(t, err)
+
+		edited := testMigrationFS()
+		edited["0002_add_widget_color.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE widgets ADD COLUMN colour TEXT;")}
+		migrator, err = NewMigrator(db, edited)
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, statuses[1].ChecksumMismatch)
+	})
+
+	t.Run("Unknown applied migration", func(t *testing.T) {
+		db := setupMigratorTestDB(t)
+		migrator, err := NewMigrator(db, testMigrationFS())
+		require.NoError(t, err)
+		_, err = migrator.Up(ctx)
+		require.NoError(t, err)
+
+		older := testMigrationFS()
+		delete(older, "0002_add_widget_color.up.sql")
+		delete(older, "0002_add_widget_color.down.sql")
+		migrator, err = NewMigrator(db, older)
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		assert.ErrorIs(t, err, ErrUnknownMigration)
+	})
+
+	t.Run("Failed migration is rolled back", func(t *testing.T) {
+		db := setupMigratorTestDB(t)
+		fsys := testMigrationFS()
+		fsys["0003_broken.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE gadgets (id INTEGER); INSERT INTO missing_table VALUES (1);")}
+		fsys["0003_broken.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE gadgets;")}
+		migrator, err := NewMigrator(db, fsys)
+		require.NoError(t, err)
+
+		_, err = migrator.Up(ctx)
+		assert.Error(t, err)
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, statuses[1].Applied)
+		assert.False(t, statuses[2].Applied)
+		assert.False(t, db.Migrator().HasTable("gadgets"))
+	})
+}
@@ -1,77 +1,58 @@
 package database
 
 import (
+	"context"
 	"log"
 
 	contestDB "github.com/TRu-S3/backend/internal/database/contest"
 	fileDB "github.com/TRu-S3/backend/internal/database/file"
 	hackathonDB "github.com/TRu-S3/backend/internal/database/hackathon"
+	"github.com/TRu-S3/backend/internal/database/migrations"
 	userDB "github.com/TRu-S3/backend/internal/database/user"
 	"gorm.io/gorm"
 )
 
 // Legacy type aliases for backward compatibility
 type FileMetadata = fileDB.FileMetadata
+type ImageRef = fileDB.ImageRef
+type UploadSession = fileDB.UploadSession
+type SignedUpload = fileDB.SignedUpload
+type FileVersionScan = fileDB.FileVersionScan
 type Contest = contestDB.Contest
+type ContestApplication = contestDB.ContestApplication
 type Hackathon = hackathonDB.Hackathon
 type HackathonParticipant = hackathonDB.HackathonParticipant
+type Team = hackathonDB.Team
+type TeamInvitation = hackathonDB.TeamInvitation
+type HackathonStatusTransition = hackathonDB.HackathonStatusTransition
+type Submission = hackathonDB.Submission
+type HackathonJudge = hackathonDB.HackathonJudge
+type JudgingCriterion = hackathonDB.JudgingCriterion
+type ScoreSheet = hackathonDB.ScoreSheet
+type CriterionScore = hackathonDB.CriterionScore
+type HackathonSeries = hackathonDB.HackathonSeries
+type SeriesStage = hackathonDB.SeriesStage
 type User = userDB.User
 type Tag = userDB.Tag
 type Profile = userDB.Profile
 type Matching = userDB.Matching
 type Bookmark = userDB.Bookmark
+type Notification = userDB.Notification
 
-// Migrate runs database migrations for all domains
+// Migrate applies all pending versioned SQL migrations
 func Migrate(db *gorm.DB) error {
 	log.Println("Running database migrations...")
 
-	// Migrate all domain models
-	if err := fileDB.AutoMigrate(db); err != nil {
+	migrator, err := NewMigrator(db, migrations.FS)
+	if err != nil {
 		return err
 	}
 
-	if err := contestDB.AutoMigrate(db); err != nil {
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
 		return err
 	}
 
-	if err := hackathonDB.AutoMigrate(db); err != nil {
-		return err
-	}
-
-	if err := userDB.AutoMigrate(db); err != nil {
-		return err
-	}
-
-	// Add custom indexes
-	if err := createIndexes(db); err != nil {
-		log.Printf("Warning: Some indexes could not be created: %v", err)
-	}
-
-	log.Println("Database migrations completed successfully")
+	log.Printf("Database migrations completed successfully (%d applied)", len(applied))
 	return nil
 }
-
-// createIndexes creates additional database indexes for performance
-func createIndexes(db *gorm.DB) error {
-	indexes := []string{
-		"CREATE INDEX IF NOT EXISTS idx_file_metadata_name ON file_metadata(name)",
-		"CREATE INDEX IF NOT EXISTS idx_file_metadata_path ON file_metadata(path)",
-		"CREATE INDEX IF NOT EXISTS idx_file_metadata_created_at ON file_metadata(created_at)",
-		"CREATE INDEX IF NOT EXISTS idx_contests_application_deadline ON contests(application_deadline)",
-		"CREATE INDEX IF NOT EXISTS idx_contests_created_at ON contests(created_at)",
-		"CREATE INDEX IF NOT EXISTS idx_hackathons_start_date ON hackathons(start_date)",
-		"CREATE INDEX IF NOT EXISTS idx_hackathons_status ON hackathons(status)",
-		"CREATE INDEX IF NOT EXISTS idx_hackathon_participants_hackathon_id ON hackathon_participants(hackathon_id)",
-		"CREATE INDEX IF NOT EXISTS idx_users_gmail ON users(gmail)",
-		"CREATE INDEX IF NOT EXISTS idx_profiles_user_id ON profiles(user_id)",
-		"CREATE INDEX IF NOT EXISTS idx_bookmarks_user_id ON bookmarks(user_id)",
-	}
-
-	for _, index := range indexes {
-		if err := db.Exec(index).Error; err != nil {
-			log.Printf("Warning: Could not create index: %v", err)
-		}
-	}
-
-	return nil
-}
\ No newline at end of file
@@ -3,22 +3,37 @@ package user
 import (
 	"time"
 
+	fileDB "github.com/TRu-S3/backend/internal/database/file"
 	"gorm.io/gorm"
 )
 
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // User represents a user in the system
 type User struct {
-	ID        uint      `gorm:"primarykey" json:"id"`
-	Name      string    `gorm:"size:100;not null" json:"name"`
-	Gmail     string    `gorm:"size:100;uniqueIndex;not null" json:"gmail"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uint      `gorm:"primarykey" json:"id"`
+	Name          string    `gorm:"size:100;not null" json:"name"`
+	Gmail         string    `gorm:"size:100;uniqueIndex;not null" json:"gmail"`
+	Role          string    `gorm:"size:20;not null;default:user" json:"role"`
+	GoogleSubject *string   `gorm:"size:255;uniqueIndex" json:"-"` // Google account ID (sub) the user logs in with
+	CalendarToken *string   `gorm:"size:64;uniqueIndex" json:"-"`  // secret of the user's calendar feed URL
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 
 	// Relationships
 	Profile   *Profile   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"profile,omitempty"`
 	Bookmarks []Bookmark `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"bookmarks,omitempty"`
 }
 
+// IsAdmin reports whether the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
 // Tag represents a tag for categorization
 type Tag struct {
 	ID        uint      `gorm:"primarykey" json:"id"`
@@ -29,14 +44,15 @@ type Tag struct {
 
 // Profile represents a user's profile information
 type Profile struct {
-	ID        uint      `gorm:"primarykey" json:"id"`
-	UserID    uint      `gorm:"uniqueIndex;not null" json:"user_id"`
-	TagID     uint      `json:"tag_id"`
-	Bio       string    `gorm:"type:text" json:"bio"`
-	Age       int       `json:"age"`
-	Location  string    `gorm:"size:100" json:"location"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint             `gorm:"primarykey" json:"id"`
+	UserID    uint             `gorm:"uniqueIndex;not null" json:"user_id"`
+	TagID     uint             `json:"tag_id"`
+	Bio       string           `gorm:"type:text" json:"bio"`
+	Age       int              `json:"age"`
+	Location  string           `gorm:"size:100" json:"location"`
+	Avatar    *fileDB.ImageRef `gorm:"type:text" json:"avatar"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
 
 	// Foreign key relationships
 	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
@@ -70,6 +86,33 @@ type Bookmark struct {
 	BookmarkedUser User `gorm:"foreignKey:BookmarkedUserID;constraint:OnDelete:CASCADE" json:"bookmarked_user,omitempty"`
 }
 
+// Notification types
+const (
+	NotificationWaitlistPromoted    = "waitlist_promoted"
+	NotificationHackathonCancelled  = "hackathon_cancelled"
+	NotificationMatchingAccepted    = "matching_accepted"
+	NotificationResultsPublished    = "results_published"
+	NotificationStageAdvanced       = "stage_advanced"
+	NotificationApplicationReceived = "application_received"
+	NotificationApplicationAccepted = "application_accepted"
+	NotificationApplicationRejected = "application_rejected"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	Type         string     `gorm:"not null;type:varchar(50)" json:"type"`
+	Message      string     `gorm:"type:text" json:"message"`
+	ResourceType string     `gorm:"type:varchar(50)" json:"resource_type,omitempty"`
+	ResourceID   uint       `json:"resource_id,omitempty"`
+	ReadAt       *time.Time `json:"read_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Foreign key relationships
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
 // Models returns all user-related models for migration
 var Models = []interface{}{
 	&User{},
@@ -77,6 +120,7 @@ var Models = []interface{}{
 	&Profile{},
 	&Matching{},
 	&Bookmark{},
+	&Notification{},
 }
 
 // AutoMigrate performs auto-migration for user models
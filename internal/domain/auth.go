@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrEmailNotVerified = errors.New("email not verified")
+	ErrUnauthenticated  = errors.New("authentication required")
+)
+
+// Identity represents a user identity asserted by an external identity provider
+type Identity struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
+
+// SessionClaims represents the claims carried by a session token issued by this service
+type SessionClaims struct {
+	UserID    uint      `json:"user_id"`
+	Email     string    `json:"email"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IDTokenVerifier defines the contract for verifying ID tokens issued by an identity provider
+type IDTokenVerifier interface {
+	// Verify validates the raw ID token and returns the identity it asserts
+	Verify(ctx context.Context, rawToken string) (*Identity, error)
+}
+
+// SessionManager defines the contract for issuing and parsing session tokens
+type SessionManager interface {
+	// Issue creates a signed session token for the given user
+	Issue(userID uint, email string) (string, *SessionClaims, error)
+
+	// Parse validates a session token and returns its claims
+	Parse(token string) (*SessionClaims, error)
+}
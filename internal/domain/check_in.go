@@ -0,0 +1,26 @@
+package domain
+
+import "strings"
+
+// CheckInClaims identifies the hackathon participant a check-in token was issued to
+type CheckInClaims struct {
+	HackathonID   uint
+	ParticipantID uint
+}
+
+// CheckInTokenSigner defines the contract for issuing and verifying the tokens
+// encoded in check-in QR codes
+type CheckInTokenSigner interface {
+	// Sign issues a token for the participant
+	Sign(claims CheckInClaims) string
+
+	// Verify validates a token and returns the participant it was issued to
+	Verify(token string) (*CheckInClaims, error)
+}
+
+// IsOnSite reports whether a hackathon held at location takes place in person.
+// Hackathons without a location or with an "online" location are held online.
+func IsOnSite(location string) bool {
+	location = strings.TrimSpace(location)
+	return location != "" && !strings.EqualFold(location, "online") && location != "オンライン"
+}
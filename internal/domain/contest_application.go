@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// ApplicationsOpen reports whether a contest with the given application deadline
+// still takes applications at now
+func ApplicationsOpen(deadline, now time.Time) bool {
+	return now.Before(deadline)
+}
+
+// RemainingSlots returns how many more applicants a role with quota can accept
+// once accepted applications have been counted
+func RemainingSlots(quota int, accepted int64) int {
+	if remaining := quota - int(accepted); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
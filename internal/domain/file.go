@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"io"
 	"time"
 )
 
@@ -11,8 +12,12 @@ type File struct {
 	Path        string    `json:"path"`
 	Size        int64     `json:"size"`
 	ContentType string    `json:"content_type"`
+	ETag        string    `json:"etag,omitempty"`
+	Generation  int64     `json:"generation,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	FileAccess
+	FileScan
 }
 
 // FileData represents file content with metadata
@@ -21,6 +26,23 @@ type FileData struct {
 	Content []byte `json:"content"`
 }
 
+// FileReader streams a byte range of file content together with its metadata.
+// The caller must close Content.
+type FileReader struct {
+	File    *File
+	Content io.ReadCloser
+	Offset  int64
+	Length  int64
+}
+
+// CreateFileStreamRequest represents a request to create a file from a stream
+type CreateFileStreamRequest struct {
+	Name        string
+	ContentType string
+	Content     io.Reader
+	Access      *FileAccessRequest // nil creates a public file without uploader
+}
+
 // CreateFileRequest represents a request to create a file
 type CreateFileRequest struct {
 	Name        string `json:"name" binding:"required"`
@@ -28,16 +50,34 @@ type CreateFileRequest struct {
 	ContentType string `json:"content_type"`
 }
 
-// UpdateFileRequest represents a request to update a file
+// UpdateFileRequest represents a request to rename a file or replace its content.
+// A nil Content keeps the stored content.
 type UpdateFileRequest struct {
-	Name        string `json:"name"`
-	Content     []byte `json:"content"`
-	ContentType string `json:"content_type"`
+	Name        string
+	Content     io.Reader
+	ContentType string
 }
 
+// Sortable fields for file listings
+const (
+	FileSortName        = "name"
+	FileSortSize        = "size"
+	FileSortContentType = "content_type"
+	FileSortCreatedAt   = "created_at"
+	FileSortUpdatedAt   = "updated_at"
+)
+
 // FileQuery represents query parameters for listing files
 type FileQuery struct {
-	Prefix string `json:"prefix"`
-	Limit  int    `json:"limit"`
-	Offset int    `json:"offset"`
+	Prefix      string `json:"prefix"`
+	ContentType string `json:"content_type"` // exact type, or "image/*" for a whole family
+	MinSize     *int64 `json:"min_size"`
+	MaxSize     *int64 `json:"max_size"`
+	SortBy      string `json:"sort_by"`
+	Desc        bool   `json:"desc"`
+	Limit       int    `json:"limit"`
+	Offset      int    `json:"offset"`
+
+	Attachment *FileAttachment   `json:"attachment"`
+	Access     *FileAccessFilter `json:"-"` // nil lists every file
 }
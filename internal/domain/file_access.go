@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrFileAccessDenied   = errors.New("file access denied")
+	ErrInvalidVisibility  = errors.New("invalid file visibility")
+	ErrInvalidAttachment  = errors.New("invalid file attachment")
+	ErrAttachmentNotFound = errors.New("attachment target not found")
+	ErrAttachmentLocked   = errors.New("files of the attachment target can no longer be changed")
+)
+
+// File visibility levels
+const (
+	FileVisibilityPrivate          = "private"           // uploader and admins only
+	FileVisibilityHackathonMembers = "hackathon_members" // also the owner and participants of the attached hackathon
+	FileVisibilityPublic           = "public"            // everyone
+)
+
+// Entity types a file can be attached to
+const (
+	AttachmentProfile     = "profile"
+	AttachmentHackathon   = "hackathon"
+	AttachmentContest     = "contest"
+	AttachmentParticipant = "participant" // a participant team of a hackathon
+	AttachmentSubmission  = "submission"  // a team's hackathon submission
+)
+
+// FileAttachment links a file to the domain entity it belongs to
+type FileAttachment struct {
+	Type string `json:"type"`
+	ID   uint   `json:"id"`
+}
+
+// FileAccess holds who uploaded a file, who may read it and what it is attached to
+type FileAccess struct {
+	UploaderID *uint           `json:"uploader_id,omitempty"`
+	Visibility string          `json:"visibility,omitempty"`
+	Attachment *FileAttachment `json:"attachment,omitempty"`
+}
+
+// FileViewer identifies the user accessing files. A nil viewer is anonymous.
+type FileViewer struct {
+	UserID uint
+	Admin  bool
+}
+
+// FileAccessRequest carries the ownership requested for a new file
+type FileAccessRequest struct {
+	Uploader   *FileViewer
+	Visibility string
+	Attachment *FileAttachment
+}
+
+// FileAccessFilter limits listings to the files a viewer may see.
+// Files uploaded by UploaderID are visible whatever their visibility.
+type FileAccessFilter struct {
+	Visibilities []string
+	UploaderID   *uint
+}
+
+// FileAccessRepository resolves the entities files are attached to
+type FileAccessRepository interface {
+	// AttachmentOwners returns the users allowed to attach files to the entity.
+	// It returns ErrAttachmentNotFound when the entity does not exist.
+	AttachmentOwners(ctx context.Context, attachment FileAttachment) ([]uint, error)
+
+	// IsHackathonMember reports whether the user owns or participates in the hackathon the attachment belongs to
+	IsHackathonMember(ctx context.Context, attachment FileAttachment, userID uint) (bool, error)
+
+	// AttachmentLocked reports whether the files attached to the entity are frozen,
+	// e.g. those of a submission once submissions are closed
+	AttachmentLocked(ctx context.Context, attachment FileAttachment) (bool, error)
+}
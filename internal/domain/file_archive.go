@@ -0,0 +1,17 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidArchiveRequest = errors.New("archive request must select files by ID or by query")
+	ErrArchiveEmpty          = errors.New("archive request matched no files")
+	ErrArchiveTooLarge       = errors.New("archive request matched too many files")
+)
+
+// ArchiveRequest selects the files to export as a ZIP archive, either by ID or by
+// a prefix and attachment query
+type ArchiveRequest struct {
+	IDs        []string        `json:"ids"`
+	Prefix     string          `json:"prefix"`
+	Attachment *FileAttachment `json:"attachment"`
+}
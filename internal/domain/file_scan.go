@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrFileInfected    = errors.New("file failed the content scan")
+	ErrFileQuarantined = errors.New("file is quarantined")
+	ErrFileNotScanned  = errors.New("file has not passed the content scan")
+
+	ErrScanningUnsupported = errors.New("content scanning is not configured")
+)
+
+// Content scan states of a file. Files stored without a scanner configured have no state.
+const (
+	ScanStatusPending  = "pending"  // not scanned yet
+	ScanStatusClean    = "clean"    // scanned without findings
+	ScanStatusInfected = "infected" // quarantined
+	ScanStatusFailed   = "failed"   // the scanner could not be reached or errored
+)
+
+// FileScan holds the result of the latest content scan of a file
+type FileScan struct {
+	ScanStatus string     `json:"scan_status,omitempty"`
+	ScanThreat string     `json:"scan_threat,omitempty"`
+	ScannedAt  *time.Time `json:"scanned_at,omitempty"`
+}
+
+// DownloadError reports why the content may not be downloaded, or nil if it may
+func (s FileScan) DownloadError() error {
+	switch s.ScanStatus {
+	case "", ScanStatusClean:
+		return nil
+	case ScanStatusInfected:
+		return ErrFileQuarantined
+	default:
+		return ErrFileNotScanned
+	}
+}
+
+// ScanResult is the verdict of a FileScanner
+type ScanResult struct {
+	Clean  bool
+	Threat string // name of the detected signature when not clean
+}
+
+// FileScanner inspects file content for malware or otherwise unwanted content
+type FileScanner interface {
+	// Scan reads content to the end and returns the verdict
+	Scan(ctx context.Context, content io.Reader) (*ScanResult, error)
+}
+
+// ScanRejection reports an upload whose content was quarantined. It wraps ErrFileInfected.
+type ScanRejection struct {
+	FileID string `json:"file_id"`
+	Threat string `json:"threat"`
+}
+
+// Error implements error
+func (e *ScanRejection) Error() string {
+	return fmt.Sprintf("%v: %s", ErrFileInfected, e.Threat)
+}
+
+// Unwrap returns ErrFileInfected
+func (e *ScanRejection) Unwrap() error {
+	return ErrFileInfected
+}
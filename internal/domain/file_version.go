@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrVersionNotFound       = errors.New("file version not found")
+	ErrVersioningUnsupported = errors.New("file versioning is not supported by the storage backend")
+)
+
+// FileVersion is one stored generation of a file's content
+type FileVersion struct {
+	Generation  int64      `json:"generation"`
+	Size        int64      `json:"size"`
+	ContentType string     `json:"content_type"`
+	ETag        string     `json:"etag,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`            // when this content was written
+	ReplacedAt  *time.Time `json:"replaced_at,omitempty"` // when newer content replaced it; nil for the live version
+	Live        bool       `json:"live"`
+}
+
+// VersionRetention limits how many replaced versions of a file are kept.
+// Zero values disable the respective limit.
+type VersionRetention struct {
+	MaxVersions int           // replaced versions kept per file
+	MaxAge      time.Duration // how long a replaced version is kept
+}
+
+// FileVersionRepository is implemented by storage backends that keep the previous
+// content of a file whenever it is overwritten or renamed
+type FileVersionRepository interface {
+	// ListVersions lists the live and replaced versions of a file, newest first
+	ListVersions(ctx context.Context, id string) ([]*FileVersion, error)
+
+	// NewVersionReader opens a reader over a byte range of a specific version.
+	// A negative length reads to the end of the version.
+	NewVersionReader(ctx context.Context, id string, generation, offset, length int64) (*FileReader, error)
+
+	// RestoreVersion writes the content of a version as the new live version.
+	// The content it replaces is kept as a version.
+	RestoreVersion(ctx context.Context, id string, generation int64) (*File, error)
+
+	// DeleteVersion permanently deletes a replaced version. The live version cannot be deleted.
+	DeleteVersion(ctx context.Context, id string, generation int64) error
+
+	// RenameFile moves a file together with its versions to newID
+	RenameFile(ctx context.Context, id, newID string) (*File, error)
+}
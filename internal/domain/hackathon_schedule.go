@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Hackathon statuses. Upcoming and ongoing hackathons follow their schedule;
+// completed and cancelled are final.
+const (
+	HackathonStatusUpcoming  = "upcoming"
+	HackathonStatusOngoing   = "ongoing"
+	HackathonStatusCompleted = "completed"
+	HackathonStatusCancelled = "cancelled"
+)
+
+// What caused a hackathon status transition
+const (
+	TransitionSourceScheduler = "scheduler"
+	TransitionSourceManual    = "manual"
+)
+
+// HackathonStatusTransition records a change of a hackathon's status
+type HackathonStatusTransition struct {
+	HackathonID uint      `json:"hackathon_id"`
+	FromStatus  string    `json:"from_status"`
+	ToStatus    string    `json:"to_status"`
+	Source      string    `json:"source"`
+	ActorID     *uint     `json:"actor_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ScheduledHackathonStatus returns the status a hackathon running from start to end has at now
+func ScheduledHackathonStatus(start, end, now time.Time) string {
+	switch {
+	case now.Before(start):
+		return HackathonStatusUpcoming
+	case now.Before(end):
+		return HackathonStatusOngoing
+	default:
+		return HackathonStatusCompleted
+	}
+}
+
+// HackathonScheduleRepository applies schedule-driven status changes
+type HackathonScheduleRepository interface {
+	// AdvanceStatuses moves every upcoming or ongoing hackathon into the status its
+	// schedule gives it at now and records each step. It makes no changes while
+	// another instance is advancing statuses.
+	AdvanceStatuses(ctx context.Context, now time.Time) ([]*HackathonStatusTransition, error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	ErrImageTooLarge    = errors.New("image too large")
+)
+
+// Thumbnail is a resized rendition of an image whose longest edge is at most Size pixels
+type Thumbnail struct {
+	Size    int
+	Width   int
+	Height  int
+	Content []byte
+}
+
+// ProcessedImage is an uploaded image re-encoded without metadata, together with its thumbnails
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Original    []byte
+	Thumbnails  []Thumbnail
+}
+
+// ImageProcessor validates uploaded images by their content and prepares them for storage
+type ImageProcessor interface {
+	// Process decodes the image, strips its metadata and renders its thumbnails.
+	// It returns ErrUnsupportedImage for content that is not a supported image.
+	Process(ctx context.Context, r io.Reader) (*ProcessedImage, error)
+}
+
+// UploadImageRequest represents a request to store an image and its thumbnails under Dir
+type UploadImageRequest struct {
+	Dir     string
+	Content io.Reader
+	Access  *FileAccessRequest
+}
+
+// StoredImage references a stored image and its thumbnails keyed by size in pixels
+type StoredImage struct {
+	Original   *File            `json:"original"`
+	Thumbnails map[string]*File `json:"thumbnails"`
+}
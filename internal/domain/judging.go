@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// JudgingCriterion is a weighted aspect submissions are scored on, from 0 to MaxScore
+type JudgingCriterion struct {
+	ID       uint
+	Weight   float64
+	MaxScore float64
+}
+
+// ScoreSheet holds one judge's scores for a submission by criterion ID
+type ScoreSheet struct {
+	SubmissionID uint
+	JudgeID      uint
+	Scores       map[uint]float64
+}
+
+// SubmissionRanking is the aggregated result of one submission. Rank is nil for
+// submissions without a complete score sheet.
+type SubmissionRanking struct {
+	SubmissionID    uint             `json:"submission_id"`
+	Rank            *int             `json:"rank"`
+	Score           float64          `json:"score"`            // weighted average on a 0-100 scale
+	CriterionScores map[uint]float64 `json:"criterion_scores"` // average raw score by criterion ID
+	JudgeCount      int              `json:"judge_count"`
+}
+
+// RankSubmissions aggregates the score sheets of each submission and ranks them.
+//
+// A sheet counts only when it scores every criterion. Its total is the weighted
+// mean of the scores normalized to their MaxScore, on a 0-100 scale, and a
+// submission's score is the mean of its sheet totals. Equal scores are broken by
+// the average normalized score of each criterion, heaviest criterion first;
+// submissions still tied share a rank. Unscored submissions come last.
+func RankSubmissions(criteria []JudgingCriterion, submissionIDs []uint, sheets []ScoreSheet) []SubmissionRanking {
+	var totalWeight float64
+	for _, c := range criteria {
+		totalWeight += c.Weight
+	}
+
+	// Tie-break order: heavier criteria first, then the order they were given in
+	priority := make([]JudgingCriterion, len(criteria))
+	copy(priority, criteria)
+	sort.SliceStable(priority, func(i, j int) bool { return priority[i].Weight > priority[j].Weight })
+
+	bySubmission := make(map[uint][]ScoreSheet)
+	for _, sheet := range sheets {
+		if completeSheet(criteria, sheet) {
+			bySubmission[sheet.SubmissionID] = append(bySubmission[sheet.SubmissionID], sheet)
+		}
+	}
+
+	entries := make([]rankingEntry, len(submissionIDs))
+	for i, id := range submissionIDs {
+		entry := rankingEntry{SubmissionRanking: SubmissionRanking{SubmissionID: id, CriterionScores: map[uint]float64{}}}
+		scored := bySubmission[id]
+		entry.JudgeCount = len(scored)
+		entry.scored = len(scored) > 0 && totalWeight > 0
+		if entry.scored {
+			for _, sheet := range scored {
+				var total float64
+				for _, c := range criteria {
+					total += c.Weight * sheet.Scores[c.ID] / c.MaxScore
+				}
+				entry.Score += total / totalWeight * 100
+			}
+			entry.Score = round2(entry.Score / float64(len(scored)))
+
+			for _, c := range criteria {
+				var sum float64
+				for _, sheet := range scored {
+					sum += sheet.Scores[c.ID]
+				}
+				entry.CriterionScores[c.ID] = round2(sum / float64(len(scored)))
+			}
+			for _, c := range priority {
+				entry.tieBreak = append(entry.tieBreak, entry.CriterionScores[c.ID]/c.MaxScore)
+			}
+		}
+		entries[i] = entry
+	}
+
+	sort.SliceStable(entries, func(a, b int) bool { return entries[a].compare(entries[b]) < 0 })
+
+	rankings := make([]SubmissionRanking, len(entries))
+	for pos, entry := range entries {
+		if entry.scored {
+			rank := pos + 1
+			if pos > 0 && entries[pos-1].compare(entry) == 0 {
+				rank = *rankings[pos-1].Rank
+			}
+			entry.Rank = &rank
+		}
+		rankings[pos] = entry.SubmissionRanking
+	}
+	return rankings
+}
+
+// rankingEntry is a ranking being computed
+type rankingEntry struct {
+	SubmissionRanking
+	scored   bool
+	tieBreak []float64 // normalized criterion averages, heaviest criterion first
+}
+
+// compare returns a negative number when e ranks above other, zero when they tie
+func (e rankingEntry) compare(other rankingEntry) int {
+	switch {
+	case e.scored != other.scored:
+		if e.scored {
+			return -1
+		}
+		return 1
+	case !e.scored:
+		return 0
+	case e.Score != other.Score:
+		if e.Score > other.Score {
+			return -1
+		}
+		return 1
+	}
+	for i := range e.tieBreak {
+		if e.tieBreak[i] != other.tieBreak[i] {
+			if e.tieBreak[i] > other.tieBreak[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// completeSheet reports whether the sheet scores every criterion
+func completeSheet(criteria []JudgingCriterion, sheet ScoreSheet) bool {
+	for _, c := range criteria {
+		if _, ok := sheet.Scores[c.ID]; !ok {
+			return false
+		}
+	}
+	return len(criteria) > 0
+}
+
+// round2 rounds to two decimal places
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -10,6 +10,7 @@ var (
 	ErrFileAlreadyExists = errors.New("file already exists")
 	ErrInvalidFileName   = errors.New("invalid file name")
 	ErrInvalidFileSize   = errors.New("invalid file size")
+	ErrInvalidRange      = errors.New("invalid byte range")
 )
 
 // FileRepository defines the contract for file storage operations
@@ -23,6 +24,13 @@ type FileRepository interface {
 	// GetContent retrieves file content with metadata
 	GetContent(ctx context.Context, id string) (*FileData, error)
 
+	// CreateFromReader creates or replaces a file, streaming its content from req.Content
+	CreateFromReader(ctx context.Context, req *CreateFileStreamRequest) (*File, error)
+
+	// NewRangeReader opens a reader over length bytes of file content starting at offset.
+	// A negative length reads to the end of the file.
+	NewRangeReader(ctx context.Context, id string, offset, length int64) (*FileReader, error)
+
 	// List retrieves a list of files based on query parameters
 	List(ctx context.Context, query *FileQuery) ([]*File, error)
 
@@ -35,3 +43,38 @@ type FileRepository interface {
 	// Exists checks if a file exists
 	Exists(ctx context.Context, id string) (bool, error)
 }
+
+// FileMetadataRepository defines the contract for the queryable index of stored files
+type FileMetadataRepository interface {
+	// Transaction runs fn with a repository bound to a single database transaction
+	Transaction(ctx context.Context, fn func(repo FileMetadataRepository) error) error
+
+	// Get retrieves the metadata of a file
+	Get(ctx context.Context, id string) (*File, error)
+
+	// Save creates or replaces the metadata of a file
+	Save(ctx context.Context, file *File) error
+
+	// Delete removes the metadata of a file
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves files matching the query and the total number of matches
+	List(ctx context.Context, query *FileQuery) ([]*File, int64, error)
+
+	// ListAll retrieves the metadata of every file
+	ListAll(ctx context.Context) ([]*File, error)
+
+	// UploaderUsage returns the total size and number of the files uploaded by a user
+	UploaderUsage(ctx context.Context, uploaderID uint) (int64, int64, error)
+
+	// GetVersionScan retrieves the scan verdict of a version, failing with
+	// ErrVersionNotFound if none was recorded
+	GetVersionScan(ctx context.Context, id string, generation int64) (*FileScan, error)
+
+	// SaveVersionScan creates or replaces the scan verdict of a version
+	SaveVersionScan(ctx context.Context, id string, generation int64, scan FileScan) error
+
+	// DeleteVersionScans removes the scan verdicts of the given versions of a file,
+	// or of all its versions if none are given
+	DeleteVersionScans(ctx context.Context, id string, generations ...int64) error
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "sort"
+
+// SeriesStage is a round held in every edition of a hackathon series
+type SeriesStage struct {
+	ID       uint
+	Position int
+}
+
+// NextStage returns the stage following the stage with currentID in Position
+// order. It reports false when currentID is the final stage or not a stage.
+func NextStage(stages []SeriesStage, currentID uint) (SeriesStage, bool) {
+	ordered := make([]SeriesStage, len(stages))
+	copy(ordered, stages)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+
+	for i, stage := range ordered {
+		if stage.ID == currentID {
+			if i+1 < len(ordered) {
+				return ordered[i+1], true
+			}
+			return SeriesStage{}, false
+		}
+	}
+	return SeriesStage{}, false
+}
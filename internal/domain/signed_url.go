@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSignedURLUnsupported = errors.New("signed URLs are not supported by the storage backend")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrSignatureExpired     = errors.New("signature expired")
+	ErrSignedUploadUsed     = errors.New("signed upload URL was already used")
+)
+
+// SignedURLRequest represents a request for a URL granting direct access to a stored file
+type SignedURLRequest struct {
+	FileID      string
+	Method      string // http.MethodGet or http.MethodPut
+	ContentType string // Content-Type the uploader must send, for PUT
+	MaxSize     int64  // largest body the uploader may send, for PUT
+	ExpiresAt   time.Time
+}
+
+// SignedURL represents a short-lived URL granting direct access to a stored file
+type SignedURL struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// URLSigner defines the contract for issuing signed URLs to the storage backend
+type URLSigner interface {
+	// SignURL issues a URL allowing req.Method on the file until req.ExpiresAt
+	SignURL(ctx context.Context, req *SignedURLRequest) (*SignedURL, error)
+}
+
+// SignedUpload tracks an upload URL issued for a new file until the upload is
+// completed. The declared type, size and access are fixed when the URL is issued.
+type SignedUpload struct {
+	FileName    string
+	ContentType string
+	Length      int64      // declared size in bytes
+	UsedAt      *time.Time // when content was sent through the URL
+	ExpiresAt   time.Time  // when the upload is discarded unless completed
+	CreatedAt   time.Time
+	FileAccess
+}
+
+// CreateSignedUploadRequest represents a request for a URL to upload a new file directly to storage
+type CreateSignedUploadRequest struct {
+	Name        string
+	ContentType string
+	Length      int64
+	Access      *FileAccessRequest
+}
+
+// SignedUploadRepository defines the contract for persisting issued signed uploads
+type SignedUploadRepository interface {
+	// Create stores a new upload, failing with ErrFileAlreadyExists if one is pending for the file
+	Create(ctx context.Context, upload *SignedUpload) error
+
+	// Get retrieves the pending upload of a file
+	Get(ctx context.Context, fileName string) (*SignedUpload, error)
+
+	// MarkUsed records that content was sent, failing with ErrSignedUploadUsed if it already was
+	MarkUsed(ctx context.Context, fileName string, at time.Time) error
+
+	// Delete removes the upload of a file
+	Delete(ctx context.Context, fileName string) error
+
+	// ListExpired retrieves the uploads that expired before the given time
+	ListExpired(ctx context.Context, before time.Time) ([]*SignedUpload, error)
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Actor is the role in which a status change is requested
+type Actor string
+
+// Actors that may trigger status transitions. Admins may make every transition a
+// machine defines.
+const (
+	ActorSystem    Actor = "system"    // schedulers and automatic promotion
+	ActorAdmin     Actor = "admin"     // platform administrators
+	ActorOwner     Actor = "owner"     // owner of the parent resource, e.g. the hackathon organizer
+	ActorSelf      Actor = "self"      // the user the record belongs to
+	ActorRequester Actor = "requester" // the user who sent a matching request
+	ActorRecipient Actor = "recipient" // the user who received a matching request
+)
+
+// StatusNone is the from status of a record that is being created
+const StatusNone = ""
+
+var (
+	ErrUnknownStatus       = errors.New("unknown status")
+	ErrInvalidTransition   = errors.New("invalid status transition")
+	ErrTransitionForbidden = errors.New("status transition not permitted")
+)
+
+// TransitionError describes a rejected status change. Allowed lists the statuses
+// the actor may move to from From, or every known status for ErrUnknownStatus.
+type TransitionError struct {
+	Entity  string
+	From    string
+	To      string
+	Allowed []string
+	Err     error
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s from %q to %q", e.Entity, e.Err, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// Transition is a permitted status change and the actors who may make it
+type Transition struct {
+	From   string
+	To     string
+	Actors []Actor
+}
+
+// StatusChange is passed to transition hooks
+type StatusChange[T any] struct {
+	Subject T
+	From    string
+	To      string
+	Actor   Actor
+}
+
+// TransitionHook runs around an applied transition. An error aborts the change.
+type TransitionHook[T any] func(change StatusChange[T]) error
+
+// StatusMachine validates status changes of one kind of entity T and fires hooks
+// when they are applied
+type StatusMachine[T any] struct {
+	entity      string
+	statuses    []string
+	transitions []Transition
+	before      []TransitionHook[T]
+	after       []TransitionHook[T]
+}
+
+// NewStatusMachine creates a machine over statuses that allows only transitions
+func NewStatusMachine[T any](entity string, statuses []string, transitions ...Transition) *StatusMachine[T] {
+	return &StatusMachine[T]{entity: entity, statuses: statuses, transitions: transitions}
+}
+
+// Before registers a hook that runs before a transition is persisted
+func (m *StatusMachine[T]) Before(hook TransitionHook[T]) *StatusMachine[T] {
+	m.before = append(m.before, hook)
+	return m
+}
+
+// After registers a hook that runs once a transition has been persisted
+func (m *StatusMachine[T]) After(hook TransitionHook[T]) *StatusMachine[T] {
+	m.after = append(m.after, hook)
+	return m
+}
+
+// Statuses returns every status of the machine
+func (m *StatusMachine[T]) Statuses() []string {
+	return append([]string(nil), m.statuses...)
+}
+
+// IsStatus reports whether status belongs to the machine
+func (m *StatusMachine[T]) IsStatus(status string) bool {
+	for _, s := range m.statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed returns the statuses actor may move to from from
+func (m *StatusMachine[T]) Allowed(from string, actor Actor) []string {
+	allowed := []string{}
+	for _, t := range m.transitions {
+		if t.From == from && t.permits(actor) {
+			allowed = append(allowed, t.To)
+		}
+	}
+	return allowed
+}
+
+// Check returns a *TransitionError unless actor may move from from to to.
+// Keeping the current status is always allowed.
+func (m *StatusMachine[T]) Check(from, to string, actor Actor) error {
+	if !m.IsStatus(to) {
+		return &TransitionError{Entity: m.entity, From: from, To: to, Allowed: m.Statuses(), Err: ErrUnknownStatus}
+	}
+	if from == to {
+		return nil
+	}
+	for _, t := range m.transitions {
+		if t.From != from || t.To != to {
+			continue
+		}
+		if t.permits(actor) {
+			return nil
+		}
+		return &TransitionError{Entity: m.entity, From: from, To: to, Allowed: m.Allowed(from, actor), Err: ErrTransitionForbidden}
+	}
+	return &TransitionError{Entity: m.entity, From: from, To: to, Allowed: m.Allowed(from, actor), Err: ErrInvalidTransition}
+}
+
+// Apply checks the transition of subject from from to to, then runs the before
+// hooks, persist and the after hooks. Hooks are skipped when the status is kept.
+func (m *StatusMachine[T]) Apply(subject T, from, to string, actor Actor, persist func() error) error {
+	if err := m.Check(from, to, actor); err != nil {
+		return err
+	}
+	if from == to {
+		return persist()
+	}
+
+	change := StatusChange[T]{Subject: subject, From: from, To: to, Actor: actor}
+	for _, hook := range m.before {
+		if err := hook(change); err != nil {
+			return err
+		}
+	}
+	if err := persist(); err != nil {
+		return err
+	}
+	for _, hook := range m.after {
+		if err := hook(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// permits reports whether actor may make the transition
+func (t Transition) permits(actor Actor) bool {
+	if actor == ActorAdmin {
+		return true
+	}
+	for _, a := range t.Actors {
+		if a == actor {
+			return true
+		}
+	}
+	return false
+}
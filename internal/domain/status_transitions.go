@@ -0,0 +1,92 @@
+package domain
+
+// Participant statuses. Registered, confirmed and checked-in participants hold a
+// seat; waitlisted participants are promoted in registration order when a seat
+// frees up. Participants are checked in on site by scanning their check-in QR code.
+const (
+	ParticipantRegistered   = "registered"
+	ParticipantConfirmed    = "confirmed"
+	ParticipantCheckedIn    = "checked_in"
+	ParticipantWaitlisted   = "waitlisted"
+	ParticipantCancelled    = "cancelled"
+	ParticipantDisqualified = "disqualified"
+)
+
+// Matching statuses. A pending request is answered by its recipient; either party
+// may block the other at any time.
+const (
+	MatchingPending  = "pending"
+	MatchingAccepted = "accepted"
+	MatchingRejected = "rejected"
+	MatchingBlocked  = "blocked"
+)
+
+// Contest application statuses. Pending applications are accepted or rejected by
+// the contest author; applicants may withdraw until they are rejected.
+const (
+	ApplicationPending   = "pending"
+	ApplicationAccepted  = "accepted"
+	ApplicationRejected  = "rejected"
+	ApplicationWithdrawn = "withdrawn"
+)
+
+// HackathonStatuses lists every hackathon status
+var HackathonStatuses = []string{HackathonStatusUpcoming, HackathonStatusOngoing, HackathonStatusCompleted, HackathonStatusCancelled}
+
+// HackathonTransitions are the allowed hackathon status changes. Completed and
+// cancelled are final.
+var HackathonTransitions = []Transition{
+	{From: HackathonStatusUpcoming, To: HackathonStatusOngoing, Actors: []Actor{ActorSystem, ActorOwner}},
+	{From: HackathonStatusUpcoming, To: HackathonStatusCancelled, Actors: []Actor{ActorOwner}},
+	{From: HackathonStatusOngoing, To: HackathonStatusCompleted, Actors: []Actor{ActorSystem, ActorOwner}},
+	{From: HackathonStatusOngoing, To: HackathonStatusCancelled, Actors: []Actor{ActorOwner}},
+}
+
+// ParticipantStatuses lists every participant status
+var ParticipantStatuses = []string{ParticipantRegistered, ParticipantConfirmed, ParticipantCheckedIn, ParticipantWaitlisted, ParticipantCancelled, ParticipantDisqualified}
+
+// ParticipantTransitions are the allowed participant status changes. A cancelled
+// registration is final; the user registers again instead.
+var ParticipantTransitions = []Transition{
+	{From: ParticipantRegistered, To: ParticipantConfirmed, Actors: []Actor{ActorOwner}},
+	{From: ParticipantRegistered, To: ParticipantCancelled, Actors: []Actor{ActorSelf, ActorOwner}},
+	{From: ParticipantRegistered, To: ParticipantDisqualified, Actors: []Actor{ActorOwner}},
+	{From: ParticipantConfirmed, To: ParticipantRegistered, Actors: []Actor{ActorOwner}},
+	{From: ParticipantConfirmed, To: ParticipantCancelled, Actors: []Actor{ActorSelf, ActorOwner}},
+	{From: ParticipantConfirmed, To: ParticipantDisqualified, Actors: []Actor{ActorOwner}},
+	{From: ParticipantRegistered, To: ParticipantCheckedIn, Actors: []Actor{ActorOwner}},
+	{From: ParticipantConfirmed, To: ParticipantCheckedIn, Actors: []Actor{ActorOwner}},
+	{From: ParticipantCheckedIn, To: ParticipantConfirmed, Actors: []Actor{ActorOwner}},
+	{From: ParticipantCheckedIn, To: ParticipantDisqualified, Actors: []Actor{ActorOwner}},
+	{From: ParticipantWaitlisted, To: ParticipantRegistered, Actors: []Actor{ActorSystem, ActorOwner}},
+	{From: ParticipantWaitlisted, To: ParticipantCancelled, Actors: []Actor{ActorSelf, ActorOwner}},
+	{From: ParticipantDisqualified, To: ParticipantRegistered, Actors: []Actor{ActorOwner}},
+}
+
+// MatchingStatuses lists every matching status
+var MatchingStatuses = []string{MatchingPending, MatchingAccepted, MatchingRejected, MatchingBlocked}
+
+// MatchingTransitions are the allowed matching status changes. New matchings start
+// pending, or blocked to block a user outright; blocked is final.
+var MatchingTransitions = []Transition{
+	{From: StatusNone, To: MatchingPending, Actors: []Actor{ActorRequester}},
+	{From: StatusNone, To: MatchingBlocked, Actors: []Actor{ActorRequester}},
+	{From: MatchingPending, To: MatchingAccepted, Actors: []Actor{ActorRecipient}},
+	{From: MatchingPending, To: MatchingRejected, Actors: []Actor{ActorRecipient}},
+	{From: MatchingPending, To: MatchingBlocked, Actors: []Actor{ActorRequester, ActorRecipient}},
+	{From: MatchingAccepted, To: MatchingBlocked, Actors: []Actor{ActorRequester, ActorRecipient}},
+	{From: MatchingRejected, To: MatchingBlocked, Actors: []Actor{ActorRequester, ActorRecipient}},
+}
+
+// ApplicationStatuses lists every contest application status
+var ApplicationStatuses = []string{ApplicationPending, ApplicationAccepted, ApplicationRejected, ApplicationWithdrawn}
+
+// ApplicationTransitions are the allowed contest application status changes.
+// Rejected and withdrawn are final; the user applies again instead.
+var ApplicationTransitions = []Transition{
+	{From: StatusNone, To: ApplicationPending, Actors: []Actor{ActorSelf}},
+	{From: ApplicationPending, To: ApplicationAccepted, Actors: []Actor{ActorOwner}},
+	{From: ApplicationPending, To: ApplicationRejected, Actors: []Actor{ActorOwner}},
+	{From: ApplicationPending, To: ApplicationWithdrawn, Actors: []Actor{ActorSelf}},
+	{From: ApplicationAccepted, To: ApplicationWithdrawn, Actors: []Actor{ActorSelf}},
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// SubmissionDeadline returns when the submissions of a hackathon lock: its
+// submission deadline, or its end when it has none
+func SubmissionDeadline(deadline *time.Time, end time.Time) time.Time {
+	if deadline != nil {
+		return *deadline
+	}
+	return end
+}
+
+// SubmissionsOpen reports whether teams may still create and edit submissions at now
+func SubmissionsOpen(status string, deadline *time.Time, end, now time.Time) bool {
+	if status == HackathonStatusCancelled {
+		return false
+	}
+	return now.Before(SubmissionDeadline(deadline, end))
+}
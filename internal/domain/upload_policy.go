@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+var (
+	ErrFileTooLarge       = errors.New("file exceeds the maximum upload size")
+	ErrFileTypeNotAllowed = errors.New("file type is not allowed")
+	ErrQuotaExceeded      = errors.New("storage quota exceeded")
+)
+
+// AttachmentNone keys the allowed types of files not attached to any entity
+const AttachmentNone = "none"
+
+// UploadPolicy limits what users may upload. Zero values disable the respective limit.
+type UploadPolicy struct {
+	MaxFileSize int64 // bytes per file
+	UserQuota   int64 // total bytes of the files a user uploaded
+
+	// AllowedTypes lists the MIME types accepted per attachment type, with AttachmentNone
+	// for unattached files. Entries may use a "type/*" wildcard. Attachment types without
+	// an entry accept any type.
+	AllowedTypes map[string][]string
+}
+
+// AllowsType reports whether files of contentType may be attached to attachmentType
+func (p UploadPolicy) AllowsType(attachmentType, contentType string) bool {
+	if attachmentType == "" {
+		attachmentType = AttachmentNone
+	}
+	allowed, ok := p.AllowedTypes[attachmentType]
+	if !ok {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, pattern := range allowed {
+		if pattern == mediaType || (strings.HasSuffix(pattern, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadRejection describes an upload refused by the UploadPolicy. It wraps
+// ErrFileTooLarge, ErrFileTypeNotAllowed or ErrQuotaExceeded.
+type UploadRejection struct {
+	Reason       error    `json:"-"`
+	Limit        int64    `json:"limit,omitempty"`         // size limit or quota in bytes
+	Used         int64    `json:"used,omitempty"`          // bytes already stored against the quota
+	ContentType  string   `json:"content_type,omitempty"`  // the rejected type
+	AllowedTypes []string `json:"allowed_types,omitempty"` // the types accepted instead
+}
+
+// Error implements error
+func (e *UploadRejection) Error() string {
+	switch {
+	case errors.Is(e.Reason, ErrFileTypeNotAllowed):
+		return fmt.Sprintf("%v: %s", e.Reason, e.ContentType)
+	case errors.Is(e.Reason, ErrQuotaExceeded):
+		return fmt.Sprintf("%v: %d of %d bytes used", e.Reason, e.Used, e.Limit)
+	default:
+		return fmt.Sprintf("%v: limit is %d bytes", e.Reason, e.Limit)
+	}
+}
+
+// Unwrap returns the rejection reason
+func (e *UploadRejection) Unwrap() error {
+	return e.Reason
+}
+
+// StorageUsage summarizes the storage used by the files a user uploaded
+type StorageUsage struct {
+	UserID         uint   `json:"user_id"`
+	UsedBytes      int64  `json:"used_bytes"`
+	FileCount      int64  `json:"file_count"`
+	QuotaBytes     int64  `json:"quota_bytes"`               // 0 means unlimited
+	RemainingBytes *int64 `json:"remaining_bytes,omitempty"` // nil when unlimited
+	MaxFileSize    int64  `json:"max_file_size"`             // 0 means unlimited
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrUploadNotFound            = errors.New("upload not found")
+	ErrUploadExpired             = errors.New("upload expired")
+	ErrUploadOffsetMismatch      = errors.New("upload offset does not match")
+	ErrUploadExceedsLength       = errors.New("upload exceeds its declared length")
+	ErrUploadIncomplete          = errors.New("upload is incomplete")
+	ErrInvalidUploadLength       = errors.New("invalid upload length")
+	ErrUploadSessionsUnsupported = errors.New("resumable uploads are not configured")
+)
+
+// UploadSession tracks a resumable upload whose content arrives in chunks until
+// it is assembled into a file
+type UploadSession struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Length      int64     `json:"length"` // declared total size in bytes
+	Offset      int64     `json:"offset"` // bytes received so far
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	FileAccess
+}
+
+// Complete reports whether all declared bytes were received
+func (s *UploadSession) Complete() bool {
+	return s.Offset == s.Length
+}
+
+// CreateUploadSessionRequest represents a request to start a resumable upload
+type CreateUploadSessionRequest struct {
+	Name        string
+	ContentType string
+	Length      int64
+	Access      *FileAccessRequest
+}
+
+// UploadSessionRepository defines the contract for persisting resumable upload sessions
+type UploadSessionRepository interface {
+	// Transaction runs fn with a repository bound to a single database transaction
+	Transaction(ctx context.Context, fn func(repo UploadSessionRepository) error) error
+
+	// Create stores a new session
+	Create(ctx context.Context, session *UploadSession) error
+
+	// Get retrieves a session
+	Get(ctx context.Context, id string) (*UploadSession, error)
+
+	// GetForUpdate retrieves a session and locks it until the surrounding transaction ends
+	GetForUpdate(ctx context.Context, id string) (*UploadSession, error)
+
+	// Update stores the offset and expiry of a session
+	Update(ctx context.Context, session *UploadSession) error
+
+	// Delete removes a session
+	Delete(ctx context.Context, id string) error
+
+	// ListExpired retrieves the sessions that expired before the given time
+	ListExpired(ctx context.Context, before time.Time) ([]*UploadSession, error)
+}
@@ -0,0 +1,125 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+// clamAVChunkSize is the size of the chunks streamed to clamd. It must stay
+// below the daemon's StreamMaxLength.
+const clamAVChunkSize = 64 * 1024
+
+// ClamAVScanner implements domain.FileScanner with the INSTREAM command of the
+// clamd daemon protocol
+type ClamAVScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner talking to clamd at address, either host:port
+// or the path of a unix socket. timeout bounds a whole scan.
+func NewClamAVScanner(address string, timeout time.Duration) *ClamAVScanner {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	return &ClamAVScanner{network: network, address: address, timeout: timeout}
+}
+
+// Scan streams content to clamd and parses its verdict
+func (s *ClamAVScanner) Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set clamd deadline: %w", err)
+		}
+	}
+
+	if err := writeInstream(conn, content); err != nil {
+		return nil, err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// writeInstream sends the INSTREAM command followed by content as length-prefixed
+// chunks and the terminating zero-length chunk
+func writeInstream(w io.Writer, content io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return fmt.Errorf("failed to send clamd command: %w", err)
+	}
+
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, werr := w.Write(buf[:4+n]); werr != nil {
+				return fmt.Errorf("failed to stream content to clamd: %w", werr)
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+	}
+
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("failed to stream content to clamd: %w", err)
+	}
+	return nil
+}
+
+// parseClamAVReply interprets replies such as "stream: OK" and "stream: Eicar-Signature FOUND"
+func parseClamAVReply(reply string) (*domain.ScanResult, error) {
+	result, ok := strings.CutPrefix(reply, "stream: ")
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unexpected clamd reply: %q", reply)
+	case result == "OK":
+		return &domain.ScanResult{Clean: true}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &domain.ScanResult{Threat: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("clamd scan failed: %s", result)
+	}
+}
+
+// NoopScanner implements domain.FileScanner by accepting all content, for
+// development setups without a scanning daemon
+type NoopScanner struct{}
+
+// Scan drains content and reports it clean
+func (NoopScanner) Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error) {
+	if _, err := io.Copy(io.Discard, content); err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	return &domain.ScanResult{Clean: true}, nil
+}
@@ -0,0 +1,102 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd serves INSTREAM requests, reporting content containing "EICAR" as infected
+// and content containing "BROKEN" as a scan error
+func fakeClamd(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				command, err := reader.ReadString(0)
+				if err != nil || command != "zINSTREAM\x00" {
+					io.WriteString(conn, "UNKNOWN COMMAND\x00")
+					return
+				}
+
+				var content bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&content, reader, int64(size)); err != nil {
+						return
+					}
+				}
+
+				switch {
+				case bytes.Contains(content.Bytes(), []byte("EICAR")):
+					io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+				case bytes.Contains(content.Bytes(), []byte("BROKEN")):
+					io.WriteString(conn, "stream: Can't allocate memory ERROR\x00")
+				default:
+					io.WriteString(conn, "stream: OK\x00")
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestClamAVScanner(t *testing.T) {
+	ctx := context.Background()
+	scanner := NewClamAVScanner(fakeClamd(t), 5*time.Second)
+
+	t.Run("Clean content", func(t *testing.T) {
+		// Larger than one chunk to exercise chunking
+		result, err := scanner.Scan(ctx, strings.NewReader(strings.Repeat("a", 3*clamAVChunkSize+17)))
+		require.NoError(t, err)
+		assert.True(t, result.Clean)
+	})
+
+	t.Run("Infected content", func(t *testing.T) {
+		result, err := scanner.Scan(ctx, strings.NewReader("X5O!P%@AP EICAR test file"))
+		require.NoError(t, err)
+		assert.False(t, result.Clean)
+		assert.Equal(t, "Eicar-Test-Signature", result.Threat)
+	})
+
+	t.Run("Scan errors", func(t *testing.T) {
+		_, err := scanner.Scan(ctx, strings.NewReader("BROKEN"))
+		assert.ErrorContains(t, err, "Can't allocate memory")
+	})
+
+	t.Run("Unreachable daemon", func(t *testing.T) {
+		_, err := NewClamAVScanner("127.0.0.1:1", time.Second).Scan(ctx, strings.NewReader("content"))
+		assert.Error(t, err)
+	})
+}
+
+func TestNoopScanner(t *testing.T) {
+	result, err := NoopScanner{}.Scan(context.Background(), strings.NewReader("EICAR"))
+	require.NoError(t, err)
+	assert.True(t, result.Clean)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"google.golang.org/api/iterator"
 )
 
-// GCSFileRepository implements domain.FileRepository using Google Cloud Storage
+// GCSFileRepository implements domain.FileRepository using Google Cloud Storage.
+// With Object Versioning enabled on the bucket, overwritten and renamed content is
+// kept as noncurrent generations and exposed through domain.FileVersionRepository.
 type GCSFileRepository struct {
 	client     *storage.Client
 	bucketName string
@@ -116,6 +119,81 @@ func (r *GCSFileRepository) GetContent(ctx context.Context, id string) (*domain.
 	}, nil
 }
 
+// CreateFromReader creates or replaces a file in GCS, streaming its content
+func (r *GCSFileRepository) CreateFromReader(ctx context.Context, req *domain.CreateFileStreamRequest) (*domain.File, error) {
+	objectName := r.getObjectName(req.Name)
+	obj := r.client.Bucket(r.bucketName).Object(objectName)
+
+	// Cancelling the writer's context aborts the upload instead of committing partial content
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := obj.NewWriter(writeCtx)
+	w.ContentType = req.ContentType
+	w.Metadata = map[string]string{
+		"original_name": req.Name,
+		"created_at":    time.Now().Format(time.RFC3339),
+	}
+
+	if _, err := io.Copy(w, req.Content); err != nil {
+		cancel()
+		w.Close()
+		return nil, fmt.Errorf("failed to write content: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	return r.attributesToFile(w.Attrs()), nil
+}
+
+// NewRangeReader opens a reader over a byte range of the current object generation
+func (r *GCSFileRepository) NewRangeReader(ctx context.Context, id string, offset, length int64) (*domain.FileReader, error) {
+	objectName := r.getObjectName(id)
+	obj := r.client.Bucket(r.bucketName).Object(objectName)
+
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, domain.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to get object attributes: %w", err)
+	}
+
+	return r.newRangeReader(ctx, obj, attrs, offset, length)
+}
+
+// newRangeReader opens a reader over a byte range of the generation described by attrs
+func (r *GCSFileRepository) newRangeReader(ctx context.Context, obj *storage.ObjectHandle, attrs *storage.ObjectAttrs, offset, length int64) (*domain.FileReader, error) {
+	if offset < 0 || (offset > 0 && offset >= attrs.Size) {
+		return nil, domain.ErrInvalidRange
+	}
+	if length < 0 || offset+length > attrs.Size {
+		length = attrs.Size - offset
+	}
+
+	readLength := length
+	if attrs.Size == 0 {
+		readLength = -1
+	}
+
+	// Pin the generation so the range matches the attributes returned
+	reader, err := obj.Generation(attrs.Generation).NewRangeReader(ctx, offset, readLength)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, domain.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to create reader: %w", err)
+	}
+
+	return &domain.FileReader{
+		File:    r.attributesToFile(attrs),
+		Content: reader,
+		Offset:  offset,
+		Length:  length,
+	}, nil
+}
+
 // List retrieves a list of files based on query parameters
 func (r *GCSFileRepository) List(ctx context.Context, query *domain.FileQuery) ([]*domain.File, error) {
 	prefix := r.folder + "/"
@@ -163,7 +241,8 @@ func (r *GCSFileRepository) List(ctx context.Context, query *domain.FileQuery) (
 	return files, nil
 }
 
-// Update updates an existing file
+// Update updates an existing file. Renaming moves the file together with its
+// noncurrent generations.
 func (r *GCSFileRepository) Update(ctx context.Context, id string, req *domain.UpdateFileRequest) (*domain.File, error) {
 	objectName := r.getObjectName(id)
 	obj := r.client.Bucket(r.bucketName).Object(objectName)
@@ -178,12 +257,12 @@ func (r *GCSFileRepository) Update(ctx context.Context, id string, req *domain.U
 	}
 
 	// Handle rename if new name is provided
-	var newObj *storage.ObjectHandle
+	newObj := obj
 	if req.Name != "" && req.Name != id {
-		newObjectName := r.getObjectName(req.Name)
-		newObj = r.client.Bucket(r.bucketName).Object(newObjectName)
-	} else {
-		newObj = obj
+		newObj = r.client.Bucket(r.bucketName).Object(r.getObjectName(req.Name))
+		if err := r.moveObject(ctx, objectName, newObj); err != nil {
+			return nil, err
+		}
 	}
 
 	// If content is provided, update the content
@@ -199,7 +278,7 @@ func (r *GCSFileRepository) Update(ctx context.Context, id string, req *domain.U
 			w.Metadata["original_name"] = req.Name
 		}
 
-		if _, err := w.Write(req.Content); err != nil {
+		if _, err := io.Copy(w, req.Content); err != nil {
 			w.Close()
 			return nil, fmt.Errorf("failed to write content: %w", err)
 		}
@@ -207,23 +286,6 @@ func (r *GCSFileRepository) Update(ctx context.Context, id string, req *domain.U
 		if err := w.Close(); err != nil {
 			return nil, fmt.Errorf("failed to close writer: %w", err)
 		}
-
-		// Delete old object if renamed
-		if newObj != obj {
-			if err := obj.Delete(ctx); err != nil {
-				return nil, fmt.Errorf("failed to delete old object: %w", err)
-			}
-		}
-	} else if newObj != obj {
-		// Only rename without content update
-		copier := newObj.CopierFrom(obj)
-		if _, err := copier.Run(ctx); err != nil {
-			return nil, fmt.Errorf("failed to copy object: %w", err)
-		}
-
-		if err := obj.Delete(ctx); err != nil {
-			return nil, fmt.Errorf("failed to delete old object: %w", err)
-		}
 	}
 
 	// Get updated object attributes
@@ -235,17 +297,23 @@ func (r *GCSFileRepository) Update(ctx context.Context, id string, req *domain.U
 	return r.attributesToFile(attrs), nil
 }
 
-// Delete deletes a file by its ID
+// Delete deletes a file by its ID together with its noncurrent generations
 func (r *GCSFileRepository) Delete(ctx context.Context, id string) error {
 	objectName := r.getObjectName(id)
 	obj := r.client.Bucket(r.bucketName).Object(objectName)
 
-	err := obj.Delete(ctx)
+	generations, err := r.listGenerations(ctx, objectName)
 	if err != nil {
-		if err == storage.ErrObjectNotExist {
-			return domain.ErrFileNotFound
+		return err
+	}
+	if len(generations) == 0 {
+		return domain.ErrFileNotFound
+	}
+
+	for _, attrs := range generations {
+		if err := obj.Generation(attrs.Generation).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+			return fmt.Errorf("failed to delete object: %w", err)
 		}
-		return fmt.Errorf("failed to delete object: %w", err)
 	}
 
 	return nil
@@ -271,6 +339,154 @@ func (r *GCSFileRepository) Exists(ctx context.Context, id string) (bool, error)
 	return true, nil
 }
 
+// ListVersions lists the live and noncurrent generations of a file, newest first
+func (r *GCSFileRepository) ListVersions(ctx context.Context, id string) ([]*domain.FileVersion, error) {
+	generations, err := r.listGenerations(ctx, r.getObjectName(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(generations) == 0 {
+		return nil, domain.ErrFileNotFound
+	}
+
+	versions := make([]*domain.FileVersion, 0, len(generations))
+	for i := len(generations) - 1; i >= 0; i-- {
+		attrs := generations[i]
+		version := &domain.FileVersion{
+			Generation:  attrs.Generation,
+			Size:        attrs.Size,
+			ContentType: attrs.ContentType,
+			ETag:        attrs.Etag,
+			CreatedAt:   attrs.Created,
+			Live:        attrs.Deleted.IsZero(),
+		}
+		if !version.Live {
+			replacedAt := attrs.Deleted
+			version.ReplacedAt = &replacedAt
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// NewVersionReader opens a reader over a byte range of a specific generation
+func (r *GCSFileRepository) NewVersionReader(ctx context.Context, id string, generation, offset, length int64) (*domain.FileReader, error) {
+	obj := r.client.Bucket(r.bucketName).Object(r.getObjectName(id))
+
+	attrs, err := obj.Generation(generation).Attrs(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, domain.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get object attributes: %w", err)
+	}
+
+	return r.newRangeReader(ctx, obj, attrs, offset, length)
+}
+
+// RestoreVersion copies a generation over the live object, which becomes noncurrent
+func (r *GCSFileRepository) RestoreVersion(ctx context.Context, id string, generation int64) (*domain.File, error) {
+	obj := r.client.Bucket(r.bucketName).Object(r.getObjectName(id))
+
+	live, err := obj.Attrs(ctx)
+	if err == nil && live.Generation == generation {
+		return r.attributesToFile(live), nil
+	}
+	if err != nil && err != storage.ErrObjectNotExist {
+		return nil, fmt.Errorf("failed to get object attributes: %w", err)
+	}
+
+	attrs, err := obj.CopierFrom(obj.Generation(generation)).Run(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, domain.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to restore version: %w", err)
+	}
+
+	return r.attributesToFile(attrs), nil
+}
+
+// DeleteVersion permanently deletes a noncurrent generation
+func (r *GCSFileRepository) DeleteVersion(ctx context.Context, id string, generation int64) error {
+	obj := r.client.Bucket(r.bucketName).Object(r.getObjectName(id))
+
+	if live, err := obj.Attrs(ctx); err == nil && live.Generation == generation {
+		return domain.ErrVersionNotFound
+	}
+
+	if err := obj.Generation(generation).Delete(ctx); err != nil {
+		if err == storage.ErrObjectNotExist {
+			return domain.ErrVersionNotFound
+		}
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+	return nil
+}
+
+// RenameFile moves a file together with its noncurrent generations to newID
+func (r *GCSFileRepository) RenameFile(ctx context.Context, id, newID string) (*domain.File, error) {
+	return r.Update(ctx, id, &domain.UpdateFileRequest{Name: newID})
+}
+
+// VersioningEnabled reports whether the bucket retains noncurrent generations
+func (r *GCSFileRepository) VersioningEnabled(ctx context.Context) (bool, error) {
+	attrs, err := r.client.Bucket(r.bucketName).Attrs(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get bucket attributes: %w", err)
+	}
+	return attrs.VersioningEnabled, nil
+}
+
+// listGenerations returns every generation of an object, oldest first
+func (r *GCSFileRepository) listGenerations(ctx context.Context, objectName string) ([]*storage.ObjectAttrs, error) {
+	it := r.client.Bucket(r.bucketName).Objects(ctx, &storage.Query{
+		Prefix:   objectName,
+		Versions: true,
+	})
+
+	var generations []*storage.ObjectAttrs
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate object versions: %w", err)
+		}
+		if attrs.Name == objectName {
+			generations = append(generations, attrs)
+		}
+	}
+
+	sort.Slice(generations, func(i, j int) bool {
+		return generations[i].Generation < generations[j].Generation
+	})
+	return generations, nil
+}
+
+// moveObject copies every generation of an object to dst, oldest first so the
+// history keeps its order, then deletes the source generations
+func (r *GCSFileRepository) moveObject(ctx context.Context, objectName string, dst *storage.ObjectHandle) error {
+	generations, err := r.listGenerations(ctx, objectName)
+	if err != nil {
+		return err
+	}
+
+	src := r.client.Bucket(r.bucketName).Object(objectName)
+	for _, attrs := range generations {
+		if _, err := dst.CopierFrom(src.Generation(attrs.Generation)).Run(ctx); err != nil {
+			return fmt.Errorf("failed to copy object: %w", err)
+		}
+	}
+	for _, attrs := range generations {
+		if err := src.Generation(attrs.Generation).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+			return fmt.Errorf("failed to delete old object: %w", err)
+		}
+	}
+	return nil
+}
+
 // getObjectName constructs the full object name with folder prefix
 func (r *GCSFileRepository) getObjectName(filename string) string {
 	if r.folder == "" {
@@ -304,6 +520,8 @@ func (r *GCSFileRepository) attributesToFile(attrs *storage.ObjectAttrs) *domain
 		Path:        attrs.Name,
 		Size:        attrs.Size,
 		ContentType: attrs.ContentType,
+		ETag:        attrs.Etag,
+		Generation:  attrs.Generation,
 		CreatedAt:   attrs.Created,
 		UpdatedAt:   attrs.Updated,
 	}
@@ -0,0 +1,246 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultGoogleJWKSURL is the endpoint publishing Google's ID token signing keys
+const DefaultGoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// googleIssuers lists the issuer values Google uses for ID tokens
+var googleIssuers = []string{"accounts.google.com", "https://accounts.google.com"}
+
+// minJWKSRefreshInterval limits how often an unknown key ID can trigger a refetch
+const minJWKSRefreshInterval = time.Minute
+
+// GoogleIDTokenVerifier implements domain.IDTokenVerifier for Google ID tokens
+type GoogleIDTokenVerifier struct {
+	jwksURL    string
+	audience   string
+	issuers    []string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	expiresAt   time.Time
+	lastFetchAt time.Time
+}
+
+// NewGoogleIDTokenVerifier creates a new GoogleIDTokenVerifier.
+// jwksURL can point at a local JWKS stand-in for tests; an empty value uses Google's endpoint.
+func NewGoogleIDTokenVerifier(jwksURL, audience string, httpClient *http.Client) *GoogleIDTokenVerifier {
+	if jwksURL == "" {
+		jwksURL = DefaultGoogleJWKSURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &GoogleIDTokenVerifier{
+		jwksURL:    jwksURL,
+		audience:   audience,
+		issuers:    googleIssuers,
+		httpClient: httpClient,
+		now:        time.Now,
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+}
+
+// googleIDTokenClaims represents the claims of a Google ID token
+type googleIDTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+// Verify validates the raw ID token and returns the identity it asserts
+func (v *GoogleIDTokenVerifier) Verify(ctx context.Context, rawToken string) (*domain.Identity, error) {
+	if rawToken == "" {
+		return nil, domain.ErrInvalidToken
+	}
+	if v.audience == "" {
+		return nil, fmt.Errorf("google client ID is not configured")
+	}
+
+	claims := &googleIDTokenClaims{}
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(v.audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(v.now),
+	)
+
+	_, err := parser.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.getKey(ctx, kid)
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	}
+
+	if !v.isValidIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", domain.ErrInvalidToken, claims.Issuer)
+	}
+	if claims.Email == "" {
+		return nil, fmt.Errorf("%w: email claim is missing", domain.ErrInvalidToken)
+	}
+	if !claims.EmailVerified {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	return &domain.Identity{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(claims.Email),
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+// isValidIssuer checks whether the issuer is one Google uses
+func (v *GoogleIDTokenVerifier) isValidIssuer(issuer string) bool {
+	for _, iss := range v.issuers {
+		if issuer == iss {
+			return true
+		}
+	}
+	return false
+}
+
+// getKey returns the public key for the given key ID, refreshing the key set when needed
+func (v *GoogleIDTokenVerifier) getKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	fresh := v.now().Before(v.expiresAt)
+	canRefresh := v.now().Sub(v.lastFetchAt) >= minJWKSRefreshInterval
+	v.mu.RUnlock()
+
+	if ok && fresh {
+		return key, nil
+	}
+	// Refresh when the cached set expired, or when an unknown key ID suggests a rotation
+	if !fresh || canRefresh {
+		if err := v.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// jsonWebKeySet represents a JWKS document
+type jsonWebKeySet struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// refreshKeys fetches the key set from the JWKS endpoint
+func (v *GoogleIDTokenVerifier) refreshKeys(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set jsonWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		key, err := parseRSAPublicKey(k.N, k.E)
+		if err != nil {
+			return fmt.Errorf("failed to parse JWKS key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+
+	now := v.now()
+	v.mu.Lock()
+	v.keys = keys
+	v.lastFetchAt = now
+	v.expiresAt = now.Add(cacheMaxAge(resp.Header.Get("Cache-Control")))
+	v.mu.Unlock()
+
+	return nil
+}
+
+// parseRSAPublicKey builds an RSA public key from base64url-encoded modulus and exponent
+func parseRSAPublicKey(n, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(eBytes)
+	if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) {
+		return nil, fmt.Errorf("exponent is too large")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(exponent.Int64()),
+	}, nil
+}
+
+// cacheMaxAge extracts max-age from a Cache-Control header, defaulting to one hour
+func cacheMaxAge(header string) time.Duration {
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.HasPrefix(directive, "max-age=") {
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return time.Hour
+}
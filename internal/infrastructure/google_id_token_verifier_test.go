@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClientID = "test-client.apps.googleusercontent.com"
+
+// newTestJWKSServer serves the public half of key as a JWKS document
+func newTestJWKSServer(t *testing.T, kid string, key *rsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": kid,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func signTestIDToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validTestClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            testClientID,
+		"sub":            "1234567890",
+		"email":          "Alice@Example.com",
+		"email_verified": true,
+		"name":           "Alice",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestGoogleIDTokenVerifier_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	server := newTestJWKSServer(t, "key-1", key)
+	verifier := NewGoogleIDTokenVerifier(server.URL, testClientID, server.Client())
+
+	t.Run("Valid token", func(t *testing.T) {
+		token := signTestIDToken(t, "key-1", key, validTestClaims())
+
+		identity, err := verifier.Verify(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", identity.Email)
+		assert.Equal(t, "Alice", identity.Name)
+		assert.Equal(t, "1234567890", identity.Subject)
+	})
+
+	t.Run("Wrong audience", func(t *testing.T) {
+		claims := validTestClaims()
+		claims["aud"] = "someone-else"
+		token := signTestIDToken(t, "key-1", key, claims)
+
+		_, err := verifier.Verify(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("Wrong issuer", func(t *testing.T) {
+		claims := validTestClaims()
+		claims["iss"] = "https://evil.example.com"
+		token := signTestIDToken(t, "key-1", key, claims)
+
+		_, err := verifier.Verify(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		claims := validTestClaims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		token := signTestIDToken(t, "key-1", key, claims)
+
+		_, err := verifier.Verify(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrTokenExpired)
+	})
+
+	t.Run("Unverified email", func(t *testing.T) {
+		claims := validTestClaims()
+		claims["email_verified"] = false
+		token := signTestIDToken(t, "key-1", key, claims)
+
+		_, err := verifier.Verify(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+	})
+
+	t.Run("Signed by unknown key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token := signTestIDToken(t, "key-1", otherKey, validTestClaims())
+
+		_, err = verifier.Verify(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+}
+
+func TestJWTSessionManager(t *testing.T) {
+	manager := NewJWTSessionManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+
+	t.Run("Issue and parse", func(t *testing.T) {
+		token, issued, err := manager.Issue(42, "alice@example.com")
+		require.NoError(t, err)
+
+		claims, err := manager.Parse(token)
+		require.NoError(t, err)
+		assert.Equal(t, uint(42), claims.UserID)
+		assert.Equal(t, "alice@example.com", claims.Email)
+		assert.True(t, issued.ExpiresAt.Equal(claims.ExpiresAt))
+	})
+
+	t.Run("Reject token signed with another secret", func(t *testing.T) {
+		other := NewJWTSessionManager([]byte("ffffffffffffffffffffffffffffffff"), time.Hour)
+		token, _, err := other.Issue(42, "alice@example.com")
+		require.NoError(t, err)
+
+		_, err = manager.Parse(token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	})
+
+	t.Run("Reject expired token", func(t *testing.T) {
+		expired := NewJWTSessionManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+		expired.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+		token, _, err := expired.Issue(42, "alice@example.com")
+		require.NoError(t, err)
+
+		_, err = manager.Parse(token)
+		assert.ErrorIs(t, err, domain.ErrTokenExpired)
+	})
+}
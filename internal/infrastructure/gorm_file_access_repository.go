@@ -0,0 +1,168 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+// GormFileAccessRepository implements domain.FileAccessRepository on the entity tables
+type GormFileAccessRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFileAccessRepository creates a new GormFileAccessRepository
+func NewGormFileAccessRepository(db *gorm.DB) *GormFileAccessRepository {
+	return &GormFileAccessRepository{db: db}
+}
+
+// AttachmentOwners returns the users allowed to attach files to the entity
+func (r *GormFileAccessRepository) AttachmentOwners(ctx context.Context, attachment domain.FileAttachment) ([]uint, error) {
+	db := r.db.WithContext(ctx)
+
+	var owners []uint
+	var err error
+	switch attachment.Type {
+	case domain.AttachmentProfile:
+		var profile database.Profile
+		if err = db.Select("id", "user_id").First(&profile, attachment.ID).Error; err == nil {
+			owners = []uint{profile.UserID}
+		}
+	case domain.AttachmentContest:
+		var contest database.Contest
+		if err = db.Select("id", "author_id").First(&contest, attachment.ID).Error; err == nil {
+			owners = []uint{contest.AuthorID}
+		}
+	case domain.AttachmentHackathon:
+		var hackathon database.Hackathon
+		if err = db.Select("id", "owner_id").First(&hackathon, attachment.ID).Error; err == nil && hackathon.OwnerID != nil {
+			owners = []uint{*hackathon.OwnerID}
+		}
+	case domain.AttachmentParticipant:
+		// The participant and the organizer of its hackathon may both attach team files
+		var participant database.HackathonParticipant
+		if err = db.Select("id", "hackathon_id", "user_id").First(&participant, attachment.ID).Error; err == nil {
+			owners = []uint{participant.UserID}
+			var hackathon database.Hackathon
+			if db.Select("id", "owner_id").First(&hackathon, participant.HackathonID).Error == nil && hackathon.OwnerID != nil {
+				owners = append(owners, *hackathon.OwnerID)
+			}
+		}
+	case domain.AttachmentSubmission:
+		// Active members of the team may attach files until submissions lock
+		var submission database.Submission
+		if err = db.Select("id", "hackathon_id", "team_id").First(&submission, attachment.ID).Error; err == nil {
+			owners, err = r.submissionEditors(db, &submission)
+		}
+	default:
+		return nil, domain.ErrInvalidAttachment
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve %s owners: %w", attachment.Type, err)
+	}
+	return owners, nil
+}
+
+// IsHackathonMember reports whether the user owns or participates in the hackathon the attachment belongs to
+func (r *GormFileAccessRepository) IsHackathonMember(ctx context.Context, attachment domain.FileAttachment, userID uint) (bool, error) {
+	db := r.db.WithContext(ctx)
+
+	var hackathonID uint
+	switch attachment.Type {
+	case domain.AttachmentHackathon:
+		hackathonID = attachment.ID
+	case domain.AttachmentParticipant:
+		var participant database.HackathonParticipant
+		if err := db.Select("id", "hackathon_id").First(&participant, attachment.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get participant: %w", err)
+		}
+		hackathonID = participant.HackathonID
+	case domain.AttachmentSubmission:
+		var submission database.Submission
+		if err := db.Select("id", "hackathon_id").First(&submission, attachment.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get submission: %w", err)
+		}
+		hackathonID = submission.HackathonID
+	default:
+		return false, nil
+	}
+
+	var count int64
+	err := db.Model(&database.Hackathon{}).Where("id = ? AND owner_id = ?", hackathonID, userID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check hackathon owner: %w", err)
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err = db.Model(&database.HackathonParticipant{}).Where("hackathon_id = ? AND user_id = ?", hackathonID, userID).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check hackathon participant: %w", err)
+	}
+	return count > 0, nil
+}
+
+// AttachmentLocked reports whether the files attached to the entity are frozen. Only
+// submission files are, once the submissions of their hackathon are closed.
+func (r *GormFileAccessRepository) AttachmentLocked(ctx context.Context, attachment domain.FileAttachment) (bool, error) {
+	if attachment.Type != domain.AttachmentSubmission {
+		return false, nil
+	}
+
+	db := r.db.WithContext(ctx)
+	var submission database.Submission
+	if err := db.Select("id", "hackathon_id").First(&submission, attachment.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get submission: %w", err)
+	}
+	open, err := submissionsOpen(db, submission.HackathonID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check submission deadline: %w", err)
+	}
+	return !open, nil
+}
+
+// submissionEditors returns the active members of the team of a submission, or
+// nobody once the submissions of its hackathon are locked
+func (r *GormFileAccessRepository) submissionEditors(db *gorm.DB, submission *database.Submission) ([]uint, error) {
+	open, err := submissionsOpen(db, submission.HackathonID)
+	if err != nil {
+		return nil, err
+	}
+	if !open {
+		return []uint{}, nil
+	}
+
+	var members []uint
+	err = db.Model(&database.HackathonParticipant{}).
+		Where("team_id = ? AND status IN ?", submission.TeamID, []string{domain.ParticipantRegistered, domain.ParticipantConfirmed, domain.ParticipantCheckedIn}).
+		Pluck("user_id", &members).Error
+	return members, err
+}
+
+// submissionsOpen reports whether teams may still change the submissions of a hackathon
+func submissionsOpen(db *gorm.DB, hackathonID uint) (bool, error) {
+	var hackathon database.Hackathon
+	if err := db.Select("id", "status", "end_date", "submission_deadline").First(&hackathon, hackathonID).Error; err != nil {
+		return false, err
+	}
+	return domain.SubmissionsOpen(hackathon.Status, hackathon.SubmissionDeadline, hackathon.EndDate, time.Now()), nil
+}
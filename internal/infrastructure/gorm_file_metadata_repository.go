@@ -0,0 +1,268 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fileSortColumns maps sortable fields to file_metadata columns
+var fileSortColumns = map[string]string{
+	domain.FileSortName:        "name",
+	domain.FileSortSize:        "size",
+	domain.FileSortContentType: "content_type",
+	domain.FileSortCreatedAt:   "created_at",
+	domain.FileSortUpdatedAt:   "updated_at",
+}
+
+// GormFileMetadataRepository implements domain.FileMetadataRepository on the file_metadata table
+type GormFileMetadataRepository struct {
+	db *gorm.DB
+}
+
+// NewGormFileMetadataRepository creates a new GormFileMetadataRepository
+func NewGormFileMetadataRepository(db *gorm.DB) *GormFileMetadataRepository {
+	return &GormFileMetadataRepository{db: db}
+}
+
+// Transaction runs fn with a repository bound to a single database transaction
+func (r *GormFileMetadataRepository) Transaction(ctx context.Context, fn func(repo domain.FileMetadataRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormFileMetadataRepository{db: tx})
+	})
+}
+
+// Get retrieves the metadata row of a file
+func (r *GormFileMetadataRepository) Get(ctx context.Context, id string) (*domain.File, error) {
+	var row database.FileMetadata
+	if err := r.db.WithContext(ctx).Where("name = ?", id).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+	return metadataToFile(row), nil
+}
+
+// Save creates or replaces the metadata row of a file
+func (r *GormFileMetadataRepository) Save(ctx context.Context, file *domain.File) error {
+	metadata := database.FileMetadata{
+		Name:        file.ID,
+		Path:        file.Path,
+		Size:        file.Size,
+		ContentType: file.ContentType,
+		ETag:        file.ETag,
+		Generation:  file.Generation,
+		UploaderID:  file.UploaderID,
+		Visibility:  file.Visibility,
+		ScanStatus:  file.ScanStatus,
+		ScanThreat:  file.ScanThreat,
+		ScannedAt:   file.ScannedAt,
+		CreatedAt:   file.CreatedAt,
+		UpdatedAt:   file.UpdatedAt,
+	}
+	if metadata.Visibility == "" {
+		metadata.Visibility = domain.FileVisibilityPublic
+	}
+	if file.Attachment != nil {
+		metadata.AttachableType = file.Attachment.Type
+		metadata.AttachableID = file.Attachment.ID
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"path", "size", "content_type", "etag", "generation",
+			"uploader_id", "visibility", "attachable_type", "attachable_id",
+			"scan_status", "scan_threat", "scanned_at", "updated_at",
+		}),
+	}).Create(&metadata).Error
+	if err != nil {
+		return fmt.Errorf("failed to save file metadata: %w", err)
+	}
+	return nil
+}
+
+// UploaderUsage returns the total size and number of the files uploaded by a user
+func (r *GormFileMetadataRepository) UploaderUsage(ctx context.Context, uploaderID uint) (int64, int64, error) {
+	var usage struct {
+		Size  int64
+		Files int64
+	}
+	err := r.db.WithContext(ctx).Model(&database.FileMetadata{}).
+		Select("COALESCE(SUM(size), 0) AS size, COUNT(*) AS files").
+		Where("uploader_id = ?", uploaderID).
+		Scan(&usage).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compute storage usage: %w", err)
+	}
+	return usage.Size, usage.Files, nil
+}
+
+// Delete removes the metadata row of a file
+func (r *GormFileMetadataRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("name = ?", id).Delete(&database.FileMetadata{}).Error; err != nil {
+		return fmt.Errorf("failed to delete file metadata: %w", err)
+	}
+	return nil
+}
+
+// GetVersionScan retrieves the scan verdict recorded for a version
+func (r *GormFileMetadataRepository) GetVersionScan(ctx context.Context, id string, generation int64) (*domain.FileScan, error) {
+	var row database.FileVersionScan
+	if err := r.db.WithContext(ctx).Where("file_name = ? AND generation = ?", id, generation).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get version scan: %w", err)
+	}
+	return &domain.FileScan{ScanStatus: row.ScanStatus, ScanThreat: row.ScanThreat, ScannedAt: row.ScannedAt}, nil
+}
+
+// SaveVersionScan creates or replaces the scan verdict of a version
+func (r *GormFileMetadataRepository) SaveVersionScan(ctx context.Context, id string, generation int64, scan domain.FileScan) error {
+	row := database.FileVersionScan{
+		FileName:   id,
+		Generation: generation,
+		ScanStatus: scan.ScanStatus,
+		ScanThreat: scan.ScanThreat,
+		ScannedAt:  scan.ScannedAt,
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "file_name"}, {Name: "generation"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scan_status", "scan_threat", "scanned_at"}),
+	}).Create(&row).Error
+	if err != nil {
+		return fmt.Errorf("failed to save version scan: %w", err)
+	}
+	return nil
+}
+
+// DeleteVersionScans removes the scan verdicts of the given versions of a file, or of all its versions
+func (r *GormFileMetadataRepository) DeleteVersionScans(ctx context.Context, id string, generations ...int64) error {
+	db := r.db.WithContext(ctx).Where("file_name = ?", id)
+	if len(generations) > 0 {
+		db = db.Where("generation IN ?", generations)
+	}
+	if err := db.Delete(&database.FileVersionScan{}).Error; err != nil {
+		return fmt.Errorf("failed to delete version scans: %w", err)
+	}
+	return nil
+}
+
+// List retrieves files matching the query and the total number of matches
+func (r *GormFileMetadataRepository) List(ctx context.Context, query *domain.FileQuery) ([]*domain.File, int64, error) {
+	db := r.db.WithContext(ctx).Model(&database.FileMetadata{})
+
+	if query.Prefix != "" {
+		db = db.Where("name LIKE ? ESCAPE '\\'", escapeLike(query.Prefix)+"%")
+	}
+	if query.ContentType != "" {
+		if family, ok := strings.CutSuffix(query.ContentType, "/*"); ok {
+			db = db.Where("content_type LIKE ? ESCAPE '\\'", escapeLike(family)+"/%")
+		} else {
+			db = db.Where("content_type = ?", query.ContentType)
+		}
+	}
+	if query.MinSize != nil {
+		db = db.Where("size >= ?", *query.MinSize)
+	}
+	if query.MaxSize != nil {
+		db = db.Where("size <= ?", *query.MaxSize)
+	}
+	if query.Attachment != nil {
+		db = db.Where("attachable_type = ? AND attachable_id = ?", query.Attachment.Type, query.Attachment.ID)
+	}
+	if access := query.Access; access != nil {
+		if access.UploaderID != nil {
+			db = db.Where("visibility IN ? OR uploader_id = ?", access.Visibilities, *access.UploaderID)
+		} else {
+			db = db.Where("visibility IN ?", access.Visibilities)
+		}
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count file metadata: %w", err)
+	}
+
+	column, ok := fileSortColumns[query.SortBy]
+	if !ok {
+		column = "name"
+	}
+	db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: query.Desc})
+	if column != "name" {
+		// Keep pages stable when the sort column has duplicates
+		db = db.Order("name")
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+	if query.Offset > 0 {
+		db = db.Offset(query.Offset)
+	}
+
+	var rows []database.FileMetadata
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list file metadata: %w", err)
+	}
+
+	return metadataToFiles(rows), total, nil
+}
+
+// ListAll retrieves the metadata of every file
+func (r *GormFileMetadataRepository) ListAll(ctx context.Context) ([]*domain.File, error) {
+	var rows []database.FileMetadata
+	if err := r.db.WithContext(ctx).Order("name").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list file metadata: %w", err)
+	}
+	return metadataToFiles(rows), nil
+}
+
+// metadataToFiles converts file_metadata rows to domain Files
+func metadataToFiles(rows []database.FileMetadata) []*domain.File {
+	files := make([]*domain.File, 0, len(rows))
+	for _, row := range rows {
+		files = append(files, metadataToFile(row))
+	}
+	return files
+}
+
+// metadataToFile converts a file_metadata row to a domain File
+func metadataToFile(row database.FileMetadata) *domain.File {
+	file := &domain.File{
+		ID:          row.Name,
+		Name:        row.Name,
+		Path:        row.Path,
+		Size:        row.Size,
+		ContentType: row.ContentType,
+		ETag:        row.ETag,
+		Generation:  row.Generation,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		FileAccess: domain.FileAccess{
+			UploaderID: row.UploaderID,
+			Visibility: row.Visibility,
+		},
+		FileScan: domain.FileScan{
+			ScanStatus: row.ScanStatus,
+			ScanThreat: row.ScanThreat,
+			ScannedAt:  row.ScannedAt,
+		},
+	}
+	if row.AttachableType != "" {
+		file.Attachment = &domain.FileAttachment{Type: row.AttachableType, ID: row.AttachableID}
+	}
+	return file
+}
+
+// escapeLike escapes LIKE wildcards so value matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestGormFileMetadataRepository(t *testing.T) *GormFileMetadataRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}))
+	return NewGormFileMetadataRepository(db)
+}
+
+func fileIDs(files []*domain.File) []string {
+	ids := make([]string, 0, len(files))
+	for _, file := range files {
+		ids = append(ids, file.ID)
+	}
+	return ids
+}
+
+func TestGormFileMetadataRepository_SaveUpserts(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestGormFileMetadataRepository(t)
+	now := time.Now()
+
+	require.NoError(t, repo.Save(ctx, &domain.File{ID: "a.txt", Size: 1, ContentType: "text/plain", ETag: "v1", CreatedAt: now, UpdatedAt: now}))
+	require.NoError(t, repo.Save(ctx, &domain.File{ID: "a.txt", Size: 2, ContentType: "text/plain", ETag: "v2", CreatedAt: now, UpdatedAt: now}))
+
+	files, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, int64(2), files[0].Size)
+	assert.Equal(t, "v2", files[0].ETag)
+
+	require.NoError(t, repo.Delete(ctx, "a.txt"))
+	files, err = repo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestGormFileMetadataRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestGormFileMetadataRepository(t)
+	now := time.Now()
+
+	for _, file := range []*domain.File{
+		{ID: "docs/a.pdf", Size: 300, ContentType: "application/pdf"},
+		{ID: "docs/b.txt", Size: 10, ContentType: "text/plain"},
+		{ID: "images/c.png", Size: 200, ContentType: "image/png"},
+		{ID: "images/d.jpg", Size: 200, ContentType: "image/jpeg"},
+		{ID: "images_old/e.gif", Size: 50, ContentType: "image/gif"},
+	} {
+		file.CreatedAt, file.UpdatedAt = now, now
+		require.NoError(t, repo.Save(ctx, file))
+	}
+
+	t.Run("Prefix matches literally", func(t *testing.T) {
+		files, total, err := repo.List(ctx, &domain.FileQuery{Prefix: "images_"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"images_old/e.gif"}, fileIDs(files))
+	})
+
+	t.Run("Content type family", func(t *testing.T) {
+		files, total, err := repo.List(ctx, &domain.FileQuery{ContentType: "image/*"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []string{"images/c.png", "images/d.jpg", "images_old/e.gif"}, fileIDs(files))
+	})
+
+	t.Run("Exact content type", func(t *testing.T) {
+		files, _, err := repo.List(ctx, &domain.FileQuery{ContentType: "text/plain"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"docs/b.txt"}, fileIDs(files))
+	})
+
+	t.Run("Size range", func(t *testing.T) {
+		minSize, maxSize := int64(50), int64(200)
+		files, total, err := repo.List(ctx, &domain.FileQuery{MinSize: &minSize, MaxSize: &maxSize})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []string{"images/c.png", "images/d.jpg", "images_old/e.gif"}, fileIDs(files))
+	})
+
+	t.Run("Sort with stable tiebreak and pagination", func(t *testing.T) {
+		files, total, err := repo.List(ctx, &domain.FileQuery{SortBy: domain.FileSortSize, Desc: true, Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), total)
+		assert.Equal(t, []string{"images/c.png", "images/d.jpg"}, fileIDs(files))
+	})
+}
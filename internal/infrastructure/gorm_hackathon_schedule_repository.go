@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+// hackathonScheduleLockKey identifies the PostgreSQL advisory lock held while statuses are advanced
+const hackathonScheduleLockKey int64 = 0x6861636b73746174 // "hackstat"
+
+// errHackathonMoved reports that a hackathon left the status it was due to move from
+var errHackathonMoved = errors.New("hackathon is no longer in the expected status")
+
+// HackathonStatusApplier makes a scheduled status change of hackathon inside tx.
+// It calls persist to write the change, so that the checks and hooks that go
+// with a status change run around it.
+type HackathonStatusApplier func(tx *gorm.DB, hackathon *database.Hackathon, from, to string, persist func() error) error
+
+// GormHackathonScheduleRepository implements domain.HackathonScheduleRepository on the hackathons table
+type GormHackathonScheduleRepository struct {
+	db    *gorm.DB
+	apply HackathonStatusApplier
+}
+
+// NewGormHackathonScheduleRepository creates a new GormHackathonScheduleRepository
+// that makes every status change through apply
+func NewGormHackathonScheduleRepository(db *gorm.DB, apply HackathonStatusApplier) *GormHackathonScheduleRepository {
+	return &GormHackathonScheduleRepository{db: db, apply: apply}
+}
+
+// AdvanceStatuses moves due hackathons forward one step at a time so that every
+// transition is recorded. On PostgreSQL a transaction-scoped advisory lock keeps
+// concurrent instances from doing the work twice; every update is also
+// conditional on the previous status, so a transition is never applied twice.
+func (r *GormHackathonScheduleRepository) AdvanceStatuses(ctx context.Context, now time.Time) ([]*domain.HackathonStatusTransition, error) {
+	var transitions []*domain.HackathonStatusTransition
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "postgres" {
+			var acquired bool
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", hackathonScheduleLockKey).Scan(&acquired).Error; err != nil {
+				return fmt.Errorf("failed to acquire scheduler lock: %w", err)
+			}
+			if !acquired {
+				return nil
+			}
+		}
+
+		var due []database.Hackathon
+		err := tx.
+			Where("(status = ? AND start_date <= ?) OR (status = ? AND end_date <= ?)",
+				domain.HackathonStatusUpcoming, now, domain.HackathonStatusOngoing, now).
+			Order("id").
+			Find(&due).Error
+		if err != nil {
+			return fmt.Errorf("failed to list due hackathons: %w", err)
+		}
+
+		for _, hackathon := range due {
+			target := domain.ScheduledHackathonStatus(hackathon.StartDate, hackathon.EndDate, now)
+			for status := hackathon.Status; status != target; {
+				next := nextScheduledStatus(status)
+				transition, err := r.transition(tx, &hackathon, status, next, now)
+				if err != nil {
+					return err
+				}
+				if transition == nil {
+					break
+				}
+				transitions = append(transitions, transition)
+				status = next
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// transition moves a hackathon from one status to the next through the applier
+// and records it. It returns nil when the hackathon is no longer in the from
+// status.
+func (r *GormHackathonScheduleRepository) transition(tx *gorm.DB, hackathon *database.Hackathon, from, to string, now time.Time) (*domain.HackathonStatusTransition, error) {
+	row := database.HackathonStatusTransition{
+		HackathonID: hackathon.ID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Source:      domain.TransitionSourceScheduler,
+		CreatedAt:   now,
+	}
+	persist := func() error {
+		result := tx.Model(&database.Hackathon{}).
+			Where("id = ? AND status = ?", hackathon.ID, from).
+			Updates(map[string]interface{}{"status": to, "updated_at": now})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update hackathon %d status: %w", hackathon.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errHackathonMoved
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return fmt.Errorf("failed to record hackathon %d transition: %w", hackathon.ID, err)
+		}
+		return nil
+	}
+
+	err := r.apply(tx, hackathon, from, to, persist)
+	if errors.Is(err, errHackathonMoved) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to move hackathon %d from %s to %s: %w", hackathon.ID, from, to, err)
+	}
+	hackathon.Status = to
+	return &domain.HackathonStatusTransition{
+		HackathonID: hackathon.ID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Source:      row.Source,
+		CreatedAt:   row.CreatedAt,
+	}, nil
+}
+
+// nextScheduledStatus returns the status that follows status on the schedule
+func nextScheduledStatus(status string) string {
+	if status == domain.HackathonStatusUpcoming {
+		return domain.HackathonStatusOngoing
+	}
+	return domain.HackathonStatusCompleted
+}
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormHackathonScheduleRepository_AdvanceStatuses(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.Hackathon{}, &database.HackathonStatusTransition{}))
+	var applied []string
+	repo := NewGormHackathonScheduleRepository(db, func(tx *gorm.DB, hackathon *database.Hackathon, from, to string, persist func() error) error {
+		applied = append(applied, hackathon.Name+":"+to)
+		return persist()
+	})
+
+	now := time.Now().UTC()
+	create := func(name, status string, start, end time.Time) uint {
+		hackathon := database.Hackathon{
+			Name:                 name,
+			StartDate:            start,
+			EndDate:              end,
+			RegistrationStart:    start.Add(-72 * time.Hour),
+			RegistrationDeadline: start.Add(-time.Hour),
+			Organizer:            "Organizer",
+			Status:               status,
+		}
+		require.NoError(t, db.Create(&hackathon).Error)
+		return hackathon.ID
+	}
+	future := create("future", domain.HackathonStatusUpcoming, now.Add(time.Hour), now.Add(2*time.Hour))
+	started := create("started", domain.HackathonStatusUpcoming, now.Add(-time.Hour), now.Add(time.Hour))
+	finished := create("finished", domain.HackathonStatusUpcoming, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	cancelled := create("cancelled", domain.HackathonStatusCancelled, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	status := func(id uint) string {
+		var hackathon database.Hackathon
+		require.NoError(t, db.First(&hackathon, id).Error)
+		return hackathon.Status
+	}
+
+	transitions, err := repo.AdvanceStatuses(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, transitions, 3)
+	assert.Equal(t, domain.HackathonStatusUpcoming, status(future))
+	assert.Equal(t, domain.HackathonStatusOngoing, status(started))
+	assert.Equal(t, domain.HackathonStatusCompleted, status(finished))
+	assert.Equal(t, domain.HackathonStatusCancelled, status(cancelled))
+	assert.Equal(t, []string{"started:ongoing", "finished:ongoing", "finished:completed"}, applied, "every step goes through the applier")
+
+	var recorded []database.HackathonStatusTransition
+	require.NoError(t, db.Where("hackathon_id = ?", finished).Order("id").Find(&recorded).Error)
+	require.Len(t, recorded, 2, "each step is recorded")
+	assert.Equal(t, []string{domain.HackathonStatusUpcoming, domain.HackathonStatusOngoing}, []string{recorded[0].FromStatus, recorded[1].FromStatus})
+	assert.Equal(t, domain.TransitionSourceScheduler, recorded[1].Source)
+
+	t.Run("Nothing is due twice", func(t *testing.T) {
+		transitions, err := repo.AdvanceStatuses(ctx, now)
+		require.NoError(t, err)
+		assert.Empty(t, transitions)
+	})
+
+	t.Run("Ongoing hackathons complete after their end", func(t *testing.T) {
+		transitions, err := repo.AdvanceStatuses(ctx, now.Add(90*time.Minute))
+		require.NoError(t, err)
+		require.Len(t, transitions, 2)
+		assert.Equal(t, domain.HackathonStatusCompleted, status(started))
+		assert.Equal(t, domain.HackathonStatusOngoing, status(future))
+	})
+}
@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormSignedUploadRepository implements domain.SignedUploadRepository on the signed_uploads table
+type GormSignedUploadRepository struct {
+	db *gorm.DB
+}
+
+// NewGormSignedUploadRepository creates a new GormSignedUploadRepository
+func NewGormSignedUploadRepository(db *gorm.DB) *GormSignedUploadRepository {
+	return &GormSignedUploadRepository{db: db}
+}
+
+// Create stores a new upload, failing with ErrFileAlreadyExists if one is pending for the file
+func (r *GormSignedUploadRepository) Create(ctx context.Context, upload *domain.SignedUpload) error {
+	row := database.SignedUpload{
+		FileName:     upload.FileName,
+		ContentType:  upload.ContentType,
+		UploadLength: upload.Length,
+		UploaderID:   upload.UploaderID,
+		Visibility:   upload.Visibility,
+		ExpiresAt:    upload.ExpiresAt,
+	}
+	if row.Visibility == "" {
+		row.Visibility = domain.FileVisibilityPublic
+	}
+	if upload.Attachment != nil {
+		row.AttachableType = upload.Attachment.Type
+		row.AttachableID = upload.Attachment.ID
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create signed upload: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrFileAlreadyExists
+	}
+	upload.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// Get retrieves the pending upload of a file
+func (r *GormSignedUploadRepository) Get(ctx context.Context, fileName string) (*domain.SignedUpload, error) {
+	var row database.SignedUpload
+	if err := r.db.WithContext(ctx).Where("file_name = ?", fileName).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get signed upload: %w", err)
+	}
+	return rowToSignedUpload(row), nil
+}
+
+// MarkUsed records that content was sent, failing with ErrSignedUploadUsed if it already was
+func (r *GormSignedUploadRepository) MarkUsed(ctx context.Context, fileName string, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&database.SignedUpload{}).
+		Where("file_name = ? AND used_at IS NULL", fileName).
+		Update("used_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark signed upload as used: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSignedUploadUsed
+	}
+	return nil
+}
+
+// Delete removes the upload of a file
+func (r *GormSignedUploadRepository) Delete(ctx context.Context, fileName string) error {
+	if err := r.db.WithContext(ctx).Where("file_name = ?", fileName).Delete(&database.SignedUpload{}).Error; err != nil {
+		return fmt.Errorf("failed to delete signed upload: %w", err)
+	}
+	return nil
+}
+
+// ListExpired retrieves the uploads that expired before the given time
+func (r *GormSignedUploadRepository) ListExpired(ctx context.Context, before time.Time) ([]*domain.SignedUpload, error) {
+	var rows []database.SignedUpload
+	if err := r.db.WithContext(ctx).Where("expires_at < ?", before).Order("expires_at").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired signed uploads: %w", err)
+	}
+
+	uploads := make([]*domain.SignedUpload, 0, len(rows))
+	for _, row := range rows {
+		uploads = append(uploads, rowToSignedUpload(row))
+	}
+	return uploads, nil
+}
+
+// rowToSignedUpload converts a signed_uploads row to a domain SignedUpload
+func rowToSignedUpload(row database.SignedUpload) *domain.SignedUpload {
+	upload := &domain.SignedUpload{
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		Length:      row.UploadLength,
+		UsedAt:      row.UsedAt,
+		ExpiresAt:   row.ExpiresAt,
+		CreatedAt:   row.CreatedAt,
+		FileAccess: domain.FileAccess{
+			UploaderID: row.UploaderID,
+			Visibility: row.Visibility,
+		},
+	}
+	if row.AttachableType != "" {
+		upload.Attachment = &domain.FileAttachment{Type: row.AttachableType, ID: row.AttachableID}
+	}
+	return upload
+}
@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormUploadSessionRepository implements domain.UploadSessionRepository on the upload_sessions table
+type GormUploadSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewGormUploadSessionRepository creates a new GormUploadSessionRepository
+func NewGormUploadSessionRepository(db *gorm.DB) *GormUploadSessionRepository {
+	return &GormUploadSessionRepository{db: db}
+}
+
+// Transaction runs fn with a repository bound to a single database transaction
+func (r *GormUploadSessionRepository) Transaction(ctx context.Context, fn func(repo domain.UploadSessionRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormUploadSessionRepository{db: tx})
+	})
+}
+
+// Create stores a new session
+func (r *GormUploadSessionRepository) Create(ctx context.Context, session *domain.UploadSession) error {
+	row := database.UploadSession{
+		ID:           session.ID,
+		FileName:     session.FileName,
+		ContentType:  session.ContentType,
+		UploadLength: session.Length,
+		UploadOffset: session.Offset,
+		UploaderID:   session.UploaderID,
+		Visibility:   session.Visibility,
+		ExpiresAt:    session.ExpiresAt,
+	}
+	if row.Visibility == "" {
+		row.Visibility = domain.FileVisibilityPublic
+	}
+	if session.Attachment != nil {
+		row.AttachableType = session.Attachment.Type
+		row.AttachableID = session.Attachment.ID
+	}
+
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+	session.CreatedAt = row.CreatedAt
+	session.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// Get retrieves a session
+func (r *GormUploadSessionRepository) Get(ctx context.Context, id string) (*domain.UploadSession, error) {
+	return r.get(r.db.WithContext(ctx), id)
+}
+
+// GetForUpdate retrieves a session and locks it until the surrounding transaction ends
+func (r *GormUploadSessionRepository) GetForUpdate(ctx context.Context, id string) (*domain.UploadSession, error) {
+	return r.get(r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *GormUploadSessionRepository) get(db *gorm.DB, id string) (*domain.UploadSession, error) {
+	var row database.UploadSession
+	if err := db.Where("id = ?", id).First(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUploadNotFound
+		}
+		return nil, fmt.Errorf("failed to get upload session: %w", err)
+	}
+	return rowToUploadSession(row), nil
+}
+
+// Update stores the offset and expiry of a session
+func (r *GormUploadSessionRepository) Update(ctx context.Context, session *domain.UploadSession) error {
+	result := r.db.WithContext(ctx).Model(&database.UploadSession{ID: session.ID}).Updates(map[string]interface{}{
+		"upload_offset": session.Offset,
+		"expires_at":    session.ExpiresAt,
+		"updated_at":    time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update upload session: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUploadNotFound
+	}
+	return nil
+}
+
+// Delete removes a session
+func (r *GormUploadSessionRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&database.UploadSession{}).Error; err != nil {
+		return fmt.Errorf("failed to delete upload session: %w", err)
+	}
+	return nil
+}
+
+// ListExpired retrieves the sessions that expired before the given time
+func (r *GormUploadSessionRepository) ListExpired(ctx context.Context, before time.Time) ([]*domain.UploadSession, error) {
+	var rows []database.UploadSession
+	if err := r.db.WithContext(ctx).Where("expires_at < ?", before).Order("expires_at").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired upload sessions: %w", err)
+	}
+
+	sessions := make([]*domain.UploadSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, rowToUploadSession(row))
+	}
+	return sessions, nil
+}
+
+// rowToUploadSession converts an upload_sessions row to a domain UploadSession
+func rowToUploadSession(row database.UploadSession) *domain.UploadSession {
+	session := &domain.UploadSession{
+		ID:          row.ID,
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		Length:      row.UploadLength,
+		Offset:      row.UploadOffset,
+		ExpiresAt:   row.ExpiresAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		FileAccess: domain.FileAccess{
+			UploaderID: row.UploaderID,
+			Visibility: row.Visibility,
+		},
+	}
+	if row.AttachableType != "" {
+		session.Attachment = &domain.FileAttachment{Type: row.AttachableType, ID: row.AttachableID}
+	}
+	return session
+}
@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACCheckInSigner(t *testing.T) {
+	signer := NewHMACCheckInSigner([]byte("test-secret"))
+	token := signer.Sign(domain.CheckInClaims{HackathonID: 3, ParticipantID: 42})
+
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CheckInClaims{HackathonID: 3, ParticipantID: 42}, *claims)
+
+	t.Run("Tampered or foreign tokens", func(t *testing.T) {
+		other := NewHMACCheckInSigner([]byte("other-secret")).Sign(domain.CheckInClaims{HackathonID: 3, ParticipantID: 42})
+		forged := "ci1.3.43" + token[len("ci1.3.42"):]
+		for _, invalid := range []string{"", "ci1.3.42", other, forged, "ci2" + token[3:]} {
+			_, err := signer.Verify(invalid)
+			assert.ErrorIs(t, err, domain.ErrInvalidToken, invalid)
+		}
+	})
+
+	t.Run("Derived keys differ from their secret", func(t *testing.T) {
+		derived := NewHMACCheckInSigner(DeriveCheckInSecret([]byte("test-secret")))
+		_, err := derived.Verify(token)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		assert.Equal(t, DeriveCheckInSecret([]byte("test-secret")), DeriveCheckInSecret([]byte("test-secret")))
+	})
+}
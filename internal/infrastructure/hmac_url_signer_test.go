@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACURLSigner(t *testing.T) {
+	signer := NewHMACURLSigner([]byte("test-secret"), "http://localhost:8080/")
+	ctx := context.Background()
+
+	signQuery := func(t *testing.T, req *domain.SignedURLRequest) url.Values {
+		signed, err := signer.SignURL(ctx, req)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(signed.URL, "http://localhost:8080"+LocalStoragePath))
+		parsed, err := url.Parse(signed.URL)
+		require.NoError(t, err)
+		return parsed.Query()
+	}
+
+	t.Run("Valid upload URL", func(t *testing.T) {
+		query := signQuery(t, &domain.SignedURLRequest{
+			FileID:      "report.pdf",
+			Method:      http.MethodPut,
+			ContentType: "application/pdf",
+			ExpiresAt:   time.Now().Add(time.Minute),
+		})
+		assert.NoError(t, signer.Verify(http.MethodPut, "report.pdf", "application/pdf", query))
+	})
+
+	t.Run("Wrong method, file or content type", func(t *testing.T) {
+		query := signQuery(t, &domain.SignedURLRequest{
+			FileID:      "report.pdf",
+			Method:      http.MethodPut,
+			ContentType: "application/pdf",
+			ExpiresAt:   time.Now().Add(time.Minute),
+		})
+		assert.ErrorIs(t, signer.Verify(http.MethodGet, "report.pdf", "", query), domain.ErrInvalidSignature)
+		assert.ErrorIs(t, signer.Verify(http.MethodPut, "other.pdf", "application/pdf", query), domain.ErrInvalidSignature)
+		assert.ErrorIs(t, signer.Verify(http.MethodPut, "report.pdf", "text/html", query), domain.ErrInvalidSignature)
+	})
+
+	t.Run("Tampered expiry", func(t *testing.T) {
+		query := signQuery(t, &domain.SignedURLRequest{FileID: "a.txt", Method: http.MethodGet, ExpiresAt: time.Now().Add(time.Minute)})
+		query.Set("expires", "9999999999")
+		assert.ErrorIs(t, signer.Verify(http.MethodGet, "a.txt", "", query), domain.ErrInvalidSignature)
+	})
+
+	t.Run("Expired URL", func(t *testing.T) {
+		query := signQuery(t, &domain.SignedURLRequest{FileID: "a.txt", Method: http.MethodGet, ExpiresAt: time.Now().Add(-time.Minute)})
+		assert.ErrorIs(t, signer.Verify(http.MethodGet, "a.txt", "", query), domain.ErrSignatureExpired)
+	})
+}
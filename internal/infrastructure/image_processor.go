@@ -0,0 +1,255 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif" // register GIF decoding
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register WebP decoding
+)
+
+// Default image processing limits
+const (
+	DefaultMaxImageBytes  = 20 << 20
+	DefaultMaxImagePixels = 50_000_000
+	jpegQuality           = 90
+)
+
+// DefaultThumbnailSizes are the thumbnail edge lengths rendered for uploaded images
+var DefaultThumbnailSizes = []int{64, 256, 1024}
+
+// ImageProcessor implements domain.ImageProcessor with the standard library codecs.
+// JPEG images stay JPEG; PNG, GIF and WebP images are stored as PNG. Re-encoding
+// drops EXIF and other metadata, after applying the EXIF orientation to the pixels.
+type ImageProcessor struct {
+	sizes     []int
+	maxBytes  int64
+	maxPixels int
+}
+
+// NewImageProcessor creates a new ImageProcessor rendering thumbnails of the given sizes
+func NewImageProcessor(sizes []int, maxBytes int64, maxPixels int) *ImageProcessor {
+	return &ImageProcessor{
+		sizes:     sizes,
+		maxBytes:  maxBytes,
+		maxPixels: maxPixels,
+	}
+}
+
+// Process decodes the image, strips its metadata and renders its thumbnails
+func (p *ImageProcessor) Process(ctx context.Context, r io.Reader) (*domain.ProcessedImage, error) {
+	data, err := io.ReadAll(io.LimitReader(r, p.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > p.maxBytes {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	// Trust the bytes, not the file name or the declared content type
+	switch http.DetectContentType(data) {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+	default:
+		return nil, domain.ErrUnsupportedImage
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedImage, err)
+	}
+	if config.Width*config.Height > p.maxPixels {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrUnsupportedImage, err)
+	}
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	encode := encodePNG
+	processed := &domain.ProcessedImage{ContentType: "image/png", Extension: ".png"}
+	if format == "jpeg" {
+		encode = encodeJPEG
+		processed.ContentType, processed.Extension = "image/jpeg", ".jpg"
+	}
+
+	bounds := img.Bounds()
+	processed.Width, processed.Height = bounds.Dx(), bounds.Dy()
+	if processed.Original, err = encode(img); err != nil {
+		return nil, err
+	}
+
+	for _, size := range p.sizes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		thumbnail := fit(img, size)
+		content, err := encode(thumbnail)
+		if err != nil {
+			return nil, err
+		}
+		processed.Thumbnails = append(processed.Thumbnails, domain.Thumbnail{
+			Size:    size,
+			Width:   thumbnail.Bounds().Dx(),
+			Height:  thumbnail.Bounds().Dy(),
+			Content: content,
+		})
+	}
+
+	return processed, nil
+}
+
+// fit scales img so its longest edge is at most size pixels, never enlarging it
+func fit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*size/width)
+		width = size
+	} else {
+		width = max(1, width*size/height)
+		height = size
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// encodeJPEG encodes img as a JPEG without metadata
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodePNG encodes img as a PNG without metadata
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// jpegOrientation returns the EXIF orientation (1-8) of a JPEG image, or 1 if it has none
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// Walk the segments up to the start of the image data looking for the APP1 Exif segment
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xDA {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag from the first IFD of a TIFF structure
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// applyOrientation transforms img so it displays upright without the EXIF orientation tag
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		// Orientations 5-8 swap the axes
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = width-1-x, y
+			case 3: // rotated 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, height-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90° clockwise
+				dx, dy = height-1-y, x
+			case 7: // transversed
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotated 90° counter-clockwise
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+// withExifOrientation inserts an APP1 Exif segment carrying the orientation tag after the JPEG SOI marker
+func withExifOrientation(t *testing.T, data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = append(tiff, 0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	require.Equal(t, []byte{0xFF, 0xD8}, data[:2])
+	return append(append([]byte{0xFF, 0xD8}, segment...), data[2:]...)
+}
+
+func TestImageProcessor_Process(t *testing.T) {
+	processor := NewImageProcessor([]int{64, 256, 1024}, DefaultMaxImageBytes, DefaultMaxImagePixels)
+	ctx := context.Background()
+
+	t.Run("PNG with thumbnails", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage(2000, 1000)))
+
+		processed, err := processor.Process(ctx, &buf)
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", processed.ContentType)
+		assert.Equal(t, ".png", processed.Extension)
+		assert.Equal(t, 2000, processed.Width)
+		assert.Equal(t, 1000, processed.Height)
+
+		require.Len(t, processed.Thumbnails, 3)
+		for i, expected := range [][2]int{{64, 32}, {256, 128}, {1024, 512}} {
+			thumbnail := processed.Thumbnails[i]
+			assert.Equal(t, expected[0], thumbnail.Width)
+			assert.Equal(t, expected[1], thumbnail.Height)
+			config, format, err := image.DecodeConfig(bytes.NewReader(thumbnail.Content))
+			require.NoError(t, err)
+			assert.Equal(t, "png", format)
+			assert.Equal(t, expected[0], config.Width)
+		}
+	})
+
+	t.Run("Small images are not enlarged", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage(100, 50)))
+
+		processed, err := processor.Process(ctx, &buf)
+		require.NoError(t, err)
+		assert.Equal(t, 64, processed.Thumbnails[0].Width)
+		assert.Equal(t, 100, processed.Thumbnails[1].Width)
+		assert.Equal(t, 50, processed.Thumbnails[2].Height)
+	})
+
+	t.Run("JPEG orientation is applied and EXIF stripped", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, testImage(40, 20), nil))
+		data := withExifOrientation(t, buf.Bytes(), 6)
+		require.Equal(t, 6, jpegOrientation(data))
+
+		processed, err := processor.Process(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, "image/jpeg", processed.ContentType)
+		assert.Equal(t, ".jpg", processed.Extension)
+		assert.Equal(t, 20, processed.Width)
+		assert.Equal(t, 40, processed.Height)
+		assert.NotContains(t, string(processed.Original), "Exif")
+		assert.Equal(t, 1, jpegOrientation(processed.Original))
+	})
+
+	t.Run("Content is sniffed rather than trusted", func(t *testing.T) {
+		_, err := processor.Process(ctx, strings.NewReader("<html><body>not an image</body></html>"))
+		assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+
+		// A PNG signature followed by garbage is rejected when decoding
+		_, err = processor.Process(ctx, strings.NewReader("\x89PNG\r\n\x1a\ngarbage"))
+		assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+	})
+
+	t.Run("Size limits", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, testImage(100, 100)))
+
+		_, err := NewImageProcessor(nil, 10, DefaultMaxImagePixels).Process(ctx, bytes.NewReader(buf.Bytes()))
+		assert.ErrorIs(t, err, domain.ErrImageTooLarge)
+
+		_, err = NewImageProcessor(nil, DefaultMaxImageBytes, 100*100-1).Process(ctx, bytes.NewReader(buf.Bytes()))
+		assert.ErrorIs(t, err, domain.ErrImageTooLarge)
+	})
+}
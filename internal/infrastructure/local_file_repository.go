@@ -0,0 +1,825 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+)
+
+const (
+	localObjectsDir  = "objects"
+	localMetadataDir = "metadata"
+	localVersionsDir = "versions"
+	localTempDir     = "tmp"
+	localMetadataExt = ".json"
+)
+
+// localFileMetadata is the sidecar stored next to every object on disk
+type localFileMetadata struct {
+	OriginalName string     `json:"original_name"`
+	ContentType  string     `json:"content_type"`
+	ETag         string     `json:"etag"`
+	Generation   int64      `json:"generation"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	ReplacedAt   *time.Time `json:"replaced_at,omitempty"` // set on retained versions
+}
+
+// LocalFileRepository implements domain.FileRepository on the local filesystem.
+// Object content lives under <root>/objects/<folder>/<id> and its metadata under
+// <root>/metadata/<folder>/<id>.json, mirroring the object names used in GCS.
+// Overwritten content is kept under <root>/versions/<folder>/<id>/<generation>.
+type LocalFileRepository struct {
+	root   string
+	folder string
+	mu     sync.RWMutex
+}
+
+// NewLocalFileRepository creates a new LocalFileRepository rooted at dir
+func NewLocalFileRepository(dir, folder string) (*LocalFileRepository, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("storage directory is required for local file repository")
+	}
+	if folder == "" {
+		folder = "uploads"
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage directory: %w", err)
+	}
+	for _, sub := range []string{localObjectsDir, localMetadataDir, localVersionsDir, localTempDir} {
+		if err := os.MkdirAll(filepath.Join(root, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+
+	return &LocalFileRepository{
+		root:   root,
+		folder: folder,
+	}, nil
+}
+
+// Create creates a new file on disk
+func (r *LocalFileRepository) Create(ctx context.Context, req *domain.CreateFileRequest) (*domain.File, error) {
+	objectName, err := r.getObjectName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	meta := &localFileMetadata{
+		OriginalName: req.Name,
+		ContentType:  req.ContentType,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := r.writeObject(objectName, bytes.NewReader(req.Content), meta); err != nil {
+		return nil, err
+	}
+
+	return r.loadFile(objectName)
+}
+
+// CreateFromReader creates or replaces a file on disk, streaming its content
+func (r *LocalFileRepository) CreateFromReader(ctx context.Context, req *domain.CreateFileStreamRequest) (*domain.File, error) {
+	objectName, err := r.getObjectName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stream into a temporary file before taking the lock so slow uploads do not block readers
+	tmpName, etag, err := r.writeTemp(req.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write content: %w", err)
+	}
+	defer os.Remove(tmpName)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	meta := &localFileMetadata{
+		OriginalName: req.Name,
+		ContentType:  req.ContentType,
+		ETag:         etag,
+		Generation:   now.UnixMicro(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := r.commitObject(objectName, tmpName, meta); err != nil {
+		return nil, err
+	}
+
+	return r.loadFile(objectName)
+}
+
+// NewRangeReader opens a reader over a byte range of file content
+func (r *LocalFileRepository) NewRangeReader(ctx context.Context, id string, offset, length int64) (*domain.FileReader, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	file, err := r.loadFile(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return openRange(r.objectPath(objectName), file, offset, length)
+}
+
+// GetByID retrieves a file by its ID
+func (r *LocalFileRepository) GetByID(ctx context.Context, id string) (*domain.File, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.loadFile(objectName)
+}
+
+// GetContent retrieves file content with metadata
+func (r *LocalFileRepository) GetContent(ctx context.Context, id string) (*domain.FileData, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	file, err := r.loadFile(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(r.objectPath(objectName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+
+	return &domain.FileData{
+		File:    file,
+		Content: content,
+	}, nil
+}
+
+// List retrieves a list of files based on query parameters.
+// Files are ordered by object name, like a GCS listing.
+func (r *LocalFileRepository) List(ctx context.Context, query *domain.FileQuery) ([]*domain.File, error) {
+	prefix := r.folder + "/"
+	if query.Prefix != "" {
+		prefix = r.folder + "/" + query.Prefix
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	objectsRoot := filepath.Join(r.root, localObjectsDir)
+	var names []string
+	err := filepath.WalkDir(filepath.Join(objectsRoot, r.folder), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(objectsRoot, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate objects: %w", err)
+	}
+	sort.Strings(names)
+
+	var files []*domain.File
+	for i, name := range names {
+		if i < query.Offset {
+			continue
+		}
+		if query.Limit > 0 && len(files) >= query.Limit {
+			break
+		}
+
+		file, err := r.loadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// Update updates an existing file. Renaming moves the file together with its
+// versions; replaced content is kept as a version.
+func (r *LocalFileRepository) Update(ctx context.Context, id string, req *domain.UpdateFileRequest) (*domain.File, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+	newObjectName := objectName
+	if req.Name != "" && req.Name != id {
+		if newObjectName, err = r.getObjectName(req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	meta, err := r.readMetadata(objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if newObjectName != objectName {
+		if err := r.moveObject(objectName, newObjectName); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Content != nil && req.ContentType != "" {
+		meta.ContentType = req.ContentType
+	}
+	if req.Name != "" {
+		meta.OriginalName = req.Name
+	}
+	meta.UpdatedAt = time.Now().UTC()
+
+	if req.Content == nil {
+		if err := r.writeMetadata(r.metadataPath(newObjectName), meta); err != nil {
+			return nil, err
+		}
+	} else if err := r.writeObject(newObjectName, req.Content, meta); err != nil {
+		return nil, err
+	}
+
+	return r.loadFile(newObjectName)
+}
+
+// Delete deletes a file by its ID together with its versions
+func (r *LocalFileRepository) Delete(ctx context.Context, id string) error {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.removeObject(objectName); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return domain.ErrFileNotFound
+		}
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	if err := os.RemoveAll(r.versionsPath(objectName)); err != nil {
+		return fmt.Errorf("failed to delete object versions: %w", err)
+	}
+
+	return nil
+}
+
+// Exists checks if a file exists
+func (r *LocalFileRepository) Exists(ctx context.Context, id string) (bool, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, err := os.Stat(r.objectPath(objectName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check object existence: %w", err)
+	}
+
+	return !info.IsDir(), nil
+}
+
+// ListVersions lists the live and retained versions of a file, newest first
+func (r *LocalFileRepository) ListVersions(ctx context.Context, id string) ([]*domain.FileVersion, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var versions []*domain.FileVersion
+	live, err := r.loadFile(objectName)
+	switch {
+	case err == nil:
+		versions = append(versions, &domain.FileVersion{
+			Generation:  live.Generation,
+			Size:        live.Size,
+			ContentType: live.ContentType,
+			ETag:        live.ETag,
+			CreatedAt:   live.UpdatedAt,
+			Live:        true,
+		})
+	case !errors.Is(err, domain.ErrFileNotFound):
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(r.versionsPath(objectName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list object versions: %w", err)
+	}
+	for _, entry := range entries {
+		generation, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if entry.IsDir() || err != nil {
+			continue
+		}
+		file, meta, err := r.loadVersion(objectName, generation)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &domain.FileVersion{
+			Generation:  generation,
+			Size:        file.Size,
+			ContentType: file.ContentType,
+			ETag:        file.ETag,
+			CreatedAt:   file.UpdatedAt,
+			ReplacedAt:  meta.ReplacedAt,
+		})
+	}
+	if len(versions) == 0 {
+		return nil, domain.ErrFileNotFound
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Generation > versions[j].Generation
+	})
+	return versions, nil
+}
+
+// NewVersionReader opens a reader over a byte range of a specific version
+func (r *LocalFileRepository) NewVersionReader(ctx context.Context, id string, generation, offset, length int64) (*domain.FileReader, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if live, err := r.loadFile(objectName); err == nil && live.Generation == generation {
+		return openRange(r.objectPath(objectName), live, offset, length)
+	}
+
+	file, _, err := r.loadVersion(objectName, generation)
+	if err != nil {
+		return nil, err
+	}
+	return openRange(r.versionPath(objectName, generation), file, offset, length)
+}
+
+// RestoreVersion writes a copy of a retained version as the live content
+func (r *LocalFileRepository) RestoreVersion(ctx context.Context, id string, generation int64) (*domain.File, error) {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	live, err := r.loadFile(objectName)
+	if err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+		return nil, err
+	}
+	if live != nil && live.Generation == generation {
+		return live, nil
+	}
+
+	_, meta, err := r.loadVersion(objectName, generation)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.Open(r.versionPath(objectName, generation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version: %w", err)
+	}
+	tmpName, _, err := r.writeTemp(content)
+	content.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write content: %w", err)
+	}
+	defer os.Remove(tmpName)
+
+	now := time.Now().UTC()
+	meta.Generation = now.UnixMicro()
+	meta.UpdatedAt = now
+	meta.ReplacedAt = nil
+	if live != nil {
+		meta.CreatedAt = live.CreatedAt
+	}
+	if err := r.commitObject(objectName, tmpName, meta); err != nil {
+		return nil, err
+	}
+
+	return r.loadFile(objectName)
+}
+
+// DeleteVersion permanently deletes a retained version
+func (r *LocalFileRepository) DeleteVersion(ctx context.Context, id string, generation int64) error {
+	objectName, err := r.getObjectName(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versionPath := r.versionPath(objectName, generation)
+	if err := os.Remove(versionPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return domain.ErrVersionNotFound
+		}
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+	if err := os.Remove(versionPath + localMetadataExt); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+	return nil
+}
+
+// RenameFile moves a file together with its versions to newID
+func (r *LocalFileRepository) RenameFile(ctx context.Context, id, newID string) (*domain.File, error) {
+	return r.Update(ctx, id, &domain.UpdateFileRequest{Name: newID})
+}
+
+// getObjectName constructs the full object name with folder prefix.
+// IDs that would escape the folder are rejected.
+func (r *LocalFileRepository) getObjectName(filename string) (string, error) {
+	if filename == "" || !filepath.IsLocal(filepath.FromSlash(filename)) || path.Clean(filename) != filename {
+		return "", domain.ErrInvalidFileName
+	}
+	return r.folder + "/" + filename, nil
+}
+
+// getFileNameFromObjectName extracts the filename from the full object name
+func (r *LocalFileRepository) getFileNameFromObjectName(objectName string) string {
+	return strings.TrimPrefix(objectName, r.folder+"/")
+}
+
+// objectPath returns the on-disk location of an object's content
+func (r *LocalFileRepository) objectPath(objectName string) string {
+	return filepath.Join(r.root, localObjectsDir, filepath.FromSlash(objectName))
+}
+
+// metadataPath returns the on-disk location of an object's sidecar metadata
+func (r *LocalFileRepository) metadataPath(objectName string) string {
+	return filepath.Join(r.root, localMetadataDir, filepath.FromSlash(objectName)+localMetadataExt)
+}
+
+// versionsPath returns the on-disk directory holding an object's retained versions
+func (r *LocalFileRepository) versionsPath(objectName string) string {
+	return filepath.Join(r.root, localVersionsDir, filepath.FromSlash(objectName))
+}
+
+// versionPath returns the on-disk location of a retained version's content;
+// its sidecar metadata is stored next to it with the metadata extension
+func (r *LocalFileRepository) versionPath(objectName string, generation int64) string {
+	return filepath.Join(r.versionsPath(objectName), strconv.FormatInt(generation, 10))
+}
+
+// loadFile builds the domain File for an object from its content and sidecar
+func (r *LocalFileRepository) loadFile(objectName string) (*domain.File, error) {
+	info, err := os.Stat(r.objectPath(objectName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to get object attributes: %w", err)
+	}
+	if info.IsDir() {
+		return nil, domain.ErrFileNotFound
+	}
+
+	meta, err := r.readMetadata(objectName)
+	if err != nil {
+		if !errors.Is(err, domain.ErrFileNotFound) {
+			return nil, err
+		}
+		// Content without a sidecar, e.g. copied in by hand
+		meta = &localFileMetadata{CreatedAt: info.ModTime().UTC(), UpdatedAt: info.ModTime().UTC()}
+	}
+
+	return r.newFile(objectName, info.Size(), meta), nil
+}
+
+// newFile builds the domain File for object content of the given size and its metadata
+func (r *LocalFileRepository) newFile(objectName string, size int64, meta *localFileMetadata) *domain.File {
+	id := r.getFileNameFromObjectName(objectName)
+	name := meta.OriginalName
+	if name == "" {
+		name = id
+	}
+	contentType := meta.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &domain.File{
+		ID:          id,
+		Name:        name,
+		Path:        objectName,
+		Size:        size,
+		ContentType: contentType,
+		ETag:        meta.ETag,
+		Generation:  meta.Generation,
+		CreatedAt:   meta.CreatedAt,
+		UpdatedAt:   meta.UpdatedAt,
+	}
+}
+
+// loadVersion builds the domain File for a retained version of an object
+func (r *LocalFileRepository) loadVersion(objectName string, generation int64) (*domain.File, *localFileMetadata, error) {
+	versionPath := r.versionPath(objectName, generation)
+	info, err := os.Stat(versionPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, domain.ErrVersionNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get version attributes: %w", err)
+	}
+
+	meta, err := r.readMetadataFile(versionPath + localMetadataExt)
+	if err != nil {
+		if !errors.Is(err, domain.ErrFileNotFound) {
+			return nil, nil, err
+		}
+		meta = &localFileMetadata{CreatedAt: info.ModTime().UTC(), UpdatedAt: info.ModTime().UTC()}
+	}
+
+	file := r.newFile(objectName, info.Size(), meta)
+	file.Generation = generation
+	return file, meta, nil
+}
+
+// readMetadata reads the sidecar metadata of an object
+func (r *LocalFileRepository) readMetadata(objectName string) (*localFileMetadata, error) {
+	return r.readMetadataFile(r.metadataPath(objectName))
+}
+
+// readMetadataFile reads a sidecar metadata file
+func (r *LocalFileRepository) readMetadataFile(metaPath string) (*localFileMetadata, error) {
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to read object metadata: %w", err)
+	}
+
+	var meta localFileMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to decode object metadata: %w", err)
+	}
+	return &meta, nil
+}
+
+// writeObject atomically replaces an object's content and sidecar metadata
+func (r *LocalFileRepository) writeObject(objectName string, content io.Reader, meta *localFileMetadata) error {
+	tmpName, etag, err := r.writeTemp(content)
+	if err != nil {
+		return fmt.Errorf("failed to write content: %w", err)
+	}
+	defer os.Remove(tmpName)
+
+	meta.ETag = etag
+	meta.Generation = time.Now().UTC().UnixMicro()
+	return r.commitObject(objectName, tmpName, meta)
+}
+
+// writeTemp streams content into a temporary file and returns its name and MD5 ETag
+func (r *LocalFileRepository) writeTemp(content io.Reader) (string, string, error) {
+	tmp, err := os.CreateTemp(filepath.Join(r.root, localTempDir), "upload-*")
+	if err != nil {
+		return "", "", err
+	}
+
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", "", err
+	}
+
+	return tmp.Name(), hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// commitObject moves a temporary content file into place and writes its sidecar
+// metadata. The content it replaces is kept as a version.
+func (r *LocalFileRepository) commitObject(objectName, tmpName string, meta *localFileMetadata) error {
+	now := time.Now().UTC()
+	previous, err := r.archiveObject(objectName, now)
+	if err != nil {
+		return err
+	}
+	// Generations must keep increasing even when writes share a timestamp
+	if previous >= meta.Generation {
+		meta.Generation = previous + 1
+	}
+
+	target := r.objectPath(objectName)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("failed to write content: %w", err)
+	}
+	if err := os.Rename(tmpName, target); err != nil {
+		return fmt.Errorf("failed to write content: %w", err)
+	}
+
+	return r.writeMetadata(r.metadataPath(objectName), meta)
+}
+
+// writeMetadata atomically writes a sidecar metadata file
+func (r *LocalFileRepository) writeMetadata(metaPath string, meta *localFileMetadata) error {
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode object metadata: %w", err)
+	}
+
+	metaTmp, _, err := r.writeTemp(bytes.NewReader(metaJSON))
+	if err != nil {
+		return fmt.Errorf("failed to write object metadata: %w", err)
+	}
+	defer os.Remove(metaTmp)
+
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return fmt.Errorf("failed to write object metadata: %w", err)
+	}
+	if err := os.Rename(metaTmp, metaPath); err != nil {
+		return fmt.Errorf("failed to write object metadata: %w", err)
+	}
+	return nil
+}
+
+// archiveObject moves the live content of an object into its versions and returns
+// the archived generation, or 0 if the object does not exist
+func (r *LocalFileRepository) archiveObject(objectName string, replacedAt time.Time) (int64, error) {
+	info, err := os.Stat(r.objectPath(objectName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get object attributes: %w", err)
+	}
+
+	meta, err := r.readMetadata(objectName)
+	if err != nil {
+		if !errors.Is(err, domain.ErrFileNotFound) {
+			return 0, err
+		}
+		meta = &localFileMetadata{CreatedAt: info.ModTime().UTC(), UpdatedAt: info.ModTime().UTC()}
+	}
+	if meta.Generation == 0 {
+		meta.Generation = info.ModTime().UTC().UnixMicro()
+	}
+	meta.ReplacedAt = &replacedAt
+
+	versionPath := r.versionPath(objectName, meta.Generation)
+	if err := os.MkdirAll(filepath.Dir(versionPath), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to archive object: %w", err)
+	}
+	if err := os.Rename(r.objectPath(objectName), versionPath); err != nil {
+		return 0, fmt.Errorf("failed to archive object: %w", err)
+	}
+	if err := r.writeMetadata(versionPath+localMetadataExt, meta); err != nil {
+		return 0, err
+	}
+	if err := os.Remove(r.metadataPath(objectName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, fmt.Errorf("failed to archive object: %w", err)
+	}
+	return meta.Generation, nil
+}
+
+// moveObject renames an object together with its versions. An object already
+// stored under the new name is kept as a version.
+func (r *LocalFileRepository) moveObject(objectName, newObjectName string) error {
+	if _, err := r.archiveObject(newObjectName, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(r.versionsPath(objectName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to move object versions: %w", err)
+	}
+	if len(entries) > 0 {
+		if err := os.MkdirAll(r.versionsPath(newObjectName), 0o755); err != nil {
+			return fmt.Errorf("failed to move object versions: %w", err)
+		}
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		from := filepath.Join(r.versionsPath(objectName), entry.Name())
+		if err := os.Rename(from, filepath.Join(r.versionsPath(newObjectName), entry.Name())); err != nil {
+			return fmt.Errorf("failed to move object versions: %w", err)
+		}
+	}
+	os.Remove(r.versionsPath(objectName))
+
+	for _, move := range [][2]string{
+		{r.objectPath(objectName), r.objectPath(newObjectName)},
+		{r.metadataPath(objectName), r.metadataPath(newObjectName)},
+	} {
+		if err := os.MkdirAll(filepath.Dir(move[1]), 0o755); err != nil {
+			return fmt.Errorf("failed to move object: %w", err)
+		}
+		if err := os.Rename(move[0], move[1]); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to move object: %w", err)
+		}
+	}
+	return nil
+}
+
+// openRange opens a reader over a byte range of the content stored at path.
+// The open descriptor keeps reading this content even if the file is replaced.
+func openRange(path string, file *domain.File, offset, length int64) (*domain.FileReader, error) {
+	if offset < 0 || (offset > 0 && offset >= file.Size) {
+		return nil, domain.ErrInvalidRange
+	}
+	if length < 0 || offset+length > file.Size {
+		length = file.Size - offset
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reader: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to seek content: %w", err)
+	}
+
+	return &domain.FileReader{
+		File: file,
+		Content: struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(f, length), f},
+		Offset: offset,
+		Length: length,
+	}, nil
+}
+
+// removeObject deletes an object's content and sidecar metadata
+func (r *LocalFileRepository) removeObject(objectName string) error {
+	if err := os.Remove(r.objectPath(objectName)); err != nil {
+		return err
+	}
+	if err := os.Remove(r.metadataPath(objectName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalFileRepository(t *testing.T) *LocalFileRepository {
+	repo, err := NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	return repo
+}
+
+func TestLocalFileRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestLocalFileRepository(t)
+
+	t.Run("Create and get", func(t *testing.T) {
+		file, err := repo.Create(ctx, &domain.CreateFileRequest{
+			Name:        "docs/readme.txt",
+			Content:     []byte("hello"),
+			ContentType: "text/plain",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "docs/readme.txt", file.ID)
+		assert.Equal(t, "test/docs/readme.txt", file.Path)
+		assert.Equal(t, int64(5), file.Size)
+		assert.Equal(t, "text/plain", file.ContentType)
+
+		data, err := repo.GetContent(ctx, "docs/readme.txt")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello"), data.Content)
+		assert.Equal(t, "text/plain", data.File.ContentType)
+
+		exists, err := repo.Exists(ctx, "docs/readme.txt")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Update content keeps content type unless given", func(t *testing.T) {
+		file, err := repo.Update(ctx, "docs/readme.txt", &domain.UpdateFileRequest{Content: strings.NewReader("hello, world")})
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), file.Size)
+		assert.Equal(t, "text/plain", file.ContentType)
+		assert.False(t, file.UpdatedAt.Before(file.CreatedAt))
+	})
+
+	t.Run("Rename moves content and metadata", func(t *testing.T) {
+		file, err := repo.Update(ctx, "docs/readme.txt", &domain.UpdateFileRequest{Name: "docs/README.md"})
+		require.NoError(t, err)
+		assert.Equal(t, "docs/README.md", file.ID)
+		assert.Equal(t, "text/plain", file.ContentType)
+
+		exists, err := repo.Exists(ctx, "docs/readme.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		data, err := repo.GetContent(ctx, "docs/README.md")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello, world"), data.Content)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, "docs/README.md"))
+		assert.ErrorIs(t, repo.Delete(ctx, "docs/README.md"), domain.ErrFileNotFound)
+
+		_, err := repo.GetByID(ctx, "docs/README.md")
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := repo.GetContent(ctx, "missing.txt")
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+
+		_, err = repo.Update(ctx, "missing.txt", &domain.UpdateFileRequest{Content: strings.NewReader("x")})
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+
+	t.Run("Path traversal is rejected", func(t *testing.T) {
+		_, err := repo.Create(ctx, &domain.CreateFileRequest{Name: "../escape.txt", Content: []byte("x")})
+		assert.ErrorIs(t, err, domain.ErrInvalidFileName)
+
+		_, err = repo.GetByID(ctx, "/etc/passwd")
+		assert.ErrorIs(t, err, domain.ErrInvalidFileName)
+	})
+}
+
+func TestLocalFileRepository_List(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestLocalFileRepository(t)
+
+	for _, name := range []string{"b.txt", "a/2.txt", "a-1.txt", "a/1.txt", "c.txt"} {
+		_, err := repo.Create(ctx, &domain.CreateFileRequest{Name: name, Content: []byte(name), ContentType: "text/plain"})
+		require.NoError(t, err)
+	}
+
+	ids := func(files []*domain.File) []string {
+		var result []string
+		for _, f := range files {
+			result = append(result, f.ID)
+		}
+		return result
+	}
+
+	t.Run("Ordered by object name", func(t *testing.T) {
+		files, err := repo.List(ctx, &domain.FileQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a-1.txt", "a/1.txt", "a/2.txt", "b.txt", "c.txt"}, ids(files))
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		files, err := repo.List(ctx, &domain.FileQuery{Prefix: "a/"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a/1.txt", "a/2.txt"}, ids(files))
+	})
+
+	t.Run("Offset and limit", func(t *testing.T) {
+		files, err := repo.List(ctx, &domain.FileQuery{Offset: 1, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a/1.txt", "a/2.txt"}, ids(files))
+	})
+}
+
+func TestLocalFileRepository_Versions(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestLocalFileRepository(t)
+
+	write := func(content string) *domain.File {
+		file, err := repo.CreateFromReader(ctx, &domain.CreateFileStreamRequest{
+			Name:        "notes.txt",
+			ContentType: "text/plain",
+			Content:     strings.NewReader(content),
+		})
+		require.NoError(t, err)
+		return file
+	}
+	readVersion := func(id string, generation int64) string {
+		reader, err := repo.NewVersionReader(ctx, id, generation, 0, -1)
+		require.NoError(t, err)
+		defer reader.Content.Close()
+		content, err := io.ReadAll(reader.Content)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	first := write("v1")
+	second := write("v2")
+	third := write("v3")
+	assert.Greater(t, second.Generation, first.Generation)
+	assert.Greater(t, third.Generation, second.Generation)
+
+	t.Run("Overwrites keep earlier versions", func(t *testing.T) {
+		versions, err := repo.ListVersions(ctx, "notes.txt")
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		assert.Equal(t, third.Generation, versions[0].Generation)
+		assert.True(t, versions[0].Live)
+		assert.Nil(t, versions[0].ReplacedAt)
+		assert.Equal(t, first.Generation, versions[2].Generation)
+		assert.False(t, versions[2].Live)
+		assert.NotNil(t, versions[2].ReplacedAt)
+
+		assert.Equal(t, "v1", readVersion("notes.txt", first.Generation))
+		assert.Equal(t, "v3", readVersion("notes.txt", third.Generation))
+
+		_, err = repo.NewVersionReader(ctx, "notes.txt", 42, 0, -1)
+		assert.ErrorIs(t, err, domain.ErrVersionNotFound)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		restored, err := repo.RestoreVersion(ctx, "notes.txt", first.Generation)
+		require.NoError(t, err)
+		assert.Greater(t, restored.Generation, third.Generation)
+
+		data, err := repo.GetContent(ctx, "notes.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", string(data.Content))
+
+		versions, err := repo.ListVersions(ctx, "notes.txt")
+		require.NoError(t, err)
+		assert.Len(t, versions, 4)
+		assert.Equal(t, "v3", readVersion("notes.txt", third.Generation))
+	})
+
+	t.Run("Rename moves versions", func(t *testing.T) {
+		_, err := repo.Update(ctx, "notes.txt", &domain.UpdateFileRequest{Name: "renamed.txt", Content: strings.NewReader("v4")})
+		require.NoError(t, err)
+
+		versions, err := repo.ListVersions(ctx, "renamed.txt")
+		require.NoError(t, err)
+		assert.Len(t, versions, 5)
+		assert.Equal(t, "v2", readVersion("renamed.txt", second.Generation))
+
+		_, err = repo.ListVersions(ctx, "notes.txt")
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+
+	t.Run("Delete version", func(t *testing.T) {
+		require.NoError(t, repo.DeleteVersion(ctx, "renamed.txt", second.Generation))
+		assert.ErrorIs(t, repo.DeleteVersion(ctx, "renamed.txt", second.Generation), domain.ErrVersionNotFound)
+
+		versions, err := repo.ListVersions(ctx, "renamed.txt")
+		require.NoError(t, err)
+		assert.Len(t, versions, 4)
+		assert.ErrorIs(t, repo.DeleteVersion(ctx, "renamed.txt", versions[0].Generation), domain.ErrVersionNotFound)
+	})
+
+	t.Run("Delete removes versions", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, "renamed.txt"))
+		_, err := repo.ListVersions(ctx, "renamed.txt")
+		assert.ErrorIs(t, err, domain.ErrFileNotFound)
+	})
+}
@@ -0,0 +1,109 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// sessionTokenIssuer identifies session tokens issued by this service
+const sessionTokenIssuer = "tru-s3-backend"
+
+// JWTSessionManager implements domain.SessionManager using HMAC-signed JWTs
+type JWTSessionManager struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewJWTSessionManager creates a new JWTSessionManager
+func NewJWTSessionManager(secret []byte, ttl time.Duration) *JWTSessionManager {
+	if len(secret) == 0 {
+		panic("session secret is required for JWT session manager")
+	}
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return &JWTSessionManager{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// sessionTokenClaims represents the JWT claims of a session token
+type sessionTokenClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// Issue creates a signed session token for the given user
+func (m *JWTSessionManager) Issue(userID uint, email string) (string, *domain.SessionClaims, error) {
+	now := m.now().UTC().Truncate(time.Second)
+	expiresAt := now.Add(m.ttl)
+
+	claims := sessionTokenClaims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    sessionTokenIssuer,
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign session token: %w", err)
+	}
+
+	return token, &domain.SessionClaims{
+		UserID:    userID,
+		Email:     email,
+		IssuedAt:  now,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Parse validates a session token and returns its claims
+func (m *JWTSessionManager) Parse(token string) (*domain.SessionClaims, error) {
+	claims := &sessionTokenClaims{}
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(sessionTokenIssuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(m.now),
+	)
+
+	_, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || userID == 0 {
+		return nil, fmt.Errorf("%w: invalid subject", domain.ErrInvalidToken)
+	}
+
+	sessionClaims := &domain.SessionClaims{
+		UserID:    uint(userID),
+		Email:     claims.Email,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if claims.IssuedAt != nil {
+		sessionClaims.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return sessionClaims, nil
+}
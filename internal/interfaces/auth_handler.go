@@ -0,0 +1,216 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	userDB "github.com/TRu-S3/backend/internal/database/user"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// errGmailTaken is returned when a new Google account's address is held by another user
+var errGmailTaken = errors.New("gmail address belongs to another user")
+
+type AuthHandler struct {
+	*BaseHandler
+	verifier    domain.IDTokenVerifier
+	sessions    domain.SessionManager
+	adminEmails map[string]bool
+}
+
+// NewAuthHandler creates a new AuthHandler. Users logging in with one of adminEmails are granted the admin role.
+func NewAuthHandler(db *gorm.DB, verifier domain.IDTokenVerifier, sessions domain.SessionManager, adminEmails []string) *AuthHandler {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(email)] = true
+	}
+
+	return &AuthHandler{
+		BaseHandler: NewBaseHandler(db),
+		verifier:    verifier,
+		sessions:    sessions,
+		adminEmails: admins,
+	}
+}
+
+type GoogleLoginRequest struct {
+	IDToken string `json:"id_token" binding:"required"`
+}
+
+type SessionResponse struct {
+	Token     string        `json:"token"`
+	TokenType string        `json:"token_type"`
+	ExpiresAt string        `json:"expires_at"`
+	User      database.User `json:"user"`
+}
+
+// GoogleLogin handles POST /api/v1/auth/google
+func (h *AuthHandler) GoogleLogin(c *gin.Context) {
+	var req GoogleLoginRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	identity, err := h.verifier.Verify(c.Request.Context(), req.IDToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmailNotVerified):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Google account email is not verified")
+		case errors.Is(err, domain.ErrTokenExpired):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "ID token expired")
+		case errors.Is(err, domain.ErrInvalidToken):
+			utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid ID token")
+		default:
+			log.Printf("Failed to verify Google ID token: %v", err)
+			utils.InternalErrorResponse(c, "Failed to verify ID token")
+		}
+		return
+	}
+	if identity.Subject == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Invalid ID token")
+		return
+	}
+
+	user, err := h.findOrCreateUser(identity)
+	if errors.Is(err, errGmailTaken) {
+		utils.ErrorResponse(c, http.StatusConflict, "This Google address is already used by another account")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to resolve user for %s: %v", identity.Email, err)
+		utils.InternalErrorResponse(c, "Failed to resolve user")
+		return
+	}
+
+	// The verified address decides, not the stored one
+	if h.adminEmails[strings.ToLower(identity.Email)] && !user.IsAdmin() {
+		if err := h.GetDatabase().Model(user).Update("role", userDB.RoleAdmin).Error; err != nil {
+			log.Printf("Failed to grant admin role to %s: %v", user.Gmail, err)
+			utils.InternalErrorResponse(c, "Failed to resolve user")
+			return
+		}
+	}
+
+	token, claims, err := h.sessions.Issue(user.ID, user.Gmail)
+	if err != nil {
+		log.Printf("Failed to issue session token: %v", err)
+		utils.InternalErrorResponse(c, "Failed to issue session token")
+		return
+	}
+
+	utils.StandardResponse(c, http.StatusOK, SessionResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: claims.ExpiresAt.Format(time.RFC3339),
+		User:      *user,
+	})
+}
+
+// GetCurrentUser handles GET /api/v1/auth/me
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
+	user, ok := CurrentUser(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	var fullUser database.User
+	query := h.GetDatabase().Preload("Profile").Preload("Profile.Tag")
+	if err := query.First(&fullUser, user.ID).Error; err != nil {
+		h.HandleDBError(c, err, "user")
+		return
+	}
+
+	utils.StandardResponse(c, http.StatusOK, fullUser)
+}
+
+// findOrCreateUser looks up the user bound to the identity's Google account,
+// creating it on first login. Accounts are matched by the immutable subject;
+// a row that was never logged into is claimed by its verified Gmail address.
+func (h *AuthHandler) findOrCreateUser(identity *domain.Identity) (*database.User, error) {
+	db := h.GetDatabase()
+	var user database.User
+	err := db.Where("google_subject = ?", identity.Subject).First(&user).Error
+	if err == nil {
+		return h.syncGmail(&user, identity.Email), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	err = db.Where("LOWER(gmail) = ? AND google_subject IS NULL", strings.ToLower(identity.Email)).First(&user).Error
+	if err == nil {
+		return h.claimUser(&user, identity)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	if runes := []rune(name); len(runes) > 100 {
+		name = string(runes[:100])
+	}
+
+	subject := identity.Subject
+	user = database.User{
+		Name:          name,
+		Gmail:         identity.Email,
+		Role:          userDB.RoleUser,
+		GoogleSubject: &subject,
+	}
+	if err := db.Create(&user).Error; err != nil {
+		// A concurrent login may have created the user first
+		if err := db.Where("google_subject = ?", identity.Subject).First(&user).Error; err == nil {
+			return &user, nil
+		}
+		if isUniqueConstraintError(err, "gmail") {
+			return nil, errGmailTaken
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// claimUser binds an unclaimed user to the identity's Google account
+func (h *AuthHandler) claimUser(user *database.User, identity *domain.Identity) (*database.User, error) {
+	result := h.GetDatabase().Model(&database.User{}).
+		Where("id = ? AND google_subject IS NULL", user.ID).
+		Update("google_subject", identity.Subject)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Claimed concurrently; only the same Google account may use it
+		if err := h.GetDatabase().Where("google_subject = ?", identity.Subject).First(user).Error; err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	user.GoogleSubject = &identity.Subject
+	return user, nil
+}
+
+// syncGmail records a changed Google address of a bound user. A conflicting
+// address held by another row is logged and left alone.
+func (h *AuthHandler) syncGmail(user *database.User, email string) *database.User {
+	if strings.EqualFold(user.Gmail, email) {
+		return user
+	}
+	if err := h.GetDatabase().Model(&database.User{}).Where("id = ?", user.ID).Update("gmail", email).Error; err != nil {
+		log.Printf("Failed to update Gmail address of user %d: %v", user.ID, err)
+		return user
+	}
+	user.Gmail = email
+	return user
+}
@@ -0,0 +1,224 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// stubIDTokenVerifier accepts a fixed token and returns a fixed identity
+type stubIDTokenVerifier struct {
+	token    string
+	identity *domain.Identity
+}
+
+func (v *stubIDTokenVerifier) Verify(_ context.Context, rawToken string) (*domain.Identity, error) {
+	if rawToken != v.token {
+		return nil, domain.ErrInvalidToken
+	}
+	return v.identity, nil
+}
+
+func setupAuthTestRouter(t *testing.T) (*gin.Engine, *gorm.DB, domain.SessionManager) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}, &database.Tag{}, &database.Profile{}, &database.Bookmark{}))
+
+	sessions := infrastructure.NewJWTSessionManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	verifier := &stubIDTokenVerifier{
+		token:    "valid-google-token",
+		identity: &domain.Identity{Subject: "google-alice", Email: "alice@example.com", EmailVerified: true, Name: "Alice"},
+	}
+	auth := NewAuthMiddleware(sessions, db)
+	authHandler := NewAuthHandler(db, verifier, sessions, nil)
+	bookmarkHandler := NewBookmarkHandler(db)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	v1.Use(auth.OptionalAuth())
+	v1.POST("/auth/google", authHandler.GoogleLogin)
+	v1.GET("/auth/me", auth.RequireAuth(), authHandler.GetCurrentUser)
+	v1.POST("/bookmarks", auth.RequireAuth(), bookmarkHandler.CreateBookmark)
+
+	return r, db, sessions
+}
+
+func login(t *testing.T, router *gin.Engine, idToken string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(GoogleLoginRequest{IDToken: idToken})
+	req, _ := http.NewRequest("POST", "/api/v1/auth/google", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_GoogleLogin(t *testing.T) {
+	router, db, _ := setupAuthTestRouter(t)
+
+	t.Run("First login creates user", func(t *testing.T) {
+		w := login(t, router, "valid-google-token")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp SessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.NotEmpty(t, resp.Token)
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.Equal(t, "alice@example.com", resp.User.Gmail)
+
+		var count int64
+		db.Model(&database.User{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Second login reuses user", func(t *testing.T) {
+		w := login(t, router, "valid-google-token")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		db.Model(&database.User{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Invalid ID token", func(t *testing.T) {
+		w := login(t, router, "forged-token")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHandler_GoogleLoginBindsGoogleAccount(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}))
+	sessions := infrastructure.NewJWTSessionManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	verifier := &stubIDTokenVerifier{token: "valid-google-token"}
+	authHandler := NewAuthHandler(db, verifier, sessions, []string{"admin@example.com"})
+	userHandler := NewUserHandler(db)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/auth/google", authHandler.GoogleLogin)
+	loginAs := func(subject, email string) *httptest.ResponseRecorder {
+		verifier.identity = &domain.Identity{Subject: subject, Email: email, EmailVerified: true}
+		return login(t, r, "valid-google-token")
+	}
+	subject := func(s string) *string { return &s }
+
+	t.Run("Unclaimed accounts are bound on first login", func(t *testing.T) {
+		invited := database.User{Name: "Invited", Gmail: "invited@example.com"}
+		require.NoError(t, db.Create(&invited).Error)
+
+		w := loginAs("google-invited", "Invited@example.com")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp SessionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, invited.ID, resp.User.ID)
+
+		w = loginAs("google-other", "invited@example.com")
+		assert.Equal(t, http.StatusConflict, w.Code, "a bound account is never matched by address")
+	})
+
+	t.Run("A changed address cannot take over the admin account", func(t *testing.T) {
+		mallory := database.User{Name: "Mallory", Gmail: "admin@example.com", GoogleSubject: subject("google-mallory")}
+		require.NoError(t, db.Create(&mallory).Error)
+
+		w := loginAs("google-admin", "admin@example.com")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		require.NoError(t, db.First(&mallory, mallory.ID).Error)
+		assert.False(t, mallory.IsAdmin())
+	})
+
+	t.Run("Users cannot change their Gmail address", func(t *testing.T) {
+		var user database.User
+		require.NoError(t, db.Where("google_subject = ?", "google-invited").First(&user).Error)
+		email := "someone-else@example.com"
+		update := func(actor *database.User) int {
+			router := gin.New()
+			router.Use(withUser(actor))
+			router.PUT("/users/:id", userHandler.UpdateUser)
+			body, _ := json.Marshal(UpdateUserRequest{Gmail: &email})
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/users/%d", user.ID), bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w.Code
+		}
+
+		assert.Equal(t, http.StatusForbidden, update(&user))
+		admin := database.User{Name: "Admin", Gmail: "root@example.com", Role: "admin"}
+		assert.Equal(t, http.StatusOK, update(&admin))
+	})
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	router, db, sessions := setupAuthTestRouter(t)
+
+	alice := database.User{Name: "Alice", Gmail: "alice@example.com"}
+	bob := database.User{Name: "Bob", Gmail: "bob@example.com"}
+	db.Create(&alice)
+	db.Create(&bob)
+	token, _, err := sessions.Issue(alice.ID, alice.Gmail)
+	require.NoError(t, err)
+
+	postBookmark := func(authorization string, body interface{}) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(body)
+		req, _ := http.NewRequest("POST", "/api/v1/bookmarks", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Missing token is rejected", func(t *testing.T) {
+		w := postBookmark("", CreateBookmarkRequest{BookmarkedUserID: bob.ID})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Invalid token is rejected", func(t *testing.T) {
+		w := postBookmark("Bearer not-a-token", CreateBookmarkRequest{BookmarkedUserID: bob.ID})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Authenticated user is used as owner", func(t *testing.T) {
+		w := postBookmark("Bearer "+token, CreateBookmarkRequest{BookmarkedUserID: bob.ID})
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var bookmark database.Bookmark
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bookmark))
+		assert.Equal(t, alice.ID, bookmark.UserID)
+	})
+
+	t.Run("Acting on behalf of another user is forbidden", func(t *testing.T) {
+		w := postBookmark("Bearer "+token, CreateBookmarkRequest{UserID: bob.ID, BookmarkedUserID: alice.ID})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Current user endpoint", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var user database.User
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Equal(t, alice.ID, user.ID)
+	})
+}
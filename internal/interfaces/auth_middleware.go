@@ -0,0 +1,144 @@
+package interfaces
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// currentUserKey is the Gin context key holding the authenticated user
+const currentUserKey = "currentUser"
+
+// AuthMiddleware authenticates requests carrying a session token
+type AuthMiddleware struct {
+	sessions domain.SessionManager
+	db       *gorm.DB
+}
+
+// NewAuthMiddleware creates a new AuthMiddleware
+func NewAuthMiddleware(sessions domain.SessionManager, db *gorm.DB) *AuthMiddleware {
+	return &AuthMiddleware{
+		sessions: sessions,
+		db:       db,
+	}
+}
+
+// RequireAuth rejects requests without a valid session token
+func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := m.authenticate(c)
+		if err != nil {
+			abortUnauthorized(c, err)
+			return
+		}
+		if user == nil {
+			abortUnauthorized(c, domain.ErrUnauthenticated)
+			return
+		}
+
+		c.Set(currentUserKey, user)
+		c.Next()
+	}
+}
+
+// OptionalAuth injects the authenticated user when a session token is present.
+// Requests without a token pass through; requests with an invalid token are rejected.
+func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := m.authenticate(c)
+		if err != nil {
+			abortUnauthorized(c, err)
+			return
+		}
+		if user != nil {
+			c.Set(currentUserKey, user)
+		}
+		c.Next()
+	}
+}
+
+// authenticate resolves the user from the Authorization header, returning nil when no token is sent
+func (m *AuthMiddleware) authenticate(c *gin.Context) (*database.User, error) {
+	token := bearerToken(c.GetHeader("Authorization"))
+	if token == "" {
+		return nil, nil
+	}
+
+	claims, err := m.sessions.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var user database.User
+	if err := m.db.First(&user, claims.UserID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CurrentUser returns the authenticated user injected by AuthMiddleware
+func CurrentUser(c *gin.Context) (*database.User, bool) {
+	value, exists := c.Get(currentUserKey)
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(*database.User)
+	return user, ok && user != nil
+}
+
+// SetCurrentUser injects an authenticated user into the context
+func SetCurrentUser(c *gin.Context, user *database.User) {
+	c.Set(currentUserKey, user)
+}
+
+// resolveActingUserID returns the user a request acts on behalf of.
+// Requests always act as the authenticated caller and may not name another user;
+// unauthenticated requests are rejected.
+func resolveActingUserID(c *gin.Context, requestedID uint) (uint, bool) {
+	user, ok := CurrentUser(c)
+	if !ok {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Authentication required")
+		return 0, false
+	}
+	if requestedID != 0 && requestedID != user.ID {
+		utils.ErrorResponse(c, http.StatusForbidden, "Cannot act on behalf of another user")
+		return 0, false
+	}
+	return user.ID, true
+}
+
+// bearerToken extracts the token from a Bearer Authorization header
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
+
+// abortUnauthorized aborts the request with a 401 response
+func abortUnauthorized(c *gin.Context, err error) {
+	message := "Authentication required"
+	switch {
+	case errors.Is(err, domain.ErrTokenExpired):
+		message = "Session token expired"
+	case errors.Is(err, domain.ErrInvalidToken):
+		message = "Invalid session token"
+	case !errors.Is(err, domain.ErrUnauthenticated):
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate request"})
+		return
+	}
+
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+}
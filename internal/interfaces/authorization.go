@@ -0,0 +1,393 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OwnerResolver loads the IDs of the users allowed to modify the resource addressed by the request
+type OwnerResolver func(c *gin.Context, db *gorm.DB) ([]uint, error)
+
+// Policy enforces ownership-based authorization in front of handlers
+type Policy struct {
+	db *gorm.DB
+}
+
+// NewPolicy creates a new Policy
+func NewPolicy(db *gorm.DB) *Policy {
+	return &Policy{db: db}
+}
+
+// RequireOwner allows the request only when the authenticated user owns the resource.
+// Admins bypass the check. Must run after AuthMiddleware.RequireAuth.
+func (p *Policy) RequireOwner(entityName string, resolve OwnerResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		if user.IsAdmin() {
+			c.Next()
+			return
+		}
+
+		owners, err := resolve(c, p.db)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": entityName + " not found"})
+				return
+			}
+			if errors.Is(err, errInvalidResourceID) {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid " + entityName + " ID format"})
+				return
+			}
+			log.Printf("Failed to resolve %s owners: %v", entityName, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to authorize request"})
+			return
+		}
+
+		for _, ownerID := range owners {
+			if ownerID != 0 && ownerID == user.ID {
+				c.Next()
+				return
+			}
+		}
+
+		abortForbidden(c, entityName)
+	}
+}
+
+// RequireAdmin allows the request only for admins
+func (p *Policy) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		if !user.IsAdmin() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin role required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// abortForbidden aborts the request with a consistent 403 response
+func abortForbidden(c *gin.Context, entityName string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You do not have permission to modify this " + entityName})
+}
+
+// canActAs reports whether the authenticated user is an admin or the given user.
+// Unauthenticated requests are denied.
+func canActAs(c *gin.Context, userID uint) bool {
+	user, ok := CurrentUser(c)
+	if !ok {
+		return false
+	}
+	return user.IsAdmin() || user.ID == userID
+}
+
+var errInvalidResourceID = errors.New("invalid resource ID")
+
+// paramID parses a numeric URL parameter
+func paramID(c *gin.Context, name string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		return 0, errInvalidResourceID
+	}
+	return uint(id), nil
+}
+
+// UserSelf resolves the user addressed by the :id parameter as its own owner
+func UserSelf(c *gin.Context, _ *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	return []uint{id}, nil
+}
+
+// ContestAuthor resolves the author of the contest addressed by :id
+func ContestAuthor(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var contest database.Contest
+	if err := db.Select("id", "author_id").First(&contest, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{contest.AuthorID}, nil
+}
+
+// ContestApplicant resolves the user who sent the contest application addressed by :id
+func ContestApplicant(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var application database.ContestApplication
+	if err := db.Select("id", "user_id").First(&application, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{application.UserID}, nil
+}
+
+// ApplicationContestAuthor resolves the author of the contest the application
+// addressed by :id was sent to
+func ApplicationContestAuthor(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var application database.ContestApplication
+	if err := db.Select("id", "contest_id").First(&application, id).Error; err != nil {
+		return nil, err
+	}
+	var contest database.Contest
+	if err := db.Select("id", "author_id").First(&contest, application.ContestID).Error; err != nil {
+		return nil, err
+	}
+	return []uint{contest.AuthorID}, nil
+}
+
+// ProfileOwner resolves the user owning the profile addressed by :id
+func ProfileOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var profile database.Profile
+	if err := db.Select("id", "user_id").First(&profile, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{profile.UserID}, nil
+}
+
+// BookmarkOwner resolves the user owning the bookmark addressed by :id
+func BookmarkOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var bookmark database.Bookmark
+	if err := db.Select("id", "user_id").First(&bookmark, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{bookmark.UserID}, nil
+}
+
+// MatchingParties resolves both users of the matching addressed by :id
+func MatchingParties(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var matching database.Matching
+	if err := db.Select("id", "user1_id", "user2_id").First(&matching, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{matching.User1ID, matching.User2ID}, nil
+}
+
+// HackathonOwner resolves the owner of the hackathon addressed by :id
+func HackathonOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var hackathon database.Hackathon
+	if err := db.Select("id", "owner_id").First(&hackathon, id).Error; err != nil {
+		return nil, err
+	}
+	if hackathon.OwnerID == nil {
+		return nil, nil
+	}
+	return []uint{*hackathon.OwnerID}, nil
+}
+
+// SeriesOwner resolves the owner of the hackathon series addressed by :id
+func SeriesOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var series database.HackathonSeries
+	if err := db.Select("id", "owner_id").First(&series, id).Error; err != nil {
+		return nil, err
+	}
+	if series.OwnerID == nil {
+		return nil, nil
+	}
+	return []uint{*series.OwnerID}, nil
+}
+
+// ParticipantOrHackathonOwner resolves the participant addressed by :participant_id
+// together with the owner of its hackathon
+func ParticipantOrHackathonOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	hackathonID, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	participantID, err := paramID(c, "participant_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var participant database.HackathonParticipant
+	if err := db.Select("id", "user_id").Where("hackathon_id = ? AND id = ?", hackathonID, participantID).First(&participant).Error; err != nil {
+		return nil, err
+	}
+
+	owners := []uint{participant.UserID}
+	var hackathon database.Hackathon
+	if err := db.Select("id", "owner_id").First(&hackathon, hackathonID).Error; err == nil && hackathon.OwnerID != nil {
+		owners = append(owners, *hackathon.OwnerID)
+	}
+	return owners, nil
+}
+
+// TeamLeaderOrHackathonOwner resolves the leader of the team addressed by :team_id
+// together with the owner of its hackathon
+func TeamLeaderOrHackathonOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	hackathonID, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	teamID, err := paramID(c, "team_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var team database.Team
+	if err := db.Select("id", "hackathon_id", "leader_id").Where("hackathon_id = ? AND id = ?", hackathonID, teamID).First(&team).Error; err != nil {
+		return nil, err
+	}
+	return teamManagers(db, &team), nil
+}
+
+// TeamMemberSelfOrLeader resolves the user addressed by :user_id together with the
+// leader of the team addressed by :team_id and the owner of its hackathon
+func TeamMemberSelfOrLeader(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	userID, err := paramID(c, "user_id")
+	if err != nil {
+		return nil, err
+	}
+	managers, err := TeamLeaderOrHackathonOwner(c, db)
+	if err != nil {
+		return nil, err
+	}
+	return append(managers, userID), nil
+}
+
+// TeamInvitee resolves the invited user of the team invitation addressed by :id
+func TeamInvitee(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var invitation database.TeamInvitation
+	if err := db.Select("id", "user_id").First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{invitation.UserID}, nil
+}
+
+// TeamInvitationSender resolves the leader of the team of the invitation addressed
+// by :id together with the owner of its hackathon
+func TeamInvitationSender(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var invitation database.TeamInvitation
+	if err := db.Select("id", "team_id").First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	var team database.Team
+	if err := db.Select("id", "hackathon_id", "leader_id").First(&team, invitation.TeamID).Error; err != nil {
+		return nil, err
+	}
+	return teamManagers(db, &team), nil
+}
+
+// TeamMembers resolves the active members of the team addressed by :team_id
+func TeamMembers(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	hackathonID, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	teamID, err := paramID(c, "team_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var team database.Team
+	if err := db.Select("id").Where("hackathon_id = ? AND id = ?", hackathonID, teamID).First(&team).Error; err != nil {
+		return nil, err
+	}
+	var members []uint
+	err = db.Model(&database.HackathonParticipant{}).
+		Where("team_id = ? AND status NOT IN ?", team.ID, inactiveParticipantStatuses).
+		Pluck("user_id", &members).Error
+	return members, err
+}
+
+// TeamMembersOrHackathonOwner resolves the active members of the team addressed by
+// :team_id together with the owner of its hackathon
+func TeamMembersOrHackathonOwner(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	members, err := TeamMembers(c, db)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := HackathonOwner(c, db)
+	if err != nil {
+		return nil, err
+	}
+	return append(members, owners...), nil
+}
+
+// teamManagers returns the leader of a team and the owner of its hackathon
+func teamManagers(db *gorm.DB, team *database.Team) []uint {
+	owners := []uint{team.LeaderID}
+	var hackathon database.Hackathon
+	if err := db.Select("id", "owner_id").First(&hackathon, team.HackathonID).Error; err == nil && hackathon.OwnerID != nil {
+		owners = append(owners, *hackathon.OwnerID)
+	}
+	return owners
+}
+
+// HackathonJudges resolves the judges assigned to the hackathon addressed by :id
+func HackathonJudges(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Select("id").First(&database.Hackathon{}, id).Error; err != nil {
+		return nil, err
+	}
+	var judges []uint
+	err = db.Model(&database.HackathonJudge{}).Where("hackathon_id = ?", id).Pluck("user_id", &judges).Error
+	return judges, err
+}
+
+// NotificationRecipient resolves the user the notification addressed by :id was sent to
+func NotificationRecipient(c *gin.Context, db *gorm.DB) ([]uint, error) {
+	id, err := paramID(c, "id")
+	if err != nil {
+		return nil, err
+	}
+	var notification database.Notification
+	if err := db.Select("id", "user_id").First(&notification, id).Error; err != nil {
+		return nil, err
+	}
+	return []uint{notification.UserID}, nil
+}
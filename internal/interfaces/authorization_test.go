@@ -0,0 +1,160 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// withUser injects a user into the context the way AuthMiddleware does
+func withUser(user *database.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user != nil {
+			SetCurrentUser(c, user)
+		}
+		c.Next()
+	}
+}
+
+func setupPolicyTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}, &database.Contest{}, &database.ContestApplication{}, &database.Matching{}))
+	return db
+}
+
+func TestPolicy_RequireOwner(t *testing.T) {
+	db := setupPolicyTestDB(t)
+	policy := NewPolicy(db)
+	contestHandler := NewContestHandler(db)
+
+	author := database.User{Name: "Author", Gmail: "author@example.com", Role: "user"}
+	other := database.User{Name: "Other", Gmail: "other@example.com", Role: "user"}
+	admin := database.User{Name: "Admin", Gmail: "admin@example.com", Role: "admin"}
+	db.Create(&author)
+	db.Create(&other)
+	db.Create(&admin)
+
+	contest := database.Contest{
+		ApplicationDeadline: time.Now().Add(24 * time.Hour),
+		Purpose:             "Purpose",
+		Message:             "Message",
+		AuthorID:            author.ID,
+	}
+	db.Create(&contest)
+
+	gin.SetMode(gin.TestMode)
+	newRouter := func(user *database.User) *gin.Engine {
+		r := gin.New()
+		r.PUT("/contests/:id", withUser(user), policy.RequireOwner("contest", ContestAuthor), contestHandler.UpdateContest)
+		return r
+	}
+
+	updateContest := func(user *database.User, id string) *httptest.ResponseRecorder {
+		purpose := "Updated"
+		body, _ := json.Marshal(UpdateContestRequest{Purpose: &purpose})
+		req, _ := http.NewRequest("PUT", "/contests/"+id, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		newRouter(user).ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Owner is allowed", func(t *testing.T) {
+		w := updateContest(&author, "1")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Other user is forbidden", func(t *testing.T) {
+		w := updateContest(&other, "1")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin bypasses ownership", func(t *testing.T) {
+		w := updateContest(&admin, "1")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unauthenticated is rejected", func(t *testing.T) {
+		w := updateContest(nil, "1")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Missing resource is not found", func(t *testing.T) {
+		w := updateContest(&other, "999")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestPolicy_MatchingParties(t *testing.T) {
+	db := setupPolicyTestDB(t)
+	policy := NewPolicy(db)
+
+	user1 := database.User{Name: "User 1", Gmail: "user1@example.com"}
+	user2 := database.User{Name: "User 2", Gmail: "user2@example.com"}
+	user3 := database.User{Name: "User 3", Gmail: "user3@example.com"}
+	db.Create(&user1)
+	db.Create(&user2)
+	db.Create(&user3)
+	db.Create(&database.Matching{User1ID: user1.ID, User2ID: user2.ID, Status: "pending"})
+
+	gin.SetMode(gin.TestMode)
+	for _, tc := range []struct {
+		name string
+		user *database.User
+		want int
+	}{
+		{"First party", &user1, http.StatusNoContent},
+		{"Second party", &user2, http.StatusNoContent},
+		{"Outsider", &user3, http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.PUT("/matchings/:id", withUser(tc.user), policy.RequireOwner("matching", MatchingParties), func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})
+
+			req, _ := http.NewRequest("PUT", "/matchings/1", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tc.want, w.Code)
+		})
+	}
+}
+
+func TestUnauthenticatedRequestsFailClosed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	owner := uint(2)
+	user := database.User{ID: 1, Role: "user"}
+	admin := database.User{ID: 3, Role: "admin"}
+	context := func(user *database.User) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		if user != nil {
+			SetCurrentUser(c, user)
+		}
+		return c
+	}
+
+	assert.False(t, canActAs(context(nil), 1))
+	assert.True(t, canActAs(context(&user), 1))
+	assert.False(t, canActAs(context(&user), 2))
+
+	assert.Equal(t, domain.Actor(""), transitionActor(context(nil), 1, &owner))
+	assert.Equal(t, domain.ActorSelf, transitionActor(context(&user), 1, &owner))
+	assert.Equal(t, domain.ActorAdmin, transitionActor(context(&admin), 1, &owner))
+	assert.Equal(t, domain.Actor(""), matchingActor(context(nil), &database.Matching{User1ID: 1, User2ID: 2}))
+
+	_, ok := resolveActingUserID(context(nil), 1)
+	assert.False(t, ok, "a user_id in the body is not trusted without authentication")
+}
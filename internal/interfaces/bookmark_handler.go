@@ -18,7 +18,7 @@ func NewBookmarkHandler(db *gorm.DB) *BookmarkHandler {
 }
 
 type CreateBookmarkRequest struct {
-	UserID           uint `json:"user_id" binding:"required"`
+	UserID           uint `json:"user_id"`
 	BookmarkedUserID uint `json:"bookmarked_user_id" binding:"required"`
 }
 
@@ -29,6 +29,12 @@ func (h *BookmarkHandler) CreateBookmark(c *gin.Context) {
 		return
 	}
 
+	userID, ok := resolveActingUserID(c, req.UserID)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
 	if req.UserID == req.BookmarkedUserID {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot bookmark yourself"})
 		return
@@ -111,6 +117,11 @@ func (h *BookmarkHandler) UpdateBookmark(c *gin.Context) {
 
 	// Update fields if provided
 	if req.UserID != nil {
+		if !canActAs(c, *req.UserID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Cannot transfer bookmark to another user"})
+			return
+		}
+
 		// Check if user exists
 		var user database.User
 		if err := h.db.First(&user, *req.UserID).Error; err != nil {
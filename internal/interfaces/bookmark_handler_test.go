@@ -40,7 +40,19 @@ func TestCreateBookmark(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	router.POST("/bookmarks", handler.CreateBookmark)
+	router.POST("/bookmarks", withUser(&user1), handler.CreateBookmark)
+	router.POST("/anonymous/bookmarks", handler.CreateBookmark)
+
+	t.Run("Unauthenticated requests are rejected", func(t *testing.T) {
+		jsonBody, _ := json.Marshal(CreateBookmarkRequest{UserID: user1.ID, BookmarkedUserID: user2.ID})
+		req, _ := http.NewRequest("POST", "/anonymous/bookmarks", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
 
 	t.Run("Valid bookmark creation", func(t *testing.T) {
 		reqBody := CreateBookmarkRequest{
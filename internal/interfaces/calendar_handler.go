@@ -0,0 +1,272 @@
+package interfaces
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// deadlineReminder is how long before a deadline calendar clients remind users
+const deadlineReminder = 24 * time.Hour
+
+// calendarFeedPath is the route prefix of personal calendar feeds
+const calendarFeedPath = "/api/v1/calendar/feeds/"
+
+// CalendarHandler handles HTTP requests for iCalendar exports of hackathons and contests
+type CalendarHandler struct {
+	*BaseHandler
+	baseURL string
+}
+
+// NewCalendarHandler creates a new CalendarHandler linking events under baseURL
+func NewCalendarHandler(db *gorm.DB, baseURL string) *CalendarHandler {
+	return &CalendarHandler{
+		BaseHandler: NewBaseHandler(db),
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// GetHackathonCalendar handles GET /api/v1/hackathons/:id/calendar.ics
+// Exports the event span, the registration window and the submission deadline.
+func (h *CalendarHandler) GetHackathonCalendar(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var hackathon database.Hackathon
+	if err := h.db.First(&hackathon, id).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+
+	h.respondCalendar(c, fmt.Sprintf("hackathon-%d.ics", id), hackathon.Name, h.hackathonEvents(&hackathon))
+}
+
+// GetContestCalendar handles GET /api/v1/contests/:id/calendar.ics
+// Exports the application deadline and, when scheduled, the contest itself.
+func (h *CalendarHandler) GetContestCalendar(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var contest database.Contest
+	if err := h.db.First(&contest, id).Error; err != nil {
+		h.HandleDBError(c, err, "Contest")
+		return
+	}
+
+	h.respondCalendar(c, fmt.Sprintf("contest-%d.ics", id), contestTitle(&contest), h.contestEvents(&contest))
+}
+
+// GetCalendarFeed handles GET /api/v1/users/:id/calendar-feed
+// Returns the subscription URL of the user's calendar feed.
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	user, ok := h.lookupUser(c)
+	if !ok {
+		return
+	}
+	if user.CalendarToken == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed is not enabled"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": h.feedURL(*user.CalendarToken)})
+}
+
+// CreateCalendarFeed handles POST /api/v1/users/:id/calendar-feed
+// Enables the user's calendar feed under a new secret URL, replacing any previous one.
+func (h *CalendarHandler) CreateCalendarFeed(c *gin.Context) {
+	user, ok := h.lookupUser(c)
+	if !ok {
+		return
+	}
+	token, err := newCalendarToken()
+	if err == nil {
+		err = h.db.Model(user).Update("calendar_token", token).Error
+	}
+	if err != nil {
+		log.Printf("Failed to create calendar feed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create calendar feed"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"url": h.feedURL(token)})
+}
+
+// DeleteCalendarFeed handles DELETE /api/v1/users/:id/calendar-feed
+// Disables the user's calendar feed; subscribed clients stop receiving updates.
+func (h *CalendarHandler) DeleteCalendarFeed(c *gin.Context) {
+	user, ok := h.lookupUser(c)
+	if !ok {
+		return
+	}
+	if err := h.db.Model(user).Update("calendar_token", nil).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable calendar feed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed disabled successfully"})
+}
+
+// GetUserCalendar handles GET /api/v1/calendar/feeds/:token
+// Serves a user's personal feed without authentication; the secret token in the
+// URL identifies the user. It lists the hackathons the user registered for or
+// organizes, the contests they wrote and the contests of users they bookmarked.
+func (h *CalendarHandler) GetUserCalendar(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	var user database.User
+	if err := h.db.Where("calendar_token = ?", token).First(&user).Error; err != nil {
+		h.HandleDBError(c, err, "Calendar feed")
+		return
+	}
+
+	var hackathons []database.Hackathon
+	err := h.db.Where("id IN (?)", h.db.Model(&database.HackathonParticipant{}).
+		Select("hackathon_id").
+		Where("user_id = ? AND status NOT IN ?", user.ID, []string{domain.ParticipantCancelled, domain.ParticipantDisqualified})).
+		Or("owner_id = ?", user.ID).
+		Find(&hackathons).Error
+	var contests []database.Contest
+	if err == nil {
+		err = h.db.Where("author_id = ?", user.ID).
+			Or("author_id IN (?)", h.db.Model(&database.Bookmark{}).Select("bookmarked_user_id").Where("user_id = ?", user.ID)).
+			Find(&contests).Error
+	}
+	if err != nil {
+		log.Printf("Failed to build calendar feed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build calendar feed"})
+		return
+	}
+
+	var events []calendarEvent
+	for i := range hackathons {
+		events = append(events, h.hackathonEvents(&hackathons[i])...)
+	}
+	for i := range contests {
+		events = append(events, h.contestEvents(&contests[i])...)
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].start.Before(events[j].start) })
+
+	h.respondCalendar(c, "calendar.ics", user.Name+" - TRu-S3", events)
+}
+
+// hackathonEvents returns the calendar events of a hackathon
+func (h *CalendarHandler) hackathonEvents(hackathon *database.Hackathon) []calendarEvent {
+	url := fmt.Sprintf("%s/api/v1/hackathons/%d", h.baseURL, hackathon.ID)
+	cancelled := hackathon.Status == domain.HackathonStatusCancelled
+	events := []calendarEvent{
+		{
+			uid:         calendarUID("hackathon", hackathon.ID, ""),
+			summary:     hackathon.Name,
+			description: hackathon.Description,
+			location:    hackathon.Location,
+			url:         url,
+			start:       hackathon.StartDate,
+			end:         hackathon.EndDate,
+			cancelled:   cancelled,
+			modified:    hackathon.UpdatedAt,
+		},
+		{
+			uid:       calendarUID("hackathon", hackathon.ID, "registration"),
+			summary:   "Registration: " + hackathon.Name,
+			url:       url,
+			start:     hackathon.RegistrationStart,
+			end:       hackathon.RegistrationDeadline,
+			cancelled: cancelled,
+			reminder:  deadlineReminder,
+			modified:  hackathon.UpdatedAt,
+		},
+	}
+	if hackathon.SubmissionDeadline != nil {
+		events = append(events, calendarEvent{
+			uid:       calendarUID("hackathon", hackathon.ID, "submission"),
+			summary:   "Submission deadline: " + hackathon.Name,
+			url:       url,
+			start:     *hackathon.SubmissionDeadline,
+			cancelled: cancelled,
+			reminder:  deadlineReminder,
+			modified:  hackathon.UpdatedAt,
+		})
+	}
+	return events
+}
+
+// contestEvents returns the calendar events of a contest
+func (h *CalendarHandler) contestEvents(contest *database.Contest) []calendarEvent {
+	url := fmt.Sprintf("%s/api/v1/contests/%d", h.baseURL, contest.ID)
+	title := contestTitle(contest)
+	events := []calendarEvent{{
+		uid:      calendarUID("contest", contest.ID, "deadline"),
+		summary:  "Application deadline: " + title,
+		url:      url,
+		start:    contest.ApplicationDeadline,
+		reminder: deadlineReminder,
+		modified: contest.UpdatedAt,
+	}}
+	if !contest.StartTime.IsZero() && contest.EndTime.After(contest.StartTime) {
+		events = append(events, calendarEvent{
+			uid:         calendarUID("contest", contest.ID, ""),
+			summary:     title,
+			description: contest.Description,
+			url:         url,
+			start:       contest.StartTime,
+			end:         contest.EndTime,
+			modified:    contest.UpdatedAt,
+		})
+	}
+	return events
+}
+
+// respondCalendar writes events as an iCalendar file
+func (h *CalendarHandler) respondCalendar(c *gin.Context, filename, name string, events []calendarEvent) {
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": filename}))
+	c.Status(http.StatusOK)
+	if err := writeICalendar(c.Writer, name, events); err != nil {
+		log.Printf("Failed to write calendar: %v", err)
+	}
+}
+
+// lookupUser loads the user addressed by :id
+func (h *CalendarHandler) lookupUser(c *gin.Context) (*database.User, bool) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+	var user database.User
+	if err := h.db.First(&user, id).Error; err != nil {
+		h.HandleDBError(c, err, "User")
+		return nil, false
+	}
+	return &user, true
+}
+
+// feedURL returns the subscription URL of the calendar feed with token
+func (h *CalendarHandler) feedURL(token string) string {
+	return h.baseURL + calendarFeedPath + token + ".ics"
+}
+
+// contestTitle returns the title of a contest, which is optional
+func contestTitle(contest *database.Contest) string {
+	if contest.Title != "" {
+		return contest.Title
+	}
+	return fmt.Sprintf("Contest #%d", contest.ID)
+}
+
+// newCalendarToken generates the secret of a calendar feed URL
+func newCalendarToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
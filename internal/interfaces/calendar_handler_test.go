@@ -0,0 +1,114 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarHandler(t *testing.T) {
+	env := setupTeamTest(t, 3)
+	require.NoError(t, env.db.AutoMigrate(&database.Contest{}, &database.Bookmark{}))
+	subscriber, followed, stranger := &env.users[0], &env.users[1], &env.users[2]
+
+	deadline := env.hackathon.EndDate.Add(-time.Hour)
+	env.db.Model(&env.hackathon).Updates(map[string]interface{}{
+		"location":            "Tokyo, Japan",
+		"description":         strings.Repeat("Build something great; ", 10),
+		"submission_deadline": deadline,
+	})
+	contest := func(author *database.User, title string) database.Contest {
+		c := database.Contest{Title: title, AuthorID: author.ID, Purpose: "p", Message: "m", ApplicationDeadline: time.Now().Add(24 * time.Hour)}
+		require.NoError(t, env.db.Create(&c).Error)
+		return c
+	}
+	followedContest := contest(followed, "Followed contest")
+	contest(stranger, "Stranger contest")
+	require.NoError(t, env.db.Create(&database.Bookmark{UserID: subscriber.ID, BookmarkedUserID: followed.ID}).Error)
+	env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", stranger.ID).Update("status", "cancelled")
+
+	handler := NewCalendarHandler(env.db, "https://api.example.com/")
+	request := func(user *database.User, method, path string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.GET("/api/v1/hackathons/:id/calendar.ics", handler.GetHackathonCalendar)
+		r.GET("/api/v1/contests/:id/calendar.ics", handler.GetContestCalendar)
+		r.GET("/api/v1/users/:id/calendar-feed", env.policy.RequireOwner("user", UserSelf), handler.GetCalendarFeed)
+		r.POST("/api/v1/users/:id/calendar-feed", env.policy.RequireOwner("user", UserSelf), handler.CreateCalendarFeed)
+		r.DELETE("/api/v1/users/:id/calendar-feed", env.policy.RequireOwner("user", UserSelf), handler.DeleteCalendarFeed)
+		r.GET("/api/v1/calendar/feeds/:token", handler.GetUserCalendar)
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	hackathonUID := fmt.Sprintf("UID:hackathon-%d@tru-s3-backend", env.hackathon.ID)
+
+	t.Run("Hackathon calendar", func(t *testing.T) {
+		w := request(nil, "GET", fmt.Sprintf("/api/v1/hackathons/%d/calendar.ics", env.hackathon.ID))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+
+		body := w.Body.String()
+		assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(body, "END:VCALENDAR\r\n"))
+		assert.Contains(t, body, hackathonUID+"\r\n")
+		assert.Contains(t, body, fmt.Sprintf("UID:hackathon-%d-registration@tru-s3-backend\r\n", env.hackathon.ID))
+		assert.Contains(t, body, "DTSTART:"+deadline.UTC().Format("20060102T150405Z")+"\r\n")
+		assert.Contains(t, body, "TRIGGER;RELATED=END:-P1D\r\n")
+		assert.Contains(t, body, `LOCATION:Tokyo\, Japan`)
+		for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75, line)
+		}
+		unfolded := strings.ReplaceAll(body, "\r\n ", "")
+		assert.Contains(t, unfolded, `DESCRIPTION:`+strings.Repeat(`Build something great\; `, 10))
+	})
+
+	t.Run("Contest deadline calendar", func(t *testing.T) {
+		w := request(nil, "GET", fmt.Sprintf("/api/v1/contests/%d/calendar.ics", followedContest.ID))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), fmt.Sprintf("UID:contest-%d-deadline@tru-s3-backend\r\n", followedContest.ID))
+		assert.Contains(t, w.Body.String(), "SUMMARY:Application deadline: Followed contest\r\n")
+		assert.Contains(t, w.Body.String(), "TRIGGER:-P1D\r\n")
+	})
+
+	t.Run("Personal feed", func(t *testing.T) {
+		feedPath := fmt.Sprintf("/api/v1/users/%d/calendar-feed", subscriber.ID)
+		assert.Equal(t, http.StatusForbidden, request(stranger, "POST", feedPath).Code)
+		assert.Equal(t, http.StatusNotFound, request(subscriber, "GET", feedPath).Code)
+
+		w := request(subscriber, "POST", feedPath)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var feed struct {
+			URL string `json:"url"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+		require.True(t, strings.HasPrefix(feed.URL, "https://api.example.com/api/v1/calendar/feeds/"))
+		path := strings.TrimPrefix(feed.URL, "https://api.example.com")
+
+		w = request(nil, "GET", path)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), hackathonUID+"\r\n")
+		assert.Contains(t, w.Body.String(), "Followed contest")
+		assert.NotContains(t, w.Body.String(), "Stranger contest")
+
+		strangerFeed := request(stranger, "POST", fmt.Sprintf("/api/v1/users/%d/calendar-feed", stranger.ID))
+		require.NoError(t, json.Unmarshal(strangerFeed.Body.Bytes(), &feed))
+		w = request(nil, "GET", strings.TrimPrefix(feed.URL, "https://api.example.com"))
+		assert.NotContains(t, w.Body.String(), hackathonUID, "cancelled registrations are left out")
+
+		require.Equal(t, http.StatusCreated, request(subscriber, "POST", feedPath).Code)
+		assert.Equal(t, http.StatusNotFound, request(nil, "GET", path).Code, "rotating revokes the old URL")
+		require.Equal(t, http.StatusOK, request(subscriber, "DELETE", feedPath).Code)
+		assert.Equal(t, http.StatusNotFound, request(subscriber, "GET", feedPath).Code)
+	})
+}
@@ -0,0 +1,278 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
+)
+
+// Check-in QR code sizes in pixels
+const (
+	defaultCheckInQRSize = 256
+	minCheckInQRSize     = 128
+	maxCheckInQRSize     = 1024
+)
+
+var (
+	errNotOnSite        = errors.New("hackathon is not held on site")
+	errCheckInClosed    = errors.New("hackathon is over or cancelled")
+	errNoSeat           = errors.New("participant does not hold a seat")
+	errForeignCheckIn   = errors.New("check-in token is for another hackathon")
+	errAlreadyCheckedIn = errors.New("participant is already checked in")
+)
+
+// CheckInHandler handles HTTP requests for on-site check-in of hackathon participants
+type CheckInHandler struct {
+	*BaseHandler
+	signer domain.CheckInTokenSigner
+}
+
+// NewCheckInHandler creates a new CheckInHandler issuing tokens with signer
+func NewCheckInHandler(db *gorm.DB, signer domain.CheckInTokenSigner) *CheckInHandler {
+	return &CheckInHandler{
+		BaseHandler: NewBaseHandler(db),
+		signer:      signer,
+	}
+}
+
+type CheckInRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// AttendanceEntry is one participant in the attendance report
+type AttendanceEntry struct {
+	ParticipantID uint       `json:"participant_id"`
+	UserID        uint       `json:"user_id"`
+	Name          string     `json:"name"`
+	TeamID        *uint      `json:"team_id"`
+	Status        string     `json:"status"`
+	CheckedInAt   *time.Time `json:"checked_in_at"`
+}
+
+// GetCheckInQRCode handles GET /api/v1/hackathons/:id/participants/:participant_id/check-in/qr
+// Renders the participant's signed check-in token as a QR code PNG. The optional
+// size query parameter sets the width in pixels.
+func (h *CheckInHandler) GetCheckInQRCode(c *gin.Context) {
+	size := defaultCheckInQRSize
+	if raw := c.Query("size"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < minCheckInQRSize || parsed > maxCheckInQRSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "size must be between 128 and 1024"})
+			return
+		}
+		size = parsed
+	}
+
+	participant, hackathon, ok := h.lookupParticipant(c)
+	if !ok {
+		return
+	}
+	if err := checkInAvailable(hackathon); err != nil {
+		handleCheckInError(c, err, "Failed to generate check-in code")
+		return
+	}
+	if !holdsSeat(participant.Status) {
+		handleCheckInError(c, errNoSeat, "Failed to generate check-in code")
+		return
+	}
+
+	token := h.signer.Sign(domain.CheckInClaims{HackathonID: hackathon.ID, ParticipantID: participant.ID})
+	png, err := qrcode.Encode(token, qrcode.Medium, size)
+	if err != nil {
+		handleCheckInError(c, err, "Failed to generate check-in code")
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// CheckIn handles POST /api/v1/hackathons/:id/check-in
+// Verifies a scanned check-in token and moves its participant to checked_in.
+func (h *CheckInHandler) CheckIn(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req CheckInRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	claims, err := h.signer.Verify(req.Token)
+	if err != nil {
+		handleCheckInError(c, err, "Failed to check in")
+		return
+	}
+	if claims.HackathonID != hackathonID {
+		handleCheckInError(c, errForeignCheckIn, "Failed to check in")
+		return
+	}
+
+	var participant database.HackathonParticipant
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var hackathon database.Hackathon
+		if err := tx.Select("id", "owner_id", "status", "location").First(&hackathon, hackathonID).Error; err != nil {
+			return err
+		}
+		if err := checkInAvailable(&hackathon); err != nil {
+			return err
+		}
+		if err := tx.Where("hackathon_id = ? AND id = ?", hackathonID, claims.ParticipantID).First(&participant).Error; err != nil {
+			return err
+		}
+		if participant.Status == domain.ParticipantCheckedIn {
+			return errAlreadyCheckedIn
+		}
+
+		previousStatus := participant.Status
+		participant.Status = domain.ParticipantCheckedIn
+		actor := transitionActor(c, participant.UserID, hackathon.OwnerID)
+		change := participantChange{tx: tx, participant: &participant}
+		return participantStatuses.Apply(change, previousStatus, participant.Status, actor, func() error {
+			return tx.Save(&participant).Error
+		})
+	})
+	if errors.Is(err, errAlreadyCheckedIn) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":         "Participant is already checked in",
+			"checked_in_at": participant.CheckedInAt,
+		})
+		return
+	}
+	if err != nil {
+		if respondTransitionError(c, err) {
+			return
+		}
+		handleCheckInError(c, err, "Failed to check in")
+		return
+	}
+
+	h.db.Preload("User").First(&participant, participant.ID)
+	c.JSON(http.StatusOK, participant)
+}
+
+// GetAttendance handles GET /api/v1/hackathons/:id/attendance
+// Reports which seat-holding participants have checked in.
+func (h *CheckInHandler) GetAttendance(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.db.Select("id").First(&database.Hackathon{}, hackathonID).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+
+	var participants []database.HackathonParticipant
+	err := h.db.Preload("User").
+		Where("hackathon_id = ? AND status IN ?", hackathonID, seatHoldingStatuses).
+		Order("id").
+		Find(&participants).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve attendance"})
+		return
+	}
+
+	// Checked-in participants first in check-in order, then everyone still expected
+	sort.SliceStable(participants, func(i, j int) bool {
+		a, b := participants[i].CheckedInAt, participants[j].CheckedInAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+
+	entries := make([]AttendanceEntry, len(participants))
+	checkedIn := 0
+	for i, p := range participants {
+		entries[i] = AttendanceEntry{ParticipantID: p.ID, UserID: p.UserID, TeamID: p.TeamID, Status: p.Status, CheckedInAt: p.CheckedInAt}
+		if p.User != nil {
+			entries[i].Name = p.User.Name
+		}
+		if p.CheckedInAt != nil {
+			checkedIn++
+		}
+	}
+	rate := 0.0
+	if len(entries) > 0 {
+		rate = float64(checkedIn) / float64(len(entries))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hackathon_id":    hackathonID,
+		"expected":        len(entries),
+		"checked_in":      checkedIn,
+		"not_checked_in":  len(entries) - checkedIn,
+		"attendance_rate": rate,
+		"participants":    entries,
+	})
+}
+
+// lookupParticipant loads the participant addressed by :participant_id within the
+// hackathon addressed by :id, together with the hackathon
+func (h *CheckInHandler) lookupParticipant(c *gin.Context) (*database.HackathonParticipant, *database.Hackathon, bool) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return nil, nil, false
+	}
+	participantID, ok := h.ParseIDParam(c, "participant_id")
+	if !ok {
+		return nil, nil, false
+	}
+
+	var hackathon database.Hackathon
+	if err := h.db.Select("id", "status", "location").First(&hackathon, hackathonID).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return nil, nil, false
+	}
+	var participant database.HackathonParticipant
+	if err := h.db.Where("hackathon_id = ? AND id = ?", hackathonID, participantID).First(&participant).Error; err != nil {
+		h.HandleDBError(c, err, "Participant")
+		return nil, nil, false
+	}
+	return &participant, &hackathon, true
+}
+
+// checkInAvailable returns an error unless participants can check in to the hackathon
+func checkInAvailable(hackathon *database.Hackathon) error {
+	if !domain.IsOnSite(hackathon.Location) {
+		return errNotOnSite
+	}
+	if hackathon.Status == domain.HackathonStatusCompleted || hackathon.Status == domain.HackathonStatusCancelled {
+		return errCheckInClosed
+	}
+	return nil
+}
+
+// handleCheckInError maps check-in errors to HTTP responses
+func handleCheckInError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+	case errors.Is(err, domain.ErrInvalidToken):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid check-in token"})
+	case errors.Is(err, errForeignCheckIn):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Check-in token is for another hackathon"})
+	case errors.Is(err, errNotOnSite):
+		c.JSON(http.StatusConflict, gin.H{"error": "Check-in is only available for on-site hackathons"})
+	case errors.Is(err, errCheckInClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "Hackathon is over or cancelled"})
+	case errors.Is(err, errNoSeat):
+		c.JSON(http.StatusConflict, gin.H{"error": "Only participants holding a seat can check in"})
+	case errors.Is(err, errHackathonFull):
+		c.JSON(http.StatusConflict, gin.H{"error": "Hackathon is full"})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
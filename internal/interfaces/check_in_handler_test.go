@@ -0,0 +1,102 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInHandler(t *testing.T) {
+	env := setupTeamTest(t, 3)
+	organizer, attendee, other := &env.users[0], &env.users[1], &env.users[2]
+	env.db.Model(&env.hackathon).Updates(map[string]interface{}{"owner_id": organizer.ID, "location": "Tokyo"})
+
+	var participants []database.HackathonParticipant
+	require.NoError(t, env.db.Order("id").Find(&participants).Error)
+	attendeeSeat, otherSeat := participants[1], participants[2]
+
+	signer := infrastructure.NewHMACCheckInSigner([]byte("test-secret"))
+	handler := NewCheckInHandler(env.db, signer)
+	request := func(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.GET("/hackathons/:id/participants/:participant_id/check-in/qr", env.policy.RequireOwner("participant", ParticipantOrHackathonOwner), handler.GetCheckInQRCode)
+		r.POST("/hackathons/:id/check-in", env.policy.RequireOwner("hackathon", HackathonOwner), handler.CheckIn)
+		r.GET("/hackathons/:id/attendance", env.policy.RequireOwner("hackathon", HackathonOwner), handler.GetAttendance)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	base := fmt.Sprintf("/hackathons/%d", env.hackathon.ID)
+	qrPath := fmt.Sprintf("%s/participants/%d/check-in/qr", base, attendeeSeat.ID)
+	token := func(p database.HackathonParticipant) CheckInRequest {
+		return CheckInRequest{Token: signer.Sign(domain.CheckInClaims{HackathonID: p.HackathonID, ParticipantID: p.ID})}
+	}
+
+	t.Run("Participants get their QR code", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(other, "GET", qrPath, nil).Code)
+		assert.Equal(t, http.StatusBadRequest, request(attendee, "GET", qrPath+"?size=50", nil).Code)
+
+		w := request(attendee, "GET", qrPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("\x89PNG")))
+
+		env.db.Model(&env.hackathon).Update("location", "Online")
+		assert.Equal(t, http.StatusConflict, request(attendee, "GET", qrPath, nil).Code)
+		env.db.Model(&env.hackathon).Update("location", "Tokyo")
+	})
+
+	t.Run("Staff scan check-in codes", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(attendee, "POST", base+"/check-in", token(attendeeSeat)).Code)
+		assert.Equal(t, http.StatusBadRequest, request(organizer, "POST", base+"/check-in", CheckInRequest{Token: "ci1.1.2.forged"}).Code)
+		foreign := CheckInRequest{Token: signer.Sign(domain.CheckInClaims{HackathonID: env.hackathon.ID + 1, ParticipantID: attendeeSeat.ID})}
+		assert.Equal(t, http.StatusBadRequest, request(organizer, "POST", base+"/check-in", foreign).Code)
+
+		w := request(organizer, "POST", base+"/check-in", token(attendeeSeat))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var participant database.HackathonParticipant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &participant))
+		assert.Equal(t, domain.ParticipantCheckedIn, participant.Status)
+		require.NotNil(t, participant.CheckedInAt)
+
+		w = request(organizer, "POST", base+"/check-in", token(attendeeSeat))
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "checked_in_at")
+	})
+
+	t.Run("Cancelled participants cannot check in", func(t *testing.T) {
+		env.db.Model(&otherSeat).Update("status", domain.ParticipantCancelled)
+		assert.Equal(t, http.StatusConflict, request(organizer, "POST", base+"/check-in", token(otherSeat)).Code)
+	})
+
+	t.Run("Attendance report", func(t *testing.T) {
+		w := request(organizer, "GET", base+"/attendance", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var report struct {
+			Expected     int               `json:"expected"`
+			CheckedIn    int               `json:"checked_in"`
+			Participants []AttendanceEntry `json:"participants"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 2, report.Expected)
+		assert.Equal(t, 1, report.CheckedIn)
+		require.Len(t, report.Participants, 2)
+		assert.Equal(t, attendeeSeat.ID, report.Participants[0].ParticipantID, "checked-in participants come first")
+		assert.NotNil(t, report.Participants[0].CheckedInAt)
+		assert.Nil(t, report.Participants[1].CheckedInAt)
+	})
+}
@@ -0,0 +1,389 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	contestDB "github.com/TRu-S3/backend/internal/database/contest"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxApplicationMessageLength is the longest message an applicant can send
+const maxApplicationMessageLength = 2000
+
+// activeApplicationStatuses are the application states a user holds at most one of per contest
+var activeApplicationStatuses = []string{domain.ApplicationPending, domain.ApplicationAccepted}
+
+var (
+	errInvalidRole        = errors.New("invalid contest role")
+	errInvalidApplication = errors.New("invalid contest application")
+	errRoleNotRecruited   = errors.New("contest does not recruit the role")
+	errRoleFull           = errors.New("role quota is filled")
+	errApplicationsClosed = errors.New("contest no longer takes applications")
+	errAlreadyApplied     = errors.New("user already has an active application for the contest")
+	errOwnContest         = errors.New("authors cannot apply to their own contest")
+	errQuotaBelowAccepted = errors.New("quota is below the accepted applications")
+)
+
+// ContestApplicationHandler handles HTTP requests for applications to contest roles
+type ContestApplicationHandler struct {
+	*BaseHandler
+}
+
+// NewContestApplicationHandler creates a new ContestApplicationHandler
+func NewContestApplicationHandler(db *gorm.DB) *ContestApplicationHandler {
+	return &ContestApplicationHandler{
+		BaseHandler: NewBaseHandler(db),
+	}
+}
+
+type ApplyToContestRequest struct {
+	Role    string `json:"role" binding:"required"`
+	Message string `json:"message" binding:"required"`
+}
+
+// Apply handles POST /api/v1/contests/:id/applications
+// Applications are taken until the application deadline while the role has free slots.
+func (h *ContestApplicationHandler) Apply(c *gin.Context) {
+	contestID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	var req ApplyToContestRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	application := database.ContestApplication{
+		ContestID: contestID,
+		UserID:    user.ID,
+		Role:      strings.ToLower(strings.TrimSpace(req.Role)),
+		Message:   strings.TrimSpace(req.Message),
+		Status:    domain.ApplicationPending,
+	}
+	err := validateApplication(&application)
+	if err == nil {
+		err = h.db.Transaction(func(tx *gorm.DB) error {
+			contest, err := lockContest(tx, contestID)
+			if err != nil {
+				return err
+			}
+			switch {
+			case contest.AuthorID == user.ID:
+				return errOwnContest
+			case !domain.ApplicationsOpen(contest.ApplicationDeadline, time.Now()):
+				return errApplicationsClosed
+			case contest.Quota(application.Role) == 0:
+				return errRoleNotRecruited
+			}
+
+			var active int64
+			err = tx.Model(&database.ContestApplication{}).
+				Where("contest_id = ? AND user_id = ? AND status IN ?", contestID, user.ID, activeApplicationStatuses).
+				Count(&active).Error
+			if err != nil {
+				return err
+			}
+			if active > 0 {
+				return errAlreadyApplied
+			}
+
+			change := applicationChange{tx: tx, contest: contest, application: &application}
+			return applicationStatuses.Apply(change, domain.StatusNone, domain.ApplicationPending, domain.ActorSelf, func() error {
+				return tx.Create(&application).Error
+			})
+		})
+	}
+	if err != nil {
+		if respondTransitionError(c, err) {
+			return
+		}
+		handleApplicationError(c, err, "Failed to create application")
+		return
+	}
+
+	c.JSON(http.StatusCreated, application)
+}
+
+// ListContestApplications handles GET /api/v1/contests/:id/applications
+// Optional status and role query parameters filter the applications.
+func (h *ContestApplicationHandler) ListContestApplications(c *gin.Context) {
+	contestID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	query := h.db.Where("contest_id = ?", contestID)
+	if status := c.Query("status"); status != "" {
+		if !applicationStatuses.IsStatus(status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: " + strings.Join(domain.ApplicationStatuses, ", ")})
+			return
+		}
+		query = query.Where("status = ?", status)
+	}
+	if role := c.Query("role"); role != "" {
+		if !isContestRole(role) {
+			handleApplicationError(c, errInvalidRole, "Invalid role")
+			return
+		}
+		query = query.Where("role = ?", role)
+	}
+
+	var applications []database.ContestApplication
+	if err := query.Preload("User").Order("created_at").Find(&applications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve applications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"applications": applications,
+		"count":        len(applications),
+	})
+}
+
+// ListMyApplications handles GET /api/v1/contest-applications
+// Lists the current user's applications, optionally filtered by status.
+func (h *ContestApplicationHandler) ListMyApplications(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	query := h.db.Where("user_id = ?", user.ID)
+	if status := c.Query("status"); status != "" {
+		if !applicationStatuses.IsStatus(status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: " + strings.Join(domain.ApplicationStatuses, ", ")})
+			return
+		}
+		query = query.Where("status = ?", status)
+	}
+	var applications []database.ContestApplication
+	if err := query.Preload("Contest").Order("created_at DESC").Find(&applications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve applications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"applications": applications,
+		"count":        len(applications),
+	})
+}
+
+// AcceptApplication handles POST /api/v1/contest-applications/:id/accept
+func (h *ContestApplicationHandler) AcceptApplication(c *gin.Context) {
+	h.changeStatus(c, domain.ApplicationAccepted)
+}
+
+// RejectApplication handles POST /api/v1/contest-applications/:id/reject
+func (h *ContestApplicationHandler) RejectApplication(c *gin.Context) {
+	h.changeStatus(c, domain.ApplicationRejected)
+}
+
+// WithdrawApplication handles POST /api/v1/contest-applications/:id/withdraw
+func (h *ContestApplicationHandler) WithdrawApplication(c *gin.Context) {
+	h.changeStatus(c, domain.ApplicationWithdrawn)
+}
+
+// changeStatus moves the application addressed by :id to status. Decisions stay
+// possible after the application deadline.
+func (h *ContestApplicationHandler) changeStatus(c *gin.Context, status string) {
+	applicationID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var application database.ContestApplication
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the contest before the application, in the same order as Apply
+		// and claimContestSlot, so that concurrent decisions cannot deadlock
+		var contestID uint
+		if err := tx.Model(&database.ContestApplication{}).Where("id = ?", applicationID).Select("contest_id").Scan(&contestID).Error; err != nil {
+			return err
+		}
+		if contestID == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		contest, err := lockContest(tx, contestID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&application, applicationID).Error; err != nil {
+			return err
+		}
+
+		from := application.Status
+		application.Status = status
+		actor := transitionActor(c, application.UserID, &contest.AuthorID)
+		change := applicationChange{tx: tx, contest: contest, application: &application}
+		return applicationStatuses.Apply(change, from, status, actor, func() error {
+			return tx.Save(&application).Error
+		})
+	})
+	if err != nil {
+		if respondTransitionError(c, err) {
+			return
+		}
+		handleApplicationError(c, err, "Failed to update application")
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+// validateApplication checks the role and message of a new application
+func validateApplication(application *database.ContestApplication) error {
+	if !isContestRole(application.Role) {
+		return errInvalidRole
+	}
+	if application.Message == "" || len(application.Message) > maxApplicationMessageLength {
+		return errInvalidApplication
+	}
+	return nil
+}
+
+// isContestRole reports whether role is one of the contest roles
+func isContestRole(role string) bool {
+	for _, r := range contestDB.Roles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// lockContest loads the contest and locks it for the rest of the transaction,
+// serializing decisions that count against its quotas
+func lockContest(tx *gorm.DB, contestID uint) (*database.Contest, error) {
+	var contest database.Contest
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&contest, contestID).Error; err != nil {
+		return nil, err
+	}
+	return &contest, nil
+}
+
+// claimContestSlot returns errRoleFull unless the role of the contest has room
+// for another accepted application
+func claimContestSlot(tx *gorm.DB, contestID uint, role string) error {
+	contest, err := lockContest(tx, contestID)
+	if err != nil {
+		return err
+	}
+	var accepted int64
+	err = tx.Model(&database.ContestApplication{}).
+		Where("contest_id = ? AND role = ? AND status = ?", contestID, role, domain.ApplicationAccepted).
+		Count(&accepted).Error
+	if err != nil {
+		return err
+	}
+	if domain.RemainingSlots(contest.Quota(role), accepted) == 0 {
+		return errRoleFull
+	}
+	return nil
+}
+
+// acceptedApplicationCounts returns the number of accepted applications of the
+// contests per contest and role
+func acceptedApplicationCounts(db *gorm.DB, contestIDs []uint) (map[uint]map[string]int64, error) {
+	var rows []struct {
+		ContestID uint
+		Role      string
+		Count     int64
+	}
+	err := db.Model(&database.ContestApplication{}).
+		Select("contest_id, role, COUNT(*) AS count").
+		Where("contest_id IN ? AND status = ?", contestIDs, domain.ApplicationAccepted).
+		Group("contest_id, role").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]map[string]int64, len(contestIDs))
+	for _, row := range rows {
+		if counts[row.ContestID] == nil {
+			counts[row.ContestID] = make(map[string]int64)
+		}
+		counts[row.ContestID][row.Role] = row.Count
+	}
+	return counts, nil
+}
+
+// fillContestSlots sets whether the contests take applications and how many
+// slots each of their roles has left
+func fillContestSlots(db *gorm.DB, contests []database.Contest) error {
+	if len(contests) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(contests))
+	for i := range contests {
+		ids[i] = contests[i].ID
+	}
+	counts, err := acceptedApplicationCounts(db, ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range contests {
+		contest := &contests[i]
+		contest.ApplicationsOpen = domain.ApplicationsOpen(contest.ApplicationDeadline, now)
+		contest.RemainingSlots = make(map[string]int, len(contestDB.Roles))
+		for _, role := range contestDB.Roles {
+			contest.RemainingSlots[role] = domain.RemainingSlots(contest.Quota(role), counts[contest.ID][role])
+		}
+	}
+	return nil
+}
+
+// checkQuotasCoverAccepted returns errQuotaBelowAccepted when a quota of the
+// contest is lower than the applications already accepted for its role
+func checkQuotasCoverAccepted(tx *gorm.DB, contest *database.Contest) error {
+	counts, err := acceptedApplicationCounts(tx, []uint{contest.ID})
+	if err != nil {
+		return err
+	}
+	for role, accepted := range counts[contest.ID] {
+		if int64(contest.Quota(role)) < accepted {
+			return errQuotaBelowAccepted
+		}
+	}
+	return nil
+}
+
+// handleApplicationError writes the response for an application error
+func handleApplicationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, errInvalidRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Must be one of: " + strings.Join(contestDB.Roles, ", ")})
+	case errors.Is(err, errInvalidApplication):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Applications need a message of up to 2000 characters"})
+	case errors.Is(err, errRoleNotRecruited):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "This contest does not recruit the role"})
+	case errors.Is(err, errOwnContest):
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot apply to your own contest"})
+	case errors.Is(err, errApplicationsClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "The application deadline has passed"})
+	case errors.Is(err, errAlreadyApplied):
+		c.JSON(http.StatusConflict, gin.H{"error": "You already have an active application for this contest"})
+	case errors.Is(err, errRoleFull):
+		c.JSON(http.StatusConflict, gin.H{"error": "All slots for this role are filled"})
+	case errors.Is(err, errQuotaBelowAccepted):
+		c.JSON(http.StatusConflict, gin.H{"error": "Quotas cannot be lowered below the accepted applications"})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,147 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContestApplicationHandler(t *testing.T) {
+	env := setupTeamTest(t, 4)
+	require.NoError(t, env.db.AutoMigrate(&database.Contest{}, &database.ContestApplication{}, &database.Notification{}))
+	author, first, second, third := &env.users[0], &env.users[1], &env.users[2], &env.users[3]
+
+	contest := database.Contest{
+		BackendQuota:        1,
+		FrontendQuota:       2,
+		ApplicationDeadline: time.Now().Add(24 * time.Hour),
+		Purpose:             "Purpose",
+		Message:             "Message",
+		AuthorID:            author.ID,
+	}
+	require.NoError(t, env.db.Create(&contest).Error)
+
+	contests := NewContestHandler(env.db)
+	handler := NewContestApplicationHandler(env.db)
+	request := func(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.GET("/contests/:id", contests.GetContest)
+		r.PUT("/contests/:id", env.policy.RequireOwner("contest", ContestAuthor), contests.UpdateContest)
+		r.POST("/contests/:id/applications", handler.Apply)
+		r.GET("/contests/:id/applications", env.policy.RequireOwner("contest", ContestAuthor), handler.ListContestApplications)
+		r.GET("/contest-applications", handler.ListMyApplications)
+		r.POST("/contest-applications/:id/accept", env.policy.RequireOwner("contest application", ApplicationContestAuthor), handler.AcceptApplication)
+		r.POST("/contest-applications/:id/reject", env.policy.RequireOwner("contest application", ApplicationContestAuthor), handler.RejectApplication)
+		r.POST("/contest-applications/:id/withdraw", env.policy.RequireOwner("contest application", ContestApplicant), handler.WithdrawApplication)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	contestPath := fmt.Sprintf("/contests/%d", contest.ID)
+	apply := func(user *database.User, role string) (*httptest.ResponseRecorder, database.ContestApplication) {
+		w := request(user, "POST", contestPath+"/applications", ApplyToContestRequest{Role: role, Message: "I would like to join"})
+		var application database.ContestApplication
+		_ = json.Unmarshal(w.Body.Bytes(), &application)
+		return w, application
+	}
+	decide := func(user *database.User, application database.ContestApplication, action string) int {
+		return request(user, "POST", fmt.Sprintf("/contest-applications/%d/%s", application.ID, action), nil).Code
+	}
+	remainingSlots := func() map[string]int {
+		w := request(nil, "GET", contestPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var body database.Contest
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.True(t, body.ApplicationsOpen)
+		return body.RemainingSlots
+	}
+
+	var firstApplication, secondApplication database.ContestApplication
+
+	t.Run("Users apply for recruited roles", func(t *testing.T) {
+		w, _ := apply(author, "backend")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w, _ = apply(first, "designer")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w, _ = apply(first, "ai")
+		assert.Equal(t, http.StatusBadRequest, w.Code, "the contest recruits no AI members")
+
+		w, firstApplication = apply(first, "Backend")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Equal(t, "backend", firstApplication.Role)
+		assert.Equal(t, domain.ApplicationPending, firstApplication.Status)
+		w, _ = apply(first, "frontend")
+		assert.Equal(t, http.StatusConflict, w.Code, "one active application per contest")
+		w, secondApplication = apply(second, "backend")
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var notifications int64
+		env.db.Model(&database.Notification{}).Where("user_id = ? AND type = ?", author.ID, "application_received").Count(&notifications)
+		assert.Equal(t, int64(2), notifications)
+	})
+
+	t.Run("Authors review applications", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(first, "GET", contestPath+"/applications", nil).Code)
+		w := request(author, "GET", contestPath+"/applications?role=backend&status=pending", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"count":2`)
+
+		w = request(second, "GET", "/contest-applications", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"count":1`)
+	})
+
+	t.Run("Accepting enforces the role quota", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, decide(first, firstApplication, "accept"))
+		require.Equal(t, http.StatusOK, decide(author, firstApplication, "accept"))
+		assert.Equal(t, map[string]int{"backend": 0, "frontend": 2, "ai": 0}, remainingSlots())
+
+		assert.Equal(t, http.StatusConflict, decide(author, secondApplication, "accept"))
+		w, _ := apply(third, "backend")
+		assert.Equal(t, http.StatusConflict, w.Code, "filled roles take no applications")
+
+		var notifications int64
+		env.db.Model(&database.Notification{}).Where("user_id = ? AND type = ?", first.ID, "application_accepted").Count(&notifications)
+		assert.Equal(t, int64(1), notifications)
+	})
+
+	t.Run("Quotas cannot drop below accepted applications", func(t *testing.T) {
+		zero := 0
+		assert.Equal(t, http.StatusConflict, request(author, "PUT", contestPath, UpdateContestRequest{BackendQuota: &zero}).Code)
+		assert.Equal(t, http.StatusOK, request(author, "PUT", contestPath, UpdateContestRequest{FrontendQuota: &zero}).Code)
+	})
+
+	t.Run("Withdrawing frees the slot", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, decide(second, firstApplication, "withdraw"))
+		require.Equal(t, http.StatusOK, decide(first, firstApplication, "withdraw"))
+		assert.Equal(t, 1, remainingSlots()["backend"])
+
+		require.Equal(t, http.StatusOK, decide(author, secondApplication, "reject"))
+		assert.Equal(t, http.StatusConflict, decide(author, secondApplication, "accept"), "rejections are final")
+		assert.Equal(t, http.StatusConflict, decide(second, secondApplication, "withdraw"))
+	})
+
+	t.Run("Applications close at the deadline", func(t *testing.T) {
+		env.db.Model(&contest).Update("application_deadline", time.Now().Add(-time.Minute))
+		w, _ := apply(third, "backend")
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = request(nil, "GET", contestPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"applications_open":false`)
+	})
+}
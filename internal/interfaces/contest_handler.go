@@ -25,7 +25,7 @@ type CreateContestRequest struct {
 	ApplicationDeadline string `json:"application_deadline" binding:"required"`
 	Purpose             string `json:"purpose" binding:"required"`
 	Message             string `json:"message" binding:"required"`
-	AuthorID            uint   `json:"author_id" binding:"required"`
+	AuthorID            uint   `json:"author_id"`
 	Title               string `json:"title,omitempty"`
 	Description         string `json:"description,omitempty"`
 }
@@ -49,6 +49,11 @@ func (h *ContestHandler) CreateContest(c *gin.Context) {
 		return
 	}
 
+	authorID, ok := resolveActingUserID(c, req.AuthorID)
+	if !ok {
+		return
+	}
+
 	// Parse deadline
 	deadline, err := time.Parse(time.RFC3339, req.ApplicationDeadline)
 	if err != nil {
@@ -63,7 +68,7 @@ func (h *ContestHandler) CreateContest(c *gin.Context) {
 		ApplicationDeadline: deadline,
 		Purpose:             req.Purpose,
 		Message:             req.Message,
-		AuthorID:            req.AuthorID,
+		AuthorID:            authorID,
 		Title:               req.Title,
 		Description:         req.Description,
 	}
@@ -73,7 +78,7 @@ func (h *ContestHandler) CreateContest(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, contest)
+	h.respondContest(c, http.StatusCreated, contest)
 }
 
 // ListContests handles GET /api/v1/contests
@@ -112,6 +117,10 @@ func (h *ContestHandler) ListContests(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contests"})
 		return
 	}
+	if err := fillContestSlots(h.db, contests); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contests"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"contests": contests,
@@ -137,7 +146,7 @@ func (h *ContestHandler) GetContest(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, contest)
+	h.respondContest(c, http.StatusOK, contest)
 }
 
 // UpdateContest handles PUT /api/v1/contests/:id
@@ -192,12 +201,22 @@ func (h *ContestHandler) UpdateContest(c *gin.Context) {
 		contest.Description = *req.Description
 	}
 
-	if err := h.db.Save(&contest).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contest"})
+	// Quotas are checked against accepted applications while the contest is locked
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockContest(tx, contest.ID); err != nil {
+			return err
+		}
+		if err := checkQuotasCoverAccepted(tx, &contest); err != nil {
+			return err
+		}
+		return tx.Save(&contest).Error
+	})
+	if err != nil {
+		handleApplicationError(c, err, "Failed to update contest")
 		return
 	}
 
-	c.JSON(http.StatusOK, contest)
+	h.respondContest(c, http.StatusOK, contest)
 }
 
 // DeleteContest handles DELETE /api/v1/contests/:id
@@ -220,4 +239,15 @@ func (h *ContestHandler) DeleteContest(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Contest deleted successfully"})
+}
+
+// respondContest responds with the contest, its application status and
+// remaining slots filled in
+func (h *ContestHandler) respondContest(c *gin.Context, status int, contest database.Contest) {
+	contests := []database.Contest{contest}
+	if err := fillContestSlots(h.db, contests); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contest"})
+		return
+	}
+	c.JSON(status, contests[0])
 }
\ No newline at end of file
@@ -24,7 +24,7 @@ func setupTestHandler(t *testing.T) (*ContestHandler, *gorm.DB) {
 	}
 
 	// Run migrations
-	err = db.AutoMigrate(&database.Contest{})
+	err = db.AutoMigrate(&database.Contest{}, &database.ContestApplication{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -36,6 +36,7 @@ func setupTestHandler(t *testing.T) (*ContestHandler, *gorm.DB) {
 func setupTestRouter(handler *ContestHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
+	r.Use(withUser(&database.User{ID: 1, Role: "user"}))
 	
 	v1 := r.Group("/api/v1")
 	contests := v1.Group("/contests")
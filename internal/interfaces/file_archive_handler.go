@@ -0,0 +1,74 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// ArchiveFilesRequest selects the files to export either by ID or by prefix and attachment
+type ArchiveFilesRequest struct {
+	IDs            []string `json:"ids"`
+	Prefix         string   `json:"prefix"`
+	AttachableType string   `json:"attachable_type"`
+	AttachableID   uint     `json:"attachable_id"`
+	Name           string   `json:"name"` // archive file name, defaults to files.zip
+}
+
+// ArchiveFiles handles requests to download several files as one ZIP archive.
+// The archive is built while it is sent, so failures after the first entry can
+// only abort the response.
+// POST /files/archive
+func (h *FileHandler) ArchiveFiles(c *gin.Context) {
+	var req ArchiveFilesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	archiveReq := &domain.ArchiveRequest{IDs: req.IDs, Prefix: req.Prefix}
+	if req.AttachableType != "" || req.AttachableID != 0 {
+		archiveReq.Attachment = &domain.FileAttachment{Type: req.AttachableType, ID: req.AttachableID}
+	}
+
+	files, err := h.fileService.ArchiveFiles(c.Request.Context(), fileViewer(c), archiveReq)
+	if err != nil {
+		if handleFileAccessError(c, err) || handleScanError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrInvalidArchiveRequest):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Select files either by ids or by prefix and attachment"})
+		case errors.Is(err, domain.ErrArchiveTooLarge):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Too many files for one archive"})
+		case errors.Is(err, domain.ErrArchiveEmpty):
+			c.JSON(http.StatusNotFound, gin.H{"error": "No files to archive"})
+		case errors.Is(err, domain.ErrFileNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found", "details": err.Error()})
+		default:
+			log.Printf("Failed to resolve archive files: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create archive"})
+		}
+		return
+	}
+
+	name := req.Name
+	if name == "" {
+		name = "files"
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ".zip") {
+		name += ".zip"
+	}
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", contentDisposition(name))
+	c.Status(http.StatusOK)
+
+	if err := h.fileService.WriteArchive(c.Request.Context(), files, c.Writer); err != nil {
+		log.Printf("Failed to stream archive: %v", err)
+		c.Abort()
+	}
+}
@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHandler_ArchiveFiles(t *testing.T) {
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/files", fileHandler.CreateFile)
+	router.POST("/files/archive", fileHandler.ArchiveFiles)
+
+	uploadTestFile(t, router, "one.txt", []byte("first"))
+	uploadTestFile(t, router, "two.txt", []byte("second"))
+
+	archive := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/files/archive", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := archive(`{"ids": ["one.txt", "two.txt"], "name": "submissions"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=submissions.zip`, w.Header().Get("Content-Disposition"))
+
+	reader, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range reader.File {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{"one.txt", "two.txt"}, names)
+
+	assert.Equal(t, http.StatusBadRequest, archive(`{}`).Code)
+	assert.Equal(t, http.StatusNotFound, archive(`{"ids": ["missing.txt"]}`).Code)
+	assert.Equal(t, http.StatusNotFound, archive(`{"prefix": "nothing/"}`).Code)
+}
@@ -1,10 +1,15 @@
 package interfaces
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/TRu-S3/backend/internal/application"
 	"github.com/TRu-S3/backend/internal/domain"
@@ -23,55 +28,75 @@ func NewFileHandler(fileService *application.FileService) *FileHandler {
 	}
 }
 
-// CreateFile handles file creation requests
+// maxFormFieldSize bounds the text fields read ahead of the file part
+const maxFormFieldSize = 1024
+
+// CreateFile handles file creation requests.
+// The multipart body is read part by part so the file streams straight into storage.
+// The optional visibility, attachable_type and attachable_id fields must precede the file part.
 // POST /files
 func (h *FileHandler) CreateFile(c *gin.Context) {
-	// Get multipart form
-	form, err := c.MultipartForm()
+	reader, err := c.Request.MultipartReader()
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
 		return
 	}
 
-	// Get files from form
-	files := form.File["file"]
-	if len(files) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file provided"})
-		return
-	}
-
-	file := files[0]
-
-	// Open the file
-	src, err := file.Open()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to open file"})
-		return
+	// Collect form fields until the file part
+	fields := map[string]string{}
+	var part *multipart.Part
+	for {
+		part, err = reader.NextPart()
+		if err == io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "No file provided"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
+			return
+		}
+		if part.FormName() == "file" && part.FileName() != "" {
+			break
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+		part.Close()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
+			return
+		}
+		fields[part.FormName()] = string(value)
 	}
-	defer src.Close()
+	defer part.Close()
 
-	// Read file content
-	content, err := io.ReadAll(src)
+	attachment, err := parseAttachment(fields["attachable_type"], fields["attachable_id"])
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file content"})
+		handleFileAccessError(c, err)
 		return
 	}
 
 	// Create file request
-	req := &domain.CreateFileRequest{
-		Name:        file.Filename,
-		Content:     content,
-		ContentType: file.Header.Get("Content-Type"),
+	req := &domain.CreateFileStreamRequest{
+		Name:        part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+		Content:     part,
+		Access: &domain.FileAccessRequest{
+			Uploader:   fileViewer(c),
+			Visibility: fields["visibility"],
+			Attachment: attachment,
+		},
 	}
 
 	// Create file through service
-	createdFile, err := h.fileService.CreateFile(c.Request.Context(), req)
+	createdFile, err := h.fileService.CreateFileFromReader(c.Request.Context(), req)
 	if err != nil {
-		if err == domain.ErrFileAlreadyExists {
+		if handleFileAccessError(c, err) || handleUploadPolicyError(c, err) || handleScanError(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrFileAlreadyExists) {
 			c.JSON(http.StatusConflict, gin.H{"error": "File already exists"})
 			return
 		}
-		if err == domain.ErrInvalidFileName {
+		if errors.Is(err, domain.ErrInvalidFileName) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
 			return
 		}
@@ -86,56 +111,215 @@ func (h *FileHandler) CreateFile(c *gin.Context) {
 // GetFile handles file retrieval requests
 // GET /files/:id
 func (h *FileHandler) GetFile(c *gin.Context) {
+	file, ok := h.lookupFile(c)
+	if !ok || !h.authorizeRead(c, file) {
+		return
+	}
+
+	c.JSON(http.StatusOK, file)
+}
+
+// DownloadFile handles file download requests.
+// Supports single byte ranges (206 Partial Content) and conditional requests via ETag.
+// GET /files/:id/download
+func (h *FileHandler) DownloadFile(c *gin.Context) {
+	file, ok := h.lookupFile(c)
+	if !ok || !h.authorizeRead(c, file) || !authorizeDownload(c, file) {
+		return
+	}
+
+	h.serveFile(c, file)
+}
+
+// lookupFile loads the file addressed by :id, responding with an error if it cannot
+func (h *FileHandler) lookupFile(c *gin.Context) (*domain.File, bool) {
 	id := c.Param("id")
 	if id == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File ID is required"})
-		return
+		return nil, false
 	}
 
 	file, err := h.fileService.GetFile(c.Request.Context(), id)
 	if err != nil {
-		if err == domain.ErrFileNotFound {
+		if errors.Is(err, domain.ErrFileNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
-			return
+			return nil, false
 		}
+		log.Printf("Failed to get file: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get file"})
-		return
+		return nil, false
 	}
 
-	c.JSON(http.StatusOK, file)
+	return file, true
 }
 
-// DownloadFile handles file download requests
-// GET /files/:id/download
-func (h *FileHandler) DownloadFile(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File ID is required"})
+// authorizeRead responds with 403 unless the current user may read file
+func (h *FileHandler) authorizeRead(c *gin.Context, file *domain.File) bool {
+	err := h.fileService.AuthorizeRead(c.Request.Context(), file, fileViewer(c))
+	if err == nil {
+		return true
+	}
+	if !handleFileAccessError(c, err) {
+		log.Printf("Failed to authorize file access: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authorize request"})
+	}
+	return false
+}
+
+// authorizeDownload responds with an error unless the content of file passed the content scan
+func authorizeDownload(c *gin.Context, file *domain.File) bool {
+	if err := file.DownloadError(); err != nil {
+		handleScanError(c, err)
+		return false
+	}
+	return true
+}
+
+// authorizeWrite responds with 403 unless the current user may modify the file addressed
+// by :id, or with 409 if the file belongs to a submission that is closed
+func (h *FileHandler) authorizeWrite(c *gin.Context) bool {
+	file, ok := h.lookupFile(c)
+	if !ok {
+		return false
+	}
+	err := h.fileService.AuthorizeWrite(c.Request.Context(), file, fileViewer(c))
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, domain.ErrFileAccessDenied):
+		abortForbidden(c, "file")
+	case errors.Is(err, domain.ErrAttachmentLocked):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "Files of a closed submission can no longer be changed"})
+	default:
+		log.Printf("Failed to authorize file write: %v", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check file access"})
+	}
+	return false
+}
+
+// serveFile streams the content of file, honouring Range and conditional request headers
+func (h *FileHandler) serveFile(c *gin.Context, file *domain.File) {
+	h.serveContent(c, file, func(offset, length int64) (*domain.FileReader, error) {
+		return h.fileService.OpenFileRange(c.Request.Context(), file.ID, offset, length)
+	})
+}
+
+// serveContent streams the content opened by open for file, honouring Range and
+// conditional request headers
+func (h *FileHandler) serveContent(c *gin.Context, file *domain.File, open func(offset, length int64) (*domain.FileReader, error)) {
+	etag := fileETag(file)
+	if etag != "" {
+		c.Header("ETag", etag)
+	}
+	c.Header("Accept-Ranges", "bytes")
+
+	if etag != "" && etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
-	fileData, err := h.fileService.GetFileContent(c.Request.Context(), id)
+	// Resolve the requested range; If-Range falls back to the full content when the file changed
+	status := http.StatusOK
+	offset, length := int64(0), int64(-1)
+	rangeHeader := c.GetHeader("Range")
+	if ifRange := c.GetHeader("If-Range"); ifRange != "" && ifRange != etag {
+		rangeHeader = ""
+	}
+	if rangeHeader != "" {
+		byteRange, err := parseByteRange(rangeHeader, file.Size)
+		if err != nil {
+			c.Header("Content-Range", fmt.Sprintf("bytes */%d", file.Size))
+			c.JSON(http.StatusRequestedRangeNotSatisfiable, gin.H{"error": "Requested range not satisfiable"})
+			return
+		}
+		if byteRange != nil {
+			status = http.StatusPartialContent
+			offset, length = byteRange.start, byteRange.length
+		}
+	}
+
+	reader, err := open(offset, length)
 	if err != nil {
-		if err == domain.ErrFileNotFound {
+		if errors.Is(err, domain.ErrFileNotFound) || errors.Is(err, domain.ErrVersionNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
 			return
 		}
+		if errors.Is(err, domain.ErrInvalidRange) {
+			c.JSON(http.StatusRequestedRangeNotSatisfiable, gin.H{"error": "Requested range not satisfiable"})
+			return
+		}
+		log.Printf("Failed to open file content: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get file content"})
 		return
 	}
+	defer reader.Content.Close()
 
-	// Set response headers
-	c.Header("Content-Disposition", "attachment; filename="+fileData.File.Name)
-	c.Header("Content-Type", fileData.File.ContentType)
-	c.Header("Content-Length", strconv.FormatInt(fileData.File.Size, 10))
+	headers := map[string]string{
+		"Content-Disposition": contentDisposition(reader.File.Name),
+	}
+	if status == http.StatusPartialContent {
+		headers["Content-Range"] = fmt.Sprintf("bytes %d-%d/%d", reader.Offset, reader.Offset+reader.Length-1, reader.File.Size)
+	}
 
-	// Write content
-	c.Data(http.StatusOK, fileData.File.ContentType, fileData.Content)
+	c.DataFromReader(status, reader.Length, reader.File.ContentType, reader.Content, headers)
 }
 
-// ListFiles handles file listing requests
+// ListFiles handles file listing requests.
+// Only public files and the caller's own uploads are listed; admins see every file.
 // GET /files
 func (h *FileHandler) ListFiles(c *gin.Context) {
+	query, ok := parseFileQuery(c)
+	if !ok {
+		return
+	}
+	h.listFiles(c, query)
+}
+
+// ListAttachedFiles returns a handler listing the files attached to the entity of
+// attachmentType whose ID is the idParam URL parameter. Hackathon-member files are
+// included for members of the entity's hackathon.
+// GET /profiles/:id/files, /hackathons/:id/files, /contests/:id/files,
+// /hackathons/:id/participants/:participant_id/files
+func (h *FileHandler) ListAttachedFiles(attachmentType, idParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := paramID(c, idParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + attachmentType + " ID format"})
+			return
+		}
+
+		query, ok := parseFileQuery(c)
+		if !ok {
+			return
+		}
+		query.Attachment = &domain.FileAttachment{Type: attachmentType, ID: id}
+		h.listFiles(c, query)
+	}
+}
+
+// listFiles responds with the page of files matching query that the current user may see
+func (h *FileHandler) listFiles(c *gin.Context, query *domain.FileQuery) {
+	files, total, err := h.fileService.ListFiles(c.Request.Context(), fileViewer(c), query)
+	if err != nil {
+		if handleFileAccessError(c, err) {
+			return
+		}
+		log.Printf("Failed to list files: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"files":  files,
+		"count":  len(files),
+		"total":  total,
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	})
+}
+
+// parseFileQuery parses the filter, sort and pagination parameters of file listings
+func parseFileQuery(c *gin.Context) (*domain.FileQuery, bool) {
 	// Parse query parameters
 	prefix := c.Query("prefix")
 	limitStr := c.DefaultQuery("limit", "100")
@@ -144,90 +328,113 @@ func (h *FileHandler) ListFiles(c *gin.Context) {
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
-		return
+		return nil, false
 	}
 
 	offset, err := strconv.Atoi(offsetStr)
-	if err != nil {
+	if err != nil || offset < 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
-		return
+		return nil, false
 	}
 
 	query := &domain.FileQuery{
-		Prefix: prefix,
-		Limit:  limit,
-		Offset: offset,
+		Prefix:      prefix,
+		ContentType: c.Query("content_type"),
+		SortBy:      c.DefaultQuery("sort_by", domain.FileSortName),
+		Limit:       limit,
+		Offset:      offset,
 	}
 
-	files, err := h.fileService.ListFiles(c.Request.Context(), query)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
-		return
+	for param, target := range map[string]**int64{"min_size": &query.MinSize, "max_size": &query.MaxSize} {
+		if value := c.Query(param); value != "" {
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " parameter"})
+				return nil, false
+			}
+			*target = &size
+		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"files": files,
-		"count": len(files),
-	})
+	switch query.SortBy {
+	case domain.FileSortName, domain.FileSortSize, domain.FileSortContentType, domain.FileSortCreatedAt, domain.FileSortUpdatedAt:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort_by parameter. Use name, size, content_type, created_at or updated_at"})
+		return nil, false
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order parameter. Use asc or desc"})
+		return nil, false
+	}
+
+	return query, true
 }
 
 // UpdateFile handles file update requests
 // PUT /files/:id
 func (h *FileHandler) UpdateFile(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File ID is required"})
+	if !h.authorizeWrite(c) {
 		return
 	}
+	id := c.Param("id")
 
-	// Get multipart form
-	form, err := c.MultipartForm()
+	reader, err := c.Request.MultipartReader()
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
 		return
 	}
 
+	// Collect form fields until the file part, if any
 	req := &domain.UpdateFileRequest{}
-
-	// Get new name if provided
-	if names, exists := form.Value["name"]; exists && len(names) > 0 {
-		req.Name = names[0]
-	}
-
-	// Get new file if provided
-	if files, exists := form.File["file"]; exists && len(files) > 0 {
-		file := files[0]
-
-		// Open the file
-		src, err := file.Open()
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to open file"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
 			return
 		}
-		defer src.Close()
-
-		// Read file content
-		content, err := io.ReadAll(src)
+		if part.FormName() == "file" && part.FileName() != "" {
+			defer part.Close()
+			req.Content = part
+			req.ContentType = part.Header.Get("Content-Type")
+			break
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+		part.Close()
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file content"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
 			return
 		}
-
-		req.Content = content
-		req.ContentType = file.Header.Get("Content-Type")
+		if part.FormName() == "name" {
+			req.Name = string(value)
+		}
 	}
 
-	// Update file through service
+	// Update file through service, streaming new content if provided
 	updatedFile, err := h.fileService.UpdateFile(c.Request.Context(), id, req)
 	if err != nil {
-		if err == domain.ErrFileNotFound {
+		if handleUploadPolicyError(c, err) || handleScanError(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrFileNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
 			return
 		}
-		if err == domain.ErrInvalidFileName {
+		if errors.Is(err, domain.ErrInvalidFileName) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
 			return
 		}
+		if errors.Is(err, domain.ErrFileAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "A file with the new name already exists"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update file"})
 		return
 	}
@@ -238,15 +445,14 @@ func (h *FileHandler) UpdateFile(c *gin.Context) {
 // DeleteFile handles file deletion requests
 // DELETE /files/:id
 func (h *FileHandler) DeleteFile(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File ID is required"})
+	if !h.authorizeWrite(c) {
 		return
 	}
+	id := c.Param("id")
 
 	err := h.fileService.DeleteFile(c.Request.Context(), id)
 	if err != nil {
-		if err == domain.ErrFileNotFound {
+		if errors.Is(err, domain.ErrFileNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
 			return
 		}
@@ -256,3 +462,214 @@ func (h *FileHandler) DeleteFile(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "File deleted successfully"})
 }
+
+// fileViewer returns the authenticated user as a file viewer, or nil for anonymous requests
+func fileViewer(c *gin.Context) *domain.FileViewer {
+	user, ok := CurrentUser(c)
+	if !ok {
+		return nil
+	}
+	return &domain.FileViewer{UserID: user.ID, Admin: user.IsAdmin()}
+}
+
+// parseAttachment parses the attachable_type and attachable_id parameters; both empty means no attachment
+func parseAttachment(attachmentType, attachmentID string) (*domain.FileAttachment, error) {
+	if attachmentType == "" && attachmentID == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(attachmentID, 10, 32)
+	if err != nil || attachmentType == "" {
+		return nil, domain.ErrInvalidAttachment
+	}
+	return &domain.FileAttachment{Type: attachmentType, ID: uint(id)}, nil
+}
+
+// GetUserStorage reports the storage used by a user's uploads against their quota
+// GET /users/:id/storage
+func (h *FileHandler) GetUserStorage(c *gin.Context) {
+	userID, err := paramID(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	usage, err := h.fileService.StorageUsage(c.Request.Context(), userID)
+	if err != nil {
+		log.Printf("Failed to compute storage usage: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute storage usage"})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// ScanFile handles requests to scan the content of a file again
+// POST /files/:id/scan
+func (h *FileHandler) ScanFile(c *gin.Context) {
+	file, err := h.fileService.ScanFile(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if handleScanError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrScanningUnsupported):
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "Content scanning is not configured"})
+		case errors.Is(err, domain.ErrFileNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		default:
+			log.Printf("Failed to scan file: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to scan file"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, file)
+}
+
+// handleFileAccessError maps file access errors to responses and reports whether err was one
+func handleFileAccessError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrFileAccessDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to access this file"})
+	case errors.Is(err, domain.ErrInvalidVisibility):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid visibility. Use private, public, or hackathon_members for files attached to a hackathon, participant or submission"})
+	case errors.Is(err, domain.ErrInvalidAttachment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment. Use attachable_type profile, hackathon, contest, participant or submission with a numeric attachable_id"})
+	case errors.Is(err, domain.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment target not found"})
+	default:
+		return false
+	}
+	return true
+}
+
+// handleUploadPolicyError maps upload policy rejections to responses and reports whether err was one.
+// The body carries the limit that was hit so clients can explain the rejection.
+func handleUploadPolicyError(c *gin.Context, err error) bool {
+	var rejection *domain.UploadRejection
+	if !errors.As(err, &rejection) {
+		return false
+	}
+
+	status, code := http.StatusRequestEntityTooLarge, "file_too_large"
+	switch {
+	case errors.Is(rejection, domain.ErrFileTypeNotAllowed):
+		status, code = http.StatusUnsupportedMediaType, "file_type_not_allowed"
+	case errors.Is(rejection, domain.ErrQuotaExceeded):
+		status, code = http.StatusInsufficientStorage, "quota_exceeded"
+	}
+	c.JSON(status, gin.H{
+		"error":   rejection.Error(),
+		"code":    code,
+		"details": rejection,
+	})
+	return true
+}
+
+// handleScanError maps content scan errors to responses and reports whether err was one
+func handleScanError(c *gin.Context, err error) bool {
+	var rejection *domain.ScanRejection
+	switch {
+	case errors.As(err, &rejection):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "File failed the content scan and was quarantined",
+			"code":    "file_infected",
+			"details": rejection,
+		})
+	case errors.Is(err, domain.ErrFileQuarantined):
+		c.JSON(http.StatusForbidden, gin.H{"error": "File is quarantined", "code": "file_quarantined"})
+	case errors.Is(err, domain.ErrFileNotScanned):
+		c.JSON(http.StatusLocked, gin.H{"error": "File has not passed the content scan yet", "code": "file_not_scanned"})
+	default:
+		return false
+	}
+	return true
+}
+
+// byteRange is a single satisfiable byte range of a file
+type byteRange struct {
+	start  int64
+	length int64
+}
+
+var errUnsatisfiableRange = errors.New("unsatisfiable range")
+
+// parseByteRange parses a Range header against a file of the given size.
+// It returns nil without error when the header should be ignored and the full
+// content served, e.g. for malformed or multi-range requests.
+func parseByteRange(header string, size int64) (*byteRange, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, nil
+	}
+
+	// Suffix range: the last N bytes
+	if startStr == "" {
+		n, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, errUnsatisfiableRange
+		}
+		if n > size {
+			n = size
+		}
+		return &byteRange{start: size - n, length: n}, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := size - 1
+	if endStr != "" {
+		if end, err = strconv.ParseInt(endStr, 10, 64); err != nil || end < start {
+			return nil, nil
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	if start >= size {
+		return nil, errUnsatisfiableRange
+	}
+
+	return &byteRange{start: start, length: end - start + 1}, nil
+}
+
+// fileETag returns the quoted entity tag of a file, derived from its ETag or generation
+func fileETag(file *domain.File) string {
+	switch {
+	case file.ETag != "":
+		return `"` + file.ETag + `"`
+	case file.Generation != 0:
+		return `"` + strconv.FormatInt(file.Generation, 10) + `"`
+	default:
+		return ""
+	}
+}
+
+// etagMatches reports whether an If-None-Match header matches etag using weak comparison
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// contentDisposition builds an attachment Content-Disposition header for filename
+func contentDisposition(filename string) string {
+	if value := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); value != "" {
+		return value
+	}
+	return "attachment"
+}
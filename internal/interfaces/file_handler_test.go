@@ -0,0 +1,491 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupFileTestRouter(t *testing.T) *gin.Engine {
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(withUser(&database.User{ID: 1, Role: "admin"}))
+	r.POST("/files", fileHandler.CreateFile)
+	r.GET("/files/:id", fileHandler.GetFile)
+	r.GET("/files/:id/download", fileHandler.DownloadFile)
+	r.PUT("/files/:id", fileHandler.UpdateFile)
+	return r
+}
+
+func multipartFileBody(t *testing.T, fields map[string]string, filename string, content []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	if filename != "" {
+		part, err := writer.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func uploadTestFile(t *testing.T, router *gin.Engine, filename string, content []byte) *domain.File {
+	body, contentType := multipartFileBody(t, nil, filename, content)
+	req, _ := http.NewRequest("POST", "/files", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var file domain.File
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &file))
+	return &file
+}
+
+func TestFileHandler_CreateFile(t *testing.T) {
+	router := setupFileTestRouter(t)
+
+	t.Run("Streams upload into storage", func(t *testing.T) {
+		file := uploadTestFile(t, router, "hello.txt", []byte("hello world"))
+		assert.Equal(t, "hello.txt", file.ID)
+		assert.Equal(t, int64(11), file.Size)
+		assert.NotEmpty(t, file.ETag)
+	})
+
+	t.Run("Duplicate file", func(t *testing.T) {
+		body, contentType := multipartFileBody(t, nil, "hello.txt", []byte("again"))
+		req, _ := http.NewRequest("POST", "/files", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("No file part", func(t *testing.T) {
+		body, contentType := multipartFileBody(t, map[string]string{"name": "x"}, "", nil)
+		req, _ := http.NewRequest("POST", "/files", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestFileHandler_DownloadFile(t *testing.T) {
+	router := setupFileTestRouter(t)
+	file := uploadTestFile(t, router, "digits.txt", []byte("0123456789"))
+
+	download := func(headers map[string]string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/files/digits.txt/download", nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Full content", func(t *testing.T) {
+		w := download(nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0123456789", w.Body.String())
+		assert.Equal(t, "bytes", w.Header().Get("Accept-Ranges"))
+		assert.Equal(t, `"`+file.ETag+`"`, w.Header().Get("ETag"))
+		assert.Equal(t, "10", w.Header().Get("Content-Length"))
+	})
+
+	t.Run("Byte range", func(t *testing.T) {
+		w := download(map[string]string{"Range": "bytes=2-5"})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "2345", w.Body.String())
+		assert.Equal(t, "bytes 2-5/10", w.Header().Get("Content-Range"))
+	})
+
+	t.Run("Open-ended and suffix ranges", func(t *testing.T) {
+		w := download(map[string]string{"Range": "bytes=7-"})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "789", w.Body.String())
+
+		w = download(map[string]string{"Range": "bytes=-4"})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "6789", w.Body.String())
+		assert.Equal(t, "bytes 6-9/10", w.Header().Get("Content-Range"))
+	})
+
+	t.Run("Unsatisfiable range", func(t *testing.T) {
+		w := download(map[string]string{"Range": "bytes=20-30"})
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, w.Code)
+		assert.Equal(t, "bytes */10", w.Header().Get("Content-Range"))
+	})
+
+	t.Run("Multiple ranges fall back to full content", func(t *testing.T) {
+		w := download(map[string]string{"Range": "bytes=0-1,4-5"})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0123456789", w.Body.String())
+	})
+
+	t.Run("If-None-Match", func(t *testing.T) {
+		w := download(map[string]string{"If-None-Match": `"` + file.ETag + `"`})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		w = download(map[string]string{"If-None-Match": `"stale"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("If-Range with stale ETag serves full content", func(t *testing.T) {
+		w := download(map[string]string{"Range": "bytes=0-1", "If-Range": `"stale"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0123456789", w.Body.String())
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/files/missing.txt/download", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestFileHandler_UpdateFile(t *testing.T) {
+	router := setupFileTestRouter(t)
+	original := uploadTestFile(t, router, "notes.txt", []byte("v1"))
+
+	body, contentType := multipartFileBody(t, map[string]string{"name": "renamed.txt"}, "notes.txt", []byte("version 2"))
+	req, _ := http.NewRequest("PUT", "/files/notes.txt", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var updated domain.File
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	assert.Equal(t, "renamed.txt", updated.ID)
+	assert.Equal(t, int64(9), updated.Size)
+	assert.NotEqual(t, original.ETag, updated.ETag)
+
+	req, _ = http.NewRequest("GET", "/files/notes.txt", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFileHandler_Visibility(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}, &database.User{}, &database.Hackathon{}, &database.HackathonParticipant{}))
+
+	organizer := database.User{Name: "Organizer", Gmail: "organizer@example.com", Role: "user"}
+	member := database.User{Name: "Member", Gmail: "member@example.com", Role: "user"}
+	outsider := database.User{Name: "Outsider", Gmail: "outsider@example.com", Role: "user"}
+	admin := database.User{Name: "Admin", Gmail: "admin@example.com", Role: "admin"}
+	for _, user := range []*database.User{&organizer, &member, &outsider, &admin} {
+		require.NoError(t, db.Create(user).Error)
+	}
+	now := time.Now()
+	hackathon := database.Hackathon{
+		Name: "Hack", Organizer: "Org", OwnerID: &organizer.ID,
+		StartDate: now, EndDate: now, RegistrationStart: now, RegistrationDeadline: now,
+	}
+	require.NoError(t, db.Create(&hackathon).Error)
+	require.NoError(t, db.Create(&database.HackathonParticipant{HackathonID: hackathon.ID, UserID: member.ID}).Error)
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		application.WithAccessRepository(infrastructure.NewGormFileAccessRepository(db)),
+	))
+
+	gin.SetMode(gin.TestMode)
+	request := func(user *database.User, method, path string, fields map[string]string, filename string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.POST("/files", fileHandler.CreateFile)
+		r.GET("/files", fileHandler.ListFiles)
+		r.GET("/files/:id", fileHandler.GetFile)
+		r.GET("/files/:id/download", fileHandler.DownloadFile)
+		r.PUT("/files/:id", fileHandler.UpdateFile)
+		r.DELETE("/files/:id", fileHandler.DeleteFile)
+		r.GET("/hackathons/:id/files", fileHandler.ListAttachedFiles(domain.AttachmentHackathon, "id"))
+
+		var req *http.Request
+		if filename != "" {
+			body, contentType := multipartFileBody(t, fields, filename, []byte("content"))
+			req, _ = http.NewRequest(method, path, body)
+			req.Header.Set("Content-Type", contentType)
+		} else {
+			req, _ = http.NewRequest(method, path, nil)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	listedIDs := func(w *httptest.ResponseRecorder) []string {
+		var response struct {
+			Files []domain.File `json:"files"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		ids := []string{}
+		for _, file := range response.Files {
+			ids = append(ids, file.ID)
+		}
+		return ids
+	}
+
+	membersOnly := map[string]string{"visibility": "hackathon_members", "attachable_type": "hackathon", "attachable_id": "1"}
+
+	t.Run("Upload records uploader, visibility and attachment", func(t *testing.T) {
+		w := request(&organizer, "POST", "/files", membersOnly, "brief.pdf")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var file domain.File
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &file))
+		require.NotNil(t, file.UploaderID)
+		assert.Equal(t, organizer.ID, *file.UploaderID)
+		assert.Equal(t, domain.FileVisibilityHackathonMembers, file.Visibility)
+		assert.Equal(t, &domain.FileAttachment{Type: domain.AttachmentHackathon, ID: hackathon.ID}, file.Attachment)
+
+		w = request(&organizer, "POST", "/files", map[string]string{"visibility": "private"}, "notes.txt")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		w = request(&outsider, "POST", "/files", nil, "public.txt")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+
+	t.Run("Upload validation", func(t *testing.T) {
+		w := request(&outsider, "POST", "/files", membersOnly, "intrusion.pdf")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = request(&organizer, "POST", "/files", map[string]string{"visibility": "hackathon_members"}, "loose.pdf")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = request(&organizer, "POST", "/files", map[string]string{"visibility": "secret"}, "bad.pdf")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = request(&organizer, "POST", "/files", map[string]string{"attachable_type": "hackathon", "attachable_id": "99"}, "missing.pdf")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Hackathon member files", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(nil, "GET", "/files/brief.pdf", nil, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(&outsider, "GET", "/files/brief.pdf", nil, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(&outsider, "GET", "/files/brief.pdf/download", nil, "").Code)
+		assert.Equal(t, http.StatusOK, request(&member, "GET", "/files/brief.pdf", nil, "").Code)
+		assert.Equal(t, http.StatusOK, request(&admin, "GET", "/files/brief.pdf", nil, "").Code)
+
+		w := request(&member, "GET", "/files/brief.pdf/download", nil, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "content", w.Body.String())
+	})
+
+	t.Run("Private files", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(&member, "GET", "/files/notes.txt", nil, "").Code)
+		assert.Equal(t, http.StatusOK, request(&organizer, "GET", "/files/notes.txt", nil, "").Code)
+	})
+
+	t.Run("Listing only shows visible files", func(t *testing.T) {
+		assert.Equal(t, []string{"public.txt"}, listedIDs(request(nil, "GET", "/files", nil, "")))
+		assert.Equal(t, []string{"brief.pdf", "notes.txt", "public.txt"}, listedIDs(request(&organizer, "GET", "/files", nil, "")))
+		assert.Equal(t, []string{"public.txt"}, listedIDs(request(&member, "GET", "/files", nil, "")))
+	})
+
+	t.Run("Listing per entity", func(t *testing.T) {
+		assert.Equal(t, []string{"brief.pdf"}, listedIDs(request(&member, "GET", "/hackathons/1/files", nil, "")))
+		assert.Equal(t, []string{}, listedIDs(request(&outsider, "GET", "/hackathons/1/files", nil, "")))
+		assert.Equal(t, http.StatusNotFound, request(&member, "GET", "/hackathons/99/files", nil, "").Code)
+	})
+
+	t.Run("Renaming cannot replace another user's file", func(t *testing.T) {
+		w := request(&outsider, "PUT", "/files/public.txt", map[string]string{"name": "notes.txt"}, "public.txt")
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+		w = request(&organizer, "GET", "/files/notes.txt", nil, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		var file domain.File
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &file))
+		require.NotNil(t, file.UploaderID)
+		assert.Equal(t, organizer.ID, *file.UploaderID)
+		assert.Equal(t, domain.FileVisibilityPrivate, file.Visibility)
+	})
+
+	t.Run("Only the uploader may delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(&member, "DELETE", "/files/brief.pdf", nil, "").Code)
+		assert.Equal(t, http.StatusOK, request(&organizer, "DELETE", "/files/brief.pdf", nil, "").Code)
+	})
+
+	t.Run("Files without an uploader are admin-only", func(t *testing.T) {
+		w := request(nil, "POST", "/files", nil, "anonymous.txt")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusForbidden, request(nil, "DELETE", "/files/anonymous.txt", nil, "").Code)
+		assert.Equal(t, http.StatusForbidden, request(&outsider, "DELETE", "/files/anonymous.txt", nil, "").Code)
+		assert.Equal(t, http.StatusOK, request(&admin, "DELETE", "/files/anonymous.txt", nil, "").Code)
+	})
+}
+
+func TestFileHandler_UploadPolicy(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}, &database.User{}))
+	user := database.User{Name: "Uploader", Gmail: "uploader@example.com", Role: "user"}
+	require.NoError(t, db.Create(&user).Error)
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		application.WithUploadPolicy(domain.UploadPolicy{
+			MaxFileSize:  8,
+			UserQuota:    12,
+			AllowedTypes: map[string][]string{domain.AttachmentProfile: {"image/*"}},
+		}),
+	))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(withUser(&user))
+	router.POST("/files", fileHandler.CreateFile)
+	router.GET("/users/:id/storage", fileHandler.GetUserStorage)
+
+	upload := func(filename string, fields map[string]string, content string) (*httptest.ResponseRecorder, map[string]any) {
+		body, contentType := multipartFileBody(t, fields, filename, []byte(content))
+		req, _ := http.NewRequest("POST", "/files", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w, response
+	}
+
+	w, response := upload("big.bin", nil, "123456789")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "file_too_large", response["code"])
+	assert.Equal(t, float64(8), response["details"].(map[string]any)["limit"])
+
+	w, response = upload("photo.bin", map[string]string{"attachable_type": "profile", "attachable_id": "1"}, "1")
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Equal(t, "file_type_not_allowed", response["code"])
+	assert.Equal(t, []any{"image/*"}, response["details"].(map[string]any)["allowed_types"])
+
+	w, _ = upload("a.bin", nil, "12345678")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w, response = upload("b.bin", nil, "12345")
+	assert.Equal(t, http.StatusInsufficientStorage, w.Code)
+	assert.Equal(t, "quota_exceeded", response["code"])
+	assert.Equal(t, float64(8), response["details"].(map[string]any)["used"])
+
+	req, _ := http.NewRequest("GET", "/users/1/storage", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var usage domain.StorageUsage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &usage))
+	assert.Equal(t, int64(8), usage.UsedBytes)
+	assert.Equal(t, int64(1), usage.FileCount)
+	assert.Equal(t, int64(12), usage.QuotaBytes)
+	require.NotNil(t, usage.RemainingBytes)
+	assert.Equal(t, int64(4), *usage.RemainingBytes)
+}
+
+// scannerFunc adapts a function to domain.FileScanner
+type scannerFunc func(content []byte) (*domain.ScanResult, error)
+
+func (f scannerFunc) Scan(ctx context.Context, content io.Reader) (*domain.ScanResult, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	return f(data)
+}
+
+func TestFileHandler_Scanning(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}))
+
+	available := true
+	scanner := scannerFunc(func(content []byte) (*domain.ScanResult, error) {
+		if !available {
+			return nil, errors.New("scanner unavailable")
+		}
+		if bytes.Contains(content, []byte("EICAR")) {
+			return &domain.ScanResult{Threat: "Eicar-Test-Signature"}, nil
+		}
+		return &domain.ScanResult{Clean: true}, nil
+	})
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		application.WithScanner(scanner, ""),
+	))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/files", fileHandler.CreateFile)
+	router.GET("/files/:id", fileHandler.GetFile)
+	router.GET("/files/:id/download", fileHandler.DownloadFile)
+	router.POST("/files/:id/scan", fileHandler.ScanFile)
+	request := func(method, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Infected uploads are rejected and quarantined", func(t *testing.T) {
+		body, contentType := multipartFileBody(t, nil, "virus.txt", []byte("EICAR"))
+		req, _ := http.NewRequest("POST", "/files", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "Eicar-Test-Signature")
+
+		w = request("GET", "/files/virus.txt")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"scan_status":"infected"`)
+		assert.Equal(t, http.StatusForbidden, request("GET", "/files/virus.txt/download").Code)
+	})
+
+	t.Run("Downloads wait for a clean scan", func(t *testing.T) {
+		available = false
+		uploadTestFile(t, router, "report.txt", []byte("report"))
+		assert.Equal(t, http.StatusLocked, request("GET", "/files/report.txt/download").Code)
+
+		available = true
+		require.Equal(t, http.StatusOK, request("POST", "/files/report.txt/scan").Code)
+		w := request("GET", "/files/report.txt/download")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "report", w.Body.String())
+	})
+}
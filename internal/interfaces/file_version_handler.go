@@ -0,0 +1,133 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// ListFileVersions handles file version listing requests
+// GET /files/:id/versions
+func (h *FileHandler) ListFileVersions(c *gin.Context) {
+	file, ok := h.lookupFile(c)
+	if !ok || !h.authorizeRead(c, file) {
+		return
+	}
+
+	versions, err := h.fileService.ListFileVersions(c.Request.Context(), file.ID)
+	if err != nil {
+		handleFileVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"versions": versions,
+		"count":    len(versions),
+	})
+}
+
+// DownloadFileVersion handles downloads of a specific file version.
+// Supports the same Range and conditional requests as DownloadFile.
+// GET /files/:id/versions/:generation/download
+func (h *FileHandler) DownloadFileVersion(c *gin.Context) {
+	generation, ok := parseGeneration(c)
+	if !ok {
+		return
+	}
+	file, ok := h.lookupFile(c)
+	if !ok || !h.authorizeRead(c, file) {
+		return
+	}
+
+	versions, err := h.fileService.ListFileVersions(c.Request.Context(), file.ID)
+	if err != nil {
+		handleFileVersionError(c, err)
+		return
+	}
+
+	// Describe the version with its own size and ETag so ranges and caching apply to it
+	var version *domain.File
+	for _, v := range versions {
+		if v.Generation == generation {
+			versionFile := *file
+			versionFile.Size = v.Size
+			versionFile.ContentType = v.ContentType
+			versionFile.ETag = v.ETag
+			versionFile.Generation = v.Generation
+			if !v.Live {
+				// Earlier content is only served if it passed the scan itself
+				versionFile.FileScan, err = h.fileService.VersionScan(c.Request.Context(), file.ID, generation)
+				if err != nil {
+					handleFileVersionError(c, err)
+					return
+				}
+			}
+			version = &versionFile
+			break
+		}
+	}
+	if version == nil {
+		handleFileVersionError(c, domain.ErrVersionNotFound)
+		return
+	}
+	if !authorizeDownload(c, version) {
+		return
+	}
+
+	h.serveContent(c, version, func(offset, length int64) (*domain.FileReader, error) {
+		return h.fileService.OpenFileVersion(c.Request.Context(), file.ID, generation, offset, length)
+	})
+}
+
+// RestoreFileVersion handles requests to make an earlier version the live content.
+// The replaced content is kept as a version.
+// POST /files/:id/versions/:generation/restore
+func (h *FileHandler) RestoreFileVersion(c *gin.Context) {
+	generation, ok := parseGeneration(c)
+	if !ok {
+		return
+	}
+	if !h.authorizeWrite(c) {
+		return
+	}
+
+	file, err := h.fileService.RestoreFileVersion(c.Request.Context(), c.Param("id"), generation)
+	if err != nil {
+		handleFileVersionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, file)
+}
+
+// parseGeneration parses the :generation URL parameter
+func parseGeneration(c *gin.Context) (int64, bool) {
+	generation, err := strconv.ParseInt(c.Param("generation"), 10, 64)
+	if err != nil || generation <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid generation"})
+		return 0, false
+	}
+	return generation, true
+}
+
+// handleFileVersionError maps file version errors to responses
+func handleFileVersionError(c *gin.Context, err error) {
+	if handleScanError(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, domain.ErrVersioningUnsupported):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "File versioning is not supported by the storage backend"})
+	case errors.Is(err, domain.ErrVersionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "File version not found"})
+	case errors.Is(err, domain.ErrFileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	default:
+		log.Printf("Failed to access file versions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to access file versions"})
+	}
+}
@@ -0,0 +1,102 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHandler_Versions(t *testing.T) {
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo,
+		application.WithVersioning(repo, domain.VersionRetention{MaxVersions: 10}),
+	))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(withUser(&database.User{ID: 1, Role: "admin"}))
+	router.POST("/files", fileHandler.CreateFile)
+	router.PUT("/files/:id", fileHandler.UpdateFile)
+	router.GET("/files/:id/download", fileHandler.DownloadFile)
+	router.GET("/files/:id/versions", fileHandler.ListFileVersions)
+	router.GET("/files/:id/versions/:generation/download", fileHandler.DownloadFileVersion)
+	router.POST("/files/:id/versions/:generation/restore", fileHandler.RestoreFileVersion)
+
+	request := func(method, path string, headers map[string]string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	original := uploadTestFile(t, router, "draft.txt", []byte("first draft"))
+	body, contentType := multipartFileBody(t, nil, "draft.txt", []byte("broken upload"))
+	req, _ := http.NewRequest("PUT", "/files/draft.txt", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("List versions", func(t *testing.T) {
+		w := request("GET", "/files/draft.txt/versions", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Versions []domain.FileVersion `json:"versions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Versions, 2)
+		assert.True(t, response.Versions[0].Live)
+		assert.Equal(t, original.Generation, response.Versions[1].Generation)
+	})
+
+	t.Run("Download a version", func(t *testing.T) {
+		path := fmt.Sprintf("/files/draft.txt/versions/%d/download", original.Generation)
+		w := request("GET", path, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "first draft", w.Body.String())
+		assert.Equal(t, `"`+original.ETag+`"`, w.Header().Get("ETag"))
+
+		w = request("GET", path, map[string]string{"Range": "bytes=0-4"})
+		assert.Equal(t, http.StatusPartialContent, w.Code)
+		assert.Equal(t, "first", w.Body.String())
+
+		w = request("GET", "/files/draft.txt/versions/42/download", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = request("GET", "/files/draft.txt/versions/latest/download", nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Restore a version", func(t *testing.T) {
+		w := request("POST", fmt.Sprintf("/files/draft.txt/versions/%d/restore", original.Generation), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request("GET", "/files/draft.txt/download", nil)
+		assert.Equal(t, "first draft", w.Body.String())
+	})
+
+	t.Run("Versioning disabled", func(t *testing.T) {
+		handler := NewFileHandler(application.NewFileService(repo))
+		r := gin.New()
+		r.GET("/files/:id/versions", handler.ListFileVersions)
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/files/draft.txt/versions", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+}
@@ -1,11 +1,13 @@
 package interfaces
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
 	"github.com/TRu-S3/backend/internal/utils"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -28,15 +30,15 @@ type CreateHackathonRequest struct {
 	EndDate              string    `json:"end_date" binding:"required"`
 	RegistrationStart    string    `json:"registration_start" binding:"required"`
 	RegistrationDeadline string    `json:"registration_deadline" binding:"required"`
+	SubmissionDeadline   string    `json:"submission_deadline"`
 	MaxParticipants      int       `json:"max_participants" binding:"min=0"`
 	Location             string    `json:"location"`
 	Organizer            string    `json:"organizer" binding:"required"`
 	ContactEmail         string    `json:"contact_email"`
 	PrizeInfo            string    `json:"prize_info"`
 	Rules                string    `json:"rules"`
-	TechStack            string    `json:"tech_stack"`
+	TechStack            []string  `json:"tech_stack"`
 	IsPublic             *bool     `json:"is_public"`
-	BannerURL            string    `json:"banner_url"`
 	WebsiteURL           string    `json:"website_url"`
 }
 
@@ -47,23 +49,22 @@ type UpdateHackathonRequest struct {
 	EndDate              *string `json:"end_date,omitempty"`
 	RegistrationStart    *string `json:"registration_start,omitempty"`
 	RegistrationDeadline *string `json:"registration_deadline,omitempty"`
+	SubmissionDeadline   *string `json:"submission_deadline,omitempty"`
 	MaxParticipants      *int    `json:"max_participants,omitempty" binding:"omitempty,min=0"`
 	Location             *string `json:"location,omitempty"`
 	Organizer            *string `json:"organizer,omitempty"`
 	ContactEmail         *string `json:"contact_email,omitempty"`
 	PrizeInfo            *string `json:"prize_info,omitempty"`
 	Rules                *string `json:"rules,omitempty"`
-	TechStack            *string `json:"tech_stack,omitempty"`
+	TechStack            []string `json:"tech_stack"`
 	Status               *string `json:"status,omitempty"`
 	IsPublic             *bool   `json:"is_public,omitempty"`
-	BannerURL            *string `json:"banner_url,omitempty"`
 	WebsiteURL           *string `json:"website_url,omitempty"`
 }
 
 type CreateParticipantRequest struct {
 	HackathonID uint   `json:"hackathon_id" binding:"required"`
-	UserID      uint   `json:"user_id" binding:"required"`
-	TeamName    string `json:"team_name"`
+	UserID      uint   `json:"user_id"`
 	Role        string `json:"role"`
 	Notes       string `json:"notes"`
 }
@@ -107,11 +108,35 @@ func (h *HackathonHandler) CreateHackathon(c *gin.Context) {
 		return
 	}
 
+	// Submissions lock at the end of the hackathon unless a deadline is given
+	var submissionDeadline *time.Time
+	if req.SubmissionDeadline != "" {
+		deadline, ok := utils.ParseDateRFC3339(c, req.SubmissionDeadline, "submission_deadline")
+		if !ok {
+			return
+		}
+		if !utils.ValidateDateRange(c, startDate, deadline, "start_date", "submission_deadline") {
+			return
+		}
+		submissionDeadline = &deadline
+	}
+
+	techStack, err := normalizeTechStack(req.TechStack)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
 	isPublic := true
 	if req.IsPublic != nil {
 		isPublic = *req.IsPublic
 	}
 
+	var ownerID *uint
+	if user, ok := CurrentUser(c); ok {
+		ownerID = &user.ID
+	}
+
 	hackathon := database.Hackathon{
 		Name:                 req.Name,
 		Description:          req.Description,
@@ -119,20 +144,28 @@ func (h *HackathonHandler) CreateHackathon(c *gin.Context) {
 		EndDate:              endDate,
 		RegistrationStart:    registrationStart,
 		RegistrationDeadline: registrationDeadline,
+		SubmissionDeadline:   submissionDeadline,
 		MaxParticipants:      req.MaxParticipants,
 		Location:             req.Location,
 		Organizer:            req.Organizer,
 		ContactEmail:         req.ContactEmail,
 		PrizeInfo:            req.PrizeInfo,
 		Rules:                req.Rules,
-		TechStack:            req.TechStack,
 		Status:               "upcoming",
 		IsPublic:             isPublic,
-		BannerURL:            req.BannerURL,
 		WebsiteURL:           req.WebsiteURL,
+		OwnerID:              ownerID,
 	}
 
-	if err := h.GetDatabase().Create(&hackathon).Error; err != nil {
+	err = h.GetDatabase().Transaction(func(tx *gorm.DB) error {
+		tags, err := resolveTechStack(tx, techStack)
+		if err != nil {
+			return err
+		}
+		hackathon.TechStack = tags
+		return tx.Create(&hackathon).Error
+	})
+	if err != nil {
 		utils.InternalErrorResponse(c, "Failed to create hackathon")
 		return
 	}
@@ -181,6 +214,23 @@ func (h *HackathonHandler) ListHackathons(c *gin.Context) {
 		query = query.Where("registration_start <= ? AND registration_deadline >= ?", now, now)
 	}
 
+	// Filter by tech stack, matching any or all of the given technologies
+	if techValues := c.QueryArray("tech"); len(techValues) > 0 {
+		names, err := parseTechFilter(techValues)
+		if err != nil {
+			utils.BadRequestResponse(c, err.Error())
+			return
+		}
+		match := c.DefaultQuery("tech_match", techMatchAny)
+		if match != techMatchAny && match != techMatchAll {
+			utils.BadRequestResponse(c, "tech_match must be any or all")
+			return
+		}
+		if len(names) > 0 {
+			query = filterByTechStack(h.GetDatabase(), query, names, match)
+		}
+	}
+
 	// Order by start date (newest first)
 	query = query.Order("start_date DESC")
 
@@ -189,7 +239,7 @@ func (h *HackathonHandler) ListHackathons(c *gin.Context) {
 	query.Count(&total)
 
 	// Get paginated results
-	if err := query.Offset(params.Offset).Limit(params.Limit).Find(&hackathons).Error; err != nil {
+	if err := query.Preload("TechStack").Offset(params.Offset).Limit(params.Limit).Find(&hackathons).Error; err != nil {
 		utils.InternalErrorResponse(c, "Failed to retrieve hackathons")
 		return
 	}
@@ -212,7 +262,7 @@ func (h *HackathonHandler) GetHackathon(c *gin.Context) {
 	}
 
 	var hackathon database.Hackathon
-	query := h.GetDatabase().Preload("Participants")
+	query := h.GetDatabase().Preload("Participants").Preload("TechStack").Preload("Series").Preload("Stage")
 	if err := query.First(&hackathon, id).Error; err != nil {
 		h.HandleDBError(c, err, "hackathon")
 		return
@@ -241,6 +291,7 @@ func (h *HackathonHandler) UpdateHackathon(c *gin.Context) {
 		}
 		return
 	}
+	previousStatus := hackathon.Status
 
 	// Update fields if provided
 	if req.Name != nil {
@@ -281,6 +332,18 @@ func (h *HackathonHandler) UpdateHackathon(c *gin.Context) {
 		}
 		hackathon.RegistrationDeadline = registrationDeadline
 	}
+	if req.SubmissionDeadline != nil {
+		// An empty value removes the deadline so submissions lock at the end date
+		hackathon.SubmissionDeadline = nil
+		if *req.SubmissionDeadline != "" {
+			submissionDeadline, err := time.Parse(time.RFC3339, *req.SubmissionDeadline)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission_deadline format. Use RFC3339 format"})
+				return
+			}
+			hackathon.SubmissionDeadline = &submissionDeadline
+		}
+	}
 	if req.MaxParticipants != nil {
 		hackathon.MaxParticipants = *req.MaxParticipants
 	}
@@ -299,21 +362,17 @@ func (h *HackathonHandler) UpdateHackathon(c *gin.Context) {
 	if req.Rules != nil {
 		hackathon.Rules = *req.Rules
 	}
+	var techStack []string
 	if req.TechStack != nil {
-		hackathon.TechStack = *req.TechStack
+		var err error
+		if techStack, err = normalizeTechStack(req.TechStack); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 	}
+	actor := transitionActor(c, 0, hackathon.OwnerID)
 	if req.Status != nil {
-		// Validate status
-		validStatuses := []string{"upcoming", "ongoing", "completed", "cancelled"}
-		valid := false
-		for _, status := range validStatuses {
-			if *req.Status == status {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: upcoming, ongoing, completed, cancelled"})
+		if respondTransitionError(c, hackathonStatuses.Check(previousStatus, *req.Status, actor)) {
 			return
 		}
 		hackathon.Status = *req.Status
@@ -321,9 +380,6 @@ func (h *HackathonHandler) UpdateHackathon(c *gin.Context) {
 	if req.IsPublic != nil {
 		hackathon.IsPublic = *req.IsPublic
 	}
-	if req.BannerURL != nil {
-		hackathon.BannerURL = *req.BannerURL
-	}
 	if req.WebsiteURL != nil {
 		hackathon.WebsiteURL = *req.WebsiteURL
 	}
@@ -341,11 +397,50 @@ func (h *HackathonHandler) UpdateHackathon(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Registration start must be before registration deadline"})
 		return
 	}
+	if hackathon.SubmissionDeadline != nil && hackathon.SubmissionDeadline.Before(hackathon.StartDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Submission deadline must be after start date"})
+		return
+	}
 
-	if err := h.db.Save(&hackathon).Error; err != nil {
+	// Raising the capacity promotes waitlisted participants into the new seats
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		change := hackathonChange{c: c, tx: tx, hackathon: &hackathon}
+		err := hackathonStatuses.Apply(change, previousStatus, hackathon.Status, actor, func() error {
+			return tx.Save(&hackathon).Error
+		})
+		if err != nil {
+			return err
+		}
+		// A given tech stack replaces the previous one; an empty list clears it
+		if req.TechStack != nil {
+			tags, err := resolveTechStack(tx, techStack)
+			if err != nil {
+				return err
+			}
+			association := tx.Model(&hackathon).Association("TechStack")
+			if len(tags) == 0 {
+				err = association.Clear()
+			} else {
+				err = association.Replace(tags)
+			}
+			if err != nil {
+				return err
+			}
+			hackathon.TechStack = tags
+		}
+		if req.MaxParticipants != nil {
+			_, err := promoteWaitlisted(tx, hackathon.ID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update hackathon"})
 		return
 	}
+	if req.TechStack == nil {
+		h.db.Model(&hackathon).Association("TechStack").Find(&hackathon.TechStack)
+	}
 
 	c.JSON(http.StatusOK, hackathon)
 }
@@ -382,6 +477,11 @@ func (h *HackathonHandler) CreateParticipant(c *gin.Context) {
 		return
 	}
 
+	userID, ok := resolveActingUserID(c, req.UserID)
+	if !ok {
+		return
+	}
+
 	// Validate hackathon exists
 	var hackathon database.Hackathon
 	if err := h.db.First(&hackathon, hackathonID).Error; err != nil {
@@ -393,6 +493,12 @@ func (h *HackathonHandler) CreateParticipant(c *gin.Context) {
 		return
 	}
 
+	// Completed and cancelled hackathons take no registrations, whatever their window says
+	if hackathon.Status != domain.HackathonStatusUpcoming && hackathon.Status != domain.HackathonStatusOngoing {
+		c.JSON(http.StatusConflict, gin.H{"error": "Hackathon is " + hackathon.Status + " and no longer takes registrations"})
+		return
+	}
+
 	// Check if registration is open
 	now := time.Now()
 	if now.Before(hackathon.RegistrationStart) || now.After(hackathon.RegistrationDeadline) {
@@ -400,38 +506,29 @@ func (h *HackathonHandler) CreateParticipant(c *gin.Context) {
 		return
 	}
 
-	// Check if hackathon is full
-	if hackathon.MaxParticipants > 0 {
-		var participantCount int64
-		h.db.Model(&database.HackathonParticipant{}).Where("hackathon_id = ? AND status != ?", hackathonID, "cancelled").Count(&participantCount)
-		if int(participantCount) >= hackathon.MaxParticipants {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Hackathon is full"})
-			return
-		}
-	}
-
-	// Override hackathon_id from URL parameter
-	hackathonIDParsed, _ := strconv.ParseUint(hackathonID, 10, 32)
-	req.HackathonID = uint(hackathonIDParsed)
-
 	participant := database.HackathonParticipant{
-		HackathonID: uint(req.HackathonID),
-		UserID:      req.UserID,
-		TeamName:    req.TeamName,
+		HackathonID: hackathon.ID,
+		UserID:      userID,
 		Role:        req.Role,
-		Status:      "registered",
 		Notes:       req.Notes,
 	}
 
-	if err := h.db.Create(&participant).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create participant"})
+	// Take a seat, or join the waitlist when the hackathon is full
+	if err := h.db.Transaction(func(tx *gorm.DB) error { return registerParticipant(tx, &participant) }); err != nil {
+		if errors.Is(err, errAlreadyRegistered) {
+			c.JSON(http.StatusConflict, gin.H{"error": "User is already registered for this hackathon"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create participant"})
+		}
 		return
 	}
 
 	// Load relationships for response
 	h.db.Preload("User").Preload("Hackathon").First(&participant, participant.ID)
+	participants := []database.HackathonParticipant{participant}
+	fillWaitlistPositions(h.db, participants)
 
-	c.JSON(http.StatusCreated, participant)
+	c.JSON(http.StatusCreated, participants[0])
 }
 
 // ListParticipants handles GET /api/v1/hackathons/:id/participants
@@ -454,9 +551,9 @@ func (h *HackathonHandler) ListParticipants(c *gin.Context) {
 		query = query.Where("status = ?", status)
 	}
 
-	// Filter by team name if specified
-	if teamName := c.Query("team_name"); teamName != "" {
-		query = query.Where("team_name ILIKE ?", "%"+teamName+"%")
+	// Filter by team if specified
+	if teamID := c.Query("team_id"); teamID != "" {
+		query = query.Where("team_id = ?", teamID)
 	}
 
 	query = query.Order("registration_date DESC")
@@ -468,6 +565,10 @@ func (h *HackathonHandler) ListParticipants(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve participants"})
 		return
 	}
+	if err := fillWaitlistPositions(h.db, participants); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve participants"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"participants": participants,
@@ -480,7 +581,6 @@ func (h *HackathonHandler) ListParticipants(c *gin.Context) {
 }
 
 type UpdateParticipantRequest struct {
-	TeamName *string `json:"team_name,omitempty"`
 	Role     *string `json:"role,omitempty"`
 	Status   *string `json:"status,omitempty"`
 	Notes    *string `json:"notes,omitempty"`
@@ -508,27 +608,21 @@ func (h *HackathonHandler) UpdateParticipant(c *gin.Context) {
 		return
 	}
 
-	// Update fields if provided
-	if req.TeamName != nil {
-		participant.TeamName = *req.TeamName
-	}
+	previousStatus := participant.Status
 
+	// Update fields if provided
 	if req.Role != nil {
 		participant.Role = *req.Role
 	}
 
+	var hackathon database.Hackathon
+	if err := h.db.Select("id", "owner_id").First(&hackathon, participant.HackathonID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve hackathon"})
+		return
+	}
+	actor := transitionActor(c, participant.UserID, hackathon.OwnerID)
 	if req.Status != nil {
-		// Validate status
-		validStatuses := []string{"registered", "confirmed", "cancelled", "disqualified"}
-		valid := false
-		for _, status := range validStatuses {
-			if *req.Status == status {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of: registered, confirmed, cancelled, disqualified"})
+		if respondTransitionError(c, participantStatuses.Check(previousStatus, *req.Status, actor)) {
 			return
 		}
 		participant.Status = *req.Status
@@ -538,18 +632,34 @@ func (h *HackathonHandler) UpdateParticipant(c *gin.Context) {
 		participant.Notes = *req.Notes
 	}
 
-	if err := h.db.Save(&participant).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update participant"})
+	// Taking a seat needs a free one; giving one up promotes the waitlist
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		change := participantChange{tx: tx, participant: &participant}
+		return participantStatuses.Apply(change, previousStatus, participant.Status, actor, func() error {
+			return tx.Save(&participant).Error
+		})
+	})
+	if err != nil {
+		if errors.Is(err, errHackathonFull) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Hackathon is full"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update participant"})
+		}
 		return
 	}
 
 	// Load relationships for response
 	h.db.Preload("User").Preload("Hackathon").First(&participant, participant.ID)
+	participants := []database.HackathonParticipant{participant}
+	fillWaitlistPositions(h.db, participants)
 
-	c.JSON(http.StatusOK, participant)
+	c.JSON(http.StatusOK, participants[0])
 }
 
 // DeleteParticipant handles DELETE /api/v1/hackathons/:id/participants/:participant_id
+// Participants cancel their own registration through the status transitions so that a
+// disqualification cannot be undone by registering again; only the hackathon owner and
+// admins remove the registration outright.
 func (h *HackathonHandler) DeleteParticipant(c *gin.Context) {
 	hackathonID := c.Param("id")
 	participantID := c.Param("participant_id")
@@ -565,10 +675,56 @@ func (h *HackathonHandler) DeleteParticipant(c *gin.Context) {
 		return
 	}
 
-	if err := h.db.Delete(&participant).Error; err != nil {
+	var hackathon database.Hackathon
+	if err := h.db.Select("id", "owner_id").First(&hackathon, participant.HackathonID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve hackathon"})
+		return
+	}
+	actor := transitionActor(c, participant.UserID, hackathon.OwnerID)
+	if actor != domain.ActorOwner && actor != domain.ActorAdmin {
+		h.cancelParticipant(c, &participant, actor)
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := leaveTeam(tx, &participant); err != nil {
+			return err
+		}
+		if err := tx.Delete(&participant).Error; err != nil {
+			return err
+		}
+		if holdsSeat(participant.Status) {
+			_, err := promoteWaitlisted(tx, participant.HackathonID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete participant"})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Participant deleted successfully"})
+}
+
+// cancelParticipant moves a registration to cancelled on behalf of its participant
+func (h *HackathonHandler) cancelParticipant(c *gin.Context, participant *database.HackathonParticipant, actor domain.Actor) {
+	previousStatus := participant.Status
+	if respondTransitionError(c, participantStatuses.Check(previousStatus, domain.ParticipantCancelled, actor)) {
+		return
+	}
+	participant.Status = domain.ParticipantCancelled
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		change := participantChange{tx: tx, participant: participant}
+		return participantStatuses.Apply(change, previousStatus, participant.Status, actor, func() error {
+			return tx.Save(participant).Error
+		})
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel participant"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Participant registration cancelled successfully"})
 }
\ No newline at end of file
@@ -0,0 +1,48 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ListStatusTransitions handles GET /api/v1/hackathons/:id/status-transitions
+// Lists the status history of a hackathon, oldest first.
+func (h *HackathonHandler) ListStatusTransitions(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.db.Select("id").First(&database.Hackathon{}, id).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+
+	var transitions []database.HackathonStatusTransition
+	if err := h.db.Where("hackathon_id = ?", id).Order("id").Find(&transitions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve status transitions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transitions": transitions,
+		"count":       len(transitions),
+	})
+}
+
+// recordStatusTransition records a status change made through the API by the current user
+func recordStatusTransition(c *gin.Context, tx *gorm.DB, hackathonID uint, from, to string) error {
+	transition := database.HackathonStatusTransition{
+		HackathonID: hackathonID,
+		FromStatus:  from,
+		ToStatus:    to,
+		Source:      domain.TransitionSourceManual,
+	}
+	if user, ok := CurrentUser(c); ok {
+		transition.ActorID = &user.ID
+	}
+	return tx.Create(&transition).Error
+}
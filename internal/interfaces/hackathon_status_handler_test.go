@@ -0,0 +1,72 @@
+package interfaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestHackathonHandler_StatusTransitions(t *testing.T) {
+	_, handler, hackathon, users := setupWaitlistTest(t, 0)
+
+	cancelled := "cancelled"
+	w := participantRequest(handler, &users[0], "PUT", fmt.Sprintf("/hackathons/%d", hackathon.ID), UpdateHackathonRequest{Status: &cancelled})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The registration window is still open, but cancelled hackathons take no registrations
+	w = participantRequest(handler, &users[1], "POST", fmt.Sprintf("/hackathons/%d/participants", hackathon.ID), CreateParticipantRequest{HackathonID: hackathon.ID})
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+
+	// Updates that keep the status are not recorded
+	name := "Renamed"
+	w = participantRequest(handler, &users[0], "PUT", fmt.Sprintf("/hackathons/%d", hackathon.ID), UpdateHackathonRequest{Name: &name})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = participantRequest(handler, nil, "GET", fmt.Sprintf("/hackathons/%d/status-transitions", hackathon.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Transitions []database.HackathonStatusTransition `json:"transitions"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Transitions, 1)
+	assert.Equal(t, "upcoming", body.Transitions[0].FromStatus)
+	assert.Equal(t, "cancelled", body.Transitions[0].ToStatus)
+	assert.Equal(t, "manual", body.Transitions[0].Source)
+	require.NotNil(t, body.Transitions[0].ActorID)
+	assert.Equal(t, users[0].ID, *body.Transitions[0].ActorID)
+
+	assert.Equal(t, http.StatusNotFound, participantRequest(handler, nil, "GET", "/hackathons/999/status-transitions", nil).Code)
+}
+
+func TestApplyScheduledHackathonStatus(t *testing.T) {
+	db, _, hackathon, _ := setupWaitlistTest(t, 0)
+
+	persisted := 0
+	persist := func() error {
+		persisted++
+		return nil
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return ApplyScheduledHackathonStatus(tx, &hackathon, domain.HackathonStatusUpcoming, domain.HackathonStatusOngoing, persist)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, persisted)
+
+	var count int64
+	require.NoError(t, db.Model(&database.HackathonStatusTransition{}).Where("hackathon_id = ?", hackathon.ID).Count(&count).Error)
+	assert.Zero(t, count, "the scheduler records its own transitions")
+
+	t.Run("Transitions the system may not make are rejected", func(t *testing.T) {
+		err := ApplyScheduledHackathonStatus(db, &hackathon, domain.HackathonStatusOngoing, domain.HackathonStatusCancelled, persist)
+		assert.ErrorIs(t, err, domain.ErrTransitionForbidden)
+		assert.Equal(t, 1, persisted)
+	})
+}
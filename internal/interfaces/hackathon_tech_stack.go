@@ -0,0 +1,113 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"gorm.io/gorm"
+)
+
+// maxTechStackItems is the largest number of technologies a hackathon can list
+const maxTechStackItems = 20
+
+// maxTagNameLength is the size of the tags.name column
+const maxTagNameLength = 50
+
+// Tech stack filter modes of ListHackathons
+const (
+	techMatchAny = "any"
+	techMatchAll = "all"
+)
+
+// normalizeTechStack trims and validates tech stack names, dropping duplicates
+// that only differ in case
+func normalizeTechStack(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, errors.New("tech_stack items must not be empty")
+		}
+		if len(name) > maxTagNameLength {
+			return nil, fmt.Errorf("tech_stack items must be at most %d characters", maxTagNameLength)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+	if len(normalized) > maxTechStackItems {
+		return nil, fmt.Errorf("tech_stack must list at most %d items", maxTechStackItems)
+	}
+	return normalized, nil
+}
+
+// resolveTechStack returns the tags named by names, matched case-insensitively
+// and created when missing, in the order of names
+func resolveTechStack(tx *gorm.DB, names []string) ([]database.Tag, error) {
+	tags := make([]database.Tag, 0, len(names))
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = strings.ToLower(name)
+	}
+	var existing []database.Tag
+	if err := tx.Where("LOWER(name) IN ?", keys).Order("id").Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]database.Tag, len(existing))
+	for _, tag := range existing {
+		key := strings.ToLower(tag.Name)
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = tag
+		}
+	}
+
+	for i, name := range names {
+		tag, ok := byKey[keys[i]]
+		if !ok {
+			tag = database.Tag{Name: name}
+			if err := tx.Create(&tag).Error; err != nil {
+				return nil, err
+			}
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// parseTechFilter reads the tech stack names of the tech query parameter, given
+// either comma separated or repeated
+func parseTechFilter(values []string) ([]string, error) {
+	var names []string
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, strings.ToLower(name))
+			}
+		}
+	}
+	return normalizeTechStack(names)
+}
+
+// filterByTechStack restricts query to hackathons listing any or all of names,
+// which must be lower case
+func filterByTechStack(db, query *gorm.DB, names []string, match string) *gorm.DB {
+	matching := db.Table("hackathon_tags").
+		Select("hackathon_tags.hackathon_id").
+		Joins("JOIN tags ON tags.id = hackathon_tags.tag_id").
+		Where("LOWER(tags.name) IN ?", names)
+	if match == techMatchAll {
+		matching = matching.Group("hackathon_tags.hackathon_id").
+			Having("COUNT(DISTINCT LOWER(tags.name)) = ?", len(names))
+	}
+	return query.Where("id IN (?)", matching)
+}
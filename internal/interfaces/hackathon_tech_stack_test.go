@@ -0,0 +1,125 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHackathonHandler_TechStack(t *testing.T) {
+	db, handler, existing, users := setupWaitlistTest(t, 0)
+	require.NoError(t, db.AutoMigrate(&database.Tag{}))
+	require.NoError(t, db.Create(&database.Tag{Name: "Go"}).Error)
+
+	request := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(&users[0]))
+		r.POST("/hackathons", handler.CreateHackathon)
+		r.GET("/hackathons", handler.ListHackathons)
+		r.GET("/hackathons/:id", handler.GetHackathon)
+		r.PUT("/hackathons/:id", handler.UpdateHackathon)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	names := func(tags []database.Tag) []string {
+		result := make([]string, len(tags))
+		for i, tag := range tags {
+			result[i] = tag.Name
+		}
+		return result
+	}
+	create := func(name string, techStack []string) *httptest.ResponseRecorder {
+		now := time.Now()
+		return request("POST", "/hackathons", CreateHackathonRequest{
+			Name:                 name,
+			Organizer:            "Organizer",
+			StartDate:            now.Add(48 * time.Hour).Format(time.RFC3339),
+			EndDate:              now.Add(72 * time.Hour).Format(time.RFC3339),
+			RegistrationStart:    now.Format(time.RFC3339),
+			RegistrationDeadline: now.Add(24 * time.Hour).Format(time.RFC3339),
+			TechStack:            techStack,
+		})
+	}
+	list := func(query string) []string {
+		w := request("GET", "/hackathons?"+query, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			Hackathons []database.Hackathon `json:"hackathons"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		var result []string
+		for _, hackathon := range body.Hackathons {
+			result = append(result, hackathon.Name)
+		}
+		return result
+	}
+
+	t.Run("Create links existing and new tags", func(t *testing.T) {
+		w := create("Backend", []string{" go ", "PostgreSQL", "GO"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var hackathon database.Hackathon
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hackathon))
+		assert.Equal(t, []string{"Go", "PostgreSQL"}, names(hackathon.TechStack))
+
+		var count int64
+		db.Model(&database.Tag{}).Count(&count)
+		assert.Equal(t, int64(2), count, "tags are matched case-insensitively")
+
+		w = create("Frontend", []string{"React", "postgresql"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	})
+
+	t.Run("Invalid tech stacks are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, create("Blank", []string{"Go", " "}).Code)
+		long := string(bytes.Repeat([]byte("x"), 51))
+		assert.Equal(t, http.StatusBadRequest, create("Long", []string{long}).Code)
+		tooMany := make([]string, maxTechStackItems+1)
+		for i := range tooMany {
+			tooMany[i] = fmt.Sprintf("tech-%d", i)
+		}
+		assert.Equal(t, http.StatusBadRequest, create("Many", tooMany).Code)
+	})
+
+	t.Run("List filters by any or all technologies", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Backend", "Frontend"}, list("tech=go,react"))
+		assert.ElementsMatch(t, []string{"Backend", "Frontend"}, list("tech=POSTGRESQL"))
+		assert.Equal(t, []string{"Frontend"}, list("tech=react&tech=postgresql&tech_match=all"))
+		assert.Empty(t, list("tech=go,react&tech_match=all"))
+		assert.Len(t, list(""), 3)
+		assert.Equal(t, http.StatusBadRequest, request("GET", "/hackathons?tech=go&tech_match=some", nil).Code)
+	})
+
+	t.Run("Update replaces the tech stack", func(t *testing.T) {
+		path := fmt.Sprintf("/hackathons/%d", existing.ID)
+		w := request("PUT", path, UpdateHackathonRequest{TechStack: []string{"Rust", "go"}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var hackathon database.Hackathon
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hackathon))
+		assert.Equal(t, []string{"Rust", "Go"}, names(hackathon.TechStack))
+
+		name := "Renamed"
+		w = request("PUT", path, UpdateHackathonRequest{Name: &name})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hackathon))
+		assert.ElementsMatch(t, []string{"Rust", "Go"}, names(hackathon.TechStack), "omitted tech stacks are kept")
+
+		w = request("PUT", path, UpdateHackathonRequest{TechStack: []string{}})
+		require.Equal(t, http.StatusOK, w.Code)
+		w = request("GET", path, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"tech_stack":[]`)
+	})
+}
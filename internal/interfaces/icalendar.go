@@ -0,0 +1,144 @@
+package interfaces
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// calendarUIDDomain qualifies the UIDs of exported events. UIDs only depend on
+// the record they are derived from so calendar clients update events in place.
+const calendarUIDDomain = "tru-s3-backend"
+
+// icalTimeFormat is the RFC 5545 UTC date-time format
+const icalTimeFormat = "20060102T150405Z"
+
+// icalMaxLineOctets is the length at which content lines are folded
+const icalMaxLineOctets = 75
+
+// calendarEvent is an event exported as an RFC 5545 VEVENT
+type calendarEvent struct {
+	uid         string
+	summary     string
+	description string
+	location    string
+	url         string
+	start       time.Time
+	end         time.Time // zero for a deadline, which has no duration
+	cancelled   bool
+	reminder    time.Duration // alarm this long before the deadline or end; zero for none
+	modified    time.Time
+}
+
+// calendarUID returns the stable UID of an event derived from a record, e.g.
+// calendarUID("hackathon", 3, "registration") is hackathon-3-registration@tru-s3-backend
+func calendarUID(kind string, id uint, part string) string {
+	uid := fmt.Sprintf("%s-%d", kind, id)
+	if part != "" {
+		uid += "-" + part
+	}
+	return uid + "@" + calendarUIDDomain
+}
+
+// writeICalendar writes events as an RFC 5545 calendar named name
+func writeICalendar(w io.Writer, name string, events []calendarEvent) error {
+	bw := bufio.NewWriter(w)
+	line := func(content string) {
+		writeICalLine(bw, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//TRu-S3//Backend//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + icalText(name))
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.uid)
+		line("DTSTAMP:" + icalTime(e.modified))
+		line("LAST-MODIFIED:" + icalTime(e.modified))
+		line("DTSTART:" + icalTime(e.start))
+		if !e.end.IsZero() {
+			line("DTEND:" + icalTime(e.end))
+		}
+		line("SUMMARY:" + icalText(e.summary))
+		if e.description != "" {
+			line("DESCRIPTION:" + icalText(e.description))
+		}
+		if e.location != "" {
+			line("LOCATION:" + icalText(e.location))
+		}
+		if e.url != "" {
+			line("URL:" + e.url)
+		}
+		if e.cancelled {
+			line("STATUS:CANCELLED")
+		} else {
+			line("STATUS:CONFIRMED")
+		}
+		if e.reminder > 0 && !e.cancelled {
+			related := ""
+			if !e.end.IsZero() {
+				related = ";RELATED=END"
+			}
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:" + icalText(e.summary))
+			line("TRIGGER" + related + ":-" + icalDuration(e.reminder))
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// writeICalLine writes a content line, folding it at 75 octets without splitting
+// UTF-8 sequences
+func writeICalLine(w *bufio.Writer, content string) {
+	limit := icalMaxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		limit = icalMaxLineOctets - 1 // continuation lines start with a space
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
+
+// icalText escapes a TEXT property value
+func icalText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// icalTime formats a time as an RFC 5545 UTC date-time
+func icalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeFormat)
+}
+
+// icalDuration formats a positive duration as an RFC 5545 duration
+func icalDuration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	}
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("PT%dH", d/time.Hour)
+	}
+	return fmt.Sprintf("PT%dM", d/time.Minute)
+}
@@ -0,0 +1,275 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ImageHandler handles profile avatar and hackathon banner uploads and downloads
+type ImageHandler struct {
+	*BaseHandler
+	fileService *application.FileService
+	files       *FileHandler
+}
+
+// NewImageHandler creates a new ImageHandler
+func NewImageHandler(db *gorm.DB, fileService *application.FileService) *ImageHandler {
+	return &ImageHandler{
+		BaseHandler: NewBaseHandler(db),
+		fileService: fileService,
+		files:       NewFileHandler(fileService),
+	}
+}
+
+// GetAvatar handles GET /api/v1/profiles/:id/avatar
+// The optional size query parameter selects a thumbnail.
+func (h *ImageHandler) GetAvatar(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var profile database.Profile
+	if err := h.db.First(&profile, id).Error; err != nil {
+		h.HandleDBError(c, err, "Profile")
+		return
+	}
+	h.serveImage(c, profile.Avatar, "Avatar")
+}
+
+// UploadAvatar handles PUT /api/v1/profiles/:id/avatar
+func (h *ImageHandler) UploadAvatar(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var profile database.Profile
+	if err := h.db.First(&profile, id).Error; err != nil {
+		h.HandleDBError(c, err, "Profile")
+		return
+	}
+
+	ref, ok := h.storeImage(c, fmt.Sprintf("profiles/%d/avatar", id), domain.FileAttachment{Type: domain.AttachmentProfile, ID: id})
+	if !ok {
+		return
+	}
+	if !h.replaceImage(c, &profile, "avatar", profile.Avatar, ref) {
+		return
+	}
+	profile.Avatar = ref
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteAvatar handles DELETE /api/v1/profiles/:id/avatar
+func (h *ImageHandler) DeleteAvatar(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var profile database.Profile
+	if err := h.db.First(&profile, id).Error; err != nil {
+		h.HandleDBError(c, err, "Profile")
+		return
+	}
+	if profile.Avatar == nil {
+		h.HandleNotFound(c, "Avatar")
+		return
+	}
+
+	if !h.replaceImage(c, &profile, "avatar", profile.Avatar, nil) {
+		return
+	}
+	h.HandleSuccess(c, "Avatar deleted successfully")
+}
+
+// UploadBanner handles PUT /api/v1/hackathons/:id/banner
+func (h *ImageHandler) UploadBanner(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var hackathon database.Hackathon
+	if err := h.db.First(&hackathon, id).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+
+	ref, ok := h.storeImage(c, fmt.Sprintf("hackathons/%d/banner", id), domain.FileAttachment{Type: domain.AttachmentHackathon, ID: id})
+	if !ok {
+		return
+	}
+	if !h.replaceImage(c, &hackathon, "banner", hackathon.Banner, ref) {
+		return
+	}
+	hackathon.Banner = ref
+
+	c.JSON(http.StatusOK, hackathon)
+}
+
+// GetBanner handles GET /api/v1/hackathons/:id/banner
+// The optional size query parameter selects a thumbnail.
+func (h *ImageHandler) GetBanner(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var hackathon database.Hackathon
+	if err := h.db.First(&hackathon, id).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+	h.serveImage(c, hackathon.Banner, "Banner")
+}
+
+// DeleteBanner handles DELETE /api/v1/hackathons/:id/banner
+func (h *ImageHandler) DeleteBanner(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var hackathon database.Hackathon
+	if err := h.db.First(&hackathon, id).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+	if hackathon.Banner == nil {
+		h.HandleNotFound(c, "Banner")
+		return
+	}
+
+	if !h.replaceImage(c, &hackathon, "banner", hackathon.Banner, nil) {
+		return
+	}
+	h.HandleSuccess(c, "Banner deleted successfully")
+}
+
+// storeImage streams the "file" part of the multipart body through the image
+// pipeline and returns a reference to the stored renditions
+func (h *ImageHandler) storeImage(c *gin.Context, dir string, attachment domain.FileAttachment) (*database.ImageRef, bool) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
+		return nil, false
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "No file provided"})
+			return nil, false
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse multipart form"})
+			return nil, false
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		stored, err := h.fileService.UploadImage(c.Request.Context(), &domain.UploadImageRequest{
+			Dir:     dir,
+			Content: part,
+			Access: &domain.FileAccessRequest{
+				Uploader:   fileViewer(c),
+				Visibility: domain.FileVisibilityPublic,
+				Attachment: &attachment,
+			},
+		})
+		part.Close()
+		if err != nil {
+			handleImageError(c, err)
+			return nil, false
+		}
+
+		ref := &database.ImageRef{FileID: stored.Original.ID, Thumbnails: make(map[string]string, len(stored.Thumbnails))}
+		for size, file := range stored.Thumbnails {
+			ref.Thumbnails[size] = file.ID
+		}
+		return ref, true
+	}
+}
+
+// serveImage streams the original of ref, or the thumbnail named by the size query parameter
+func (h *ImageHandler) serveImage(c *gin.Context, ref *database.ImageRef, name string) {
+	if ref == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": name + " not found"})
+		return
+	}
+	fileID := ref.FileID
+	if size := c.Query("size"); size != "" {
+		thumbnail, ok := ref.Thumbnails[size]
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Thumbnail not found"})
+			return
+		}
+		fileID = thumbnail
+	}
+
+	file, err := h.fileService.GetFile(c.Request.Context(), fileID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": name + " not found"})
+			return
+		}
+		log.Printf("Failed to get %s file: %v", name, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get file"})
+		return
+	}
+	if !h.files.authorizeRead(c, file) || !authorizeDownload(c, file) {
+		return
+	}
+	h.files.serveFile(c, file)
+}
+
+// replaceImage stores ref in the image column of model and deletes the files of
+// the previous image. If the column cannot be updated the new files are removed.
+func (h *ImageHandler) replaceImage(c *gin.Context, model interface{}, column string, previous, ref *database.ImageRef) bool {
+	if err := h.db.Model(model).Update(column, ref).Error; err != nil {
+		if ref != nil {
+			if cleanupErr := h.fileService.DeleteFiles(c.Request.Context(), ref.FileIDs()...); cleanupErr != nil {
+				log.Printf("Failed to remove unused %s files: %v", column, cleanupErr)
+			}
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update " + column})
+		return false
+	}
+
+	if previous != nil {
+		if err := h.fileService.DeleteFiles(c.Request.Context(), previous.FileIDs()...); err != nil {
+			log.Printf("Failed to remove previous %s files: %v", column, err)
+		}
+	}
+	return true
+}
+
+// handleImageError maps image upload errors to responses
+func handleImageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedImage):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Unsupported image. Upload a JPEG, PNG, GIF or WebP image"})
+	case errors.Is(err, domain.ErrImageTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image is too large"})
+	default:
+		if handleFileAccessError(c, err) || handleUploadPolicyError(c, err) || handleScanError(c, err) {
+			return
+		}
+		log.Printf("Failed to upload image: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload image"})
+	}
+}
@@ -0,0 +1,146 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestImageHandler_Avatar(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}, &database.User{}, &database.Profile{}))
+
+	owner := database.User{Name: "Owner", Gmail: "owner@example.com", Role: "user"}
+	require.NoError(t, db.Create(&owner).Error)
+	profile := database.Profile{UserID: owner.ID}
+	require.NoError(t, db.Create(&profile).Error)
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileService := application.NewFileService(repo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		application.WithAccessRepository(infrastructure.NewGormFileAccessRepository(db)),
+		application.WithImageProcessor(infrastructure.NewImageProcessor([]int{64, 256}, infrastructure.DefaultMaxImageBytes, infrastructure.DefaultMaxImagePixels)),
+	)
+	handler := NewImageHandler(db, fileService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(withUser(&owner))
+	r.GET("/profiles/:id/avatar", handler.GetAvatar)
+	r.PUT("/profiles/:id/avatar", handler.UploadAvatar)
+	r.DELETE("/profiles/:id/avatar", handler.DeleteAvatar)
+
+	upload := func(filename string, content []byte) *httptest.ResponseRecorder {
+		body, contentType := multipartFileBody(t, nil, filename, content)
+		req, _ := http.NewRequest("PUT", "/profiles/1/avatar", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	fileExists := func(id string) bool {
+		_, err := fileService.GetFile(context.Background(), id)
+		return err == nil
+	}
+
+	w := upload("avatar.png", testPNG(t, 512, 512))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response database.Profile
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Avatar)
+	first := *response.Avatar
+	assert.Len(t, first.Thumbnails, 2)
+
+	thumbnail, err := fileService.GetFile(context.Background(), first.Thumbnails["64"])
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", thumbnail.ContentType)
+	assert.Equal(t, domain.AttachmentProfile, thumbnail.Attachment.Type)
+	require.NotNil(t, thumbnail.UploaderID)
+	assert.Equal(t, owner.ID, *thumbnail.UploaderID)
+
+	var stored database.Profile
+	require.NoError(t, db.First(&stored, profile.ID).Error)
+	require.NotNil(t, stored.Avatar)
+	assert.Equal(t, first.FileID, stored.Avatar.FileID)
+
+	t.Run("Download the uploaded avatar", func(t *testing.T) {
+		download := func(path string) *httptest.ResponseRecorder {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		w := download("/profiles/1/avatar")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		decoded, err := png.Decode(w.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 512, decoded.Bounds().Dx())
+
+		w = download("/profiles/1/avatar?size=64")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		decoded, err = png.Decode(w.Body)
+		require.NoError(t, err)
+		assert.Equal(t, 64, decoded.Bounds().Dx())
+
+		assert.Equal(t, http.StatusNotFound, download("/profiles/1/avatar?size=1024").Code)
+		assert.Equal(t, http.StatusNotFound, download("/profiles/99/avatar").Code)
+	})
+
+	t.Run("Replacing removes the previous image", func(t *testing.T) {
+		w := upload("avatar.png", testPNG(t, 128, 128))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		for _, id := range first.FileIDs() {
+			assert.False(t, fileExists(id), id)
+		}
+	})
+
+	t.Run("Non-image content is rejected", func(t *testing.T) {
+		w := upload("avatar.png", []byte("definitely not a png"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, db.First(&stored, profile.ID).Error)
+		req, _ := http.NewRequest("DELETE", "/profiles/1/avatar", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		for _, id := range stored.Avatar.FileIDs() {
+			assert.False(t, fileExists(id), id)
+		}
+		var deleted database.Profile
+		require.NoError(t, db.First(&deleted, profile.ID).Error)
+		assert.Nil(t, deleted.Avatar)
+
+		req, _ = http.NewRequest("GET", "/profiles/1/avatar", nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
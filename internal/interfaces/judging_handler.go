@@ -0,0 +1,710 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	userDB "github.com/TRu-S3/backend/internal/database/user"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var (
+	errJudgeExists          = errors.New("user is already a judge")
+	errInvalidCriterion     = errors.New("invalid judging criterion")
+	errUnknownCriterion     = errors.New("criterion does not belong to the hackathon")
+	errScoreOutOfRange      = errors.New("score is out of range")
+	errConflictOfInterest   = errors.New("judge belongs to the submitting team")
+	errResultsPublished     = errors.New("results have been published")
+	errSubmissionsStillOpen = errors.New("submissions are still open")
+	errNoCriteria           = errors.New("hackathon has no judging criteria")
+	errResultsNotPublished  = errors.New("results have not been published")
+)
+
+// JudgingHandler handles HTTP requests for judging hackathon submissions
+type JudgingHandler struct {
+	*BaseHandler
+}
+
+// NewJudgingHandler creates a new JudgingHandler
+func NewJudgingHandler(db *gorm.DB) *JudgingHandler {
+	return &JudgingHandler{
+		BaseHandler: NewBaseHandler(db),
+	}
+}
+
+type AddJudgeRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+type CreateCriterionRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Weight      *float64 `json:"weight,omitempty"`
+	MaxScore    *int     `json:"max_score,omitempty"`
+}
+
+type UpdateCriterionRequest struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Weight      *float64 `json:"weight,omitempty"`
+	MaxScore    *int     `json:"max_score,omitempty"`
+}
+
+type CriterionScoreRequest struct {
+	CriterionID uint     `json:"criterion_id" binding:"required"`
+	Score       *float64 `json:"score" binding:"required"`
+}
+
+type SaveScoreSheetRequest struct {
+	Comment string                  `json:"comment"`
+	Scores  []CriterionScoreRequest `json:"scores" binding:"dive"`
+}
+
+// JudgingSubmission is a submission as shown to a judge, with the judge's score sheet
+type JudgingSubmission struct {
+	database.Submission
+	ScoreSheet *database.ScoreSheet `json:"score_sheet"`
+}
+
+// ResultEntry is one row of the hackathon leaderboard
+type ResultEntry struct {
+	domain.SubmissionRanking
+	TeamID   uint   `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Title    string `json:"title"`
+}
+
+// AddJudge handles POST /api/v1/hackathons/:id/judges
+func (h *JudgingHandler) AddJudge(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req AddJudgeRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	judge := database.HackathonJudge{HackathonID: hackathonID, UserID: req.UserID}
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select("id").First(&database.Hackathon{}, hackathonID).Error; err != nil {
+			return err
+		}
+		if err := tx.Select("id").First(&database.User{}, req.UserID).Error; err != nil {
+			return err
+		}
+		var existing int64
+		if err := tx.Model(&database.HackathonJudge{}).Where("hackathon_id = ? AND user_id = ?", hackathonID, req.UserID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errJudgeExists
+		}
+		return tx.Create(&judge).Error
+	})
+	if err != nil {
+		handleJudgingError(c, err, "Failed to add judge")
+		return
+	}
+
+	h.db.Preload("User").First(&judge, judge.ID)
+	c.JSON(http.StatusCreated, judge)
+}
+
+// ListJudges handles GET /api/v1/hackathons/:id/judges
+func (h *JudgingHandler) ListJudges(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var judges []database.HackathonJudge
+	if err := h.db.Preload("User").Where("hackathon_id = ?", hackathonID).Order("id").Find(&judges).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve judges"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"judges": judges,
+		"count":  len(judges),
+	})
+}
+
+// RemoveJudge handles DELETE /api/v1/hackathons/:id/judges/:user_id
+// Score sheets of a removed judge no longer count towards the results.
+func (h *JudgingHandler) RemoveJudge(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := h.ParseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	result := h.db.Where("hackathon_id = ? AND user_id = ?", hackathonID, userID).Delete(&database.HackathonJudge{})
+	if result.Error != nil {
+		handleJudgingError(c, result.Error, "Failed to remove judge")
+		return
+	}
+	if result.RowsAffected == 0 {
+		h.HandleNotFound(c, "Judge")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Judge removed successfully"})
+}
+
+// CreateCriterion handles POST /api/v1/hackathons/:id/criteria
+func (h *JudgingHandler) CreateCriterion(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req CreateCriterionRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	criterion := database.JudgingCriterion{
+		HackathonID: hackathonID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Weight:      1,
+		MaxScore:    10,
+	}
+	if req.Weight != nil {
+		criterion.Weight = *req.Weight
+	}
+	if req.MaxScore != nil {
+		criterion.MaxScore = *req.MaxScore
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkResultsUnpublished(tx, hackathonID); err != nil {
+			return err
+		}
+		if err := validateCriterion(&criterion); err != nil {
+			return err
+		}
+		return tx.Create(&criterion).Error
+	})
+	if err != nil {
+		handleJudgingError(c, err, "Failed to create criterion")
+		return
+	}
+
+	c.JSON(http.StatusCreated, criterion)
+}
+
+// ListCriteria handles GET /api/v1/hackathons/:id/criteria
+func (h *JudgingHandler) ListCriteria(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.db.Select("id").First(&database.Hackathon{}, hackathonID).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+
+	criteria, err := hackathonCriteria(h.db, hackathonID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve criteria"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"criteria": criteria,
+		"count":    len(criteria),
+	})
+}
+
+// UpdateCriterion handles PUT /api/v1/hackathons/:id/criteria/:criterion_id
+func (h *JudgingHandler) UpdateCriterion(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	criterionID, ok := h.ParseIDParam(c, "criterion_id")
+	if !ok {
+		return
+	}
+	var req UpdateCriterionRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	var criterion database.JudgingCriterion
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkResultsUnpublished(tx, hackathonID); err != nil {
+			return err
+		}
+		if err := tx.Where("hackathon_id = ? AND id = ?", hackathonID, criterionID).First(&criterion).Error; err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			criterion.Name = strings.TrimSpace(*req.Name)
+		}
+		if req.Description != nil {
+			criterion.Description = *req.Description
+		}
+		if req.Weight != nil {
+			criterion.Weight = *req.Weight
+		}
+		if req.MaxScore != nil {
+			criterion.MaxScore = *req.MaxScore
+		}
+		if err := validateCriterion(&criterion); err != nil {
+			return err
+		}
+		return tx.Save(&criterion).Error
+	})
+	if err != nil {
+		handleJudgingError(c, err, "Failed to update criterion")
+		return
+	}
+
+	c.JSON(http.StatusOK, criterion)
+}
+
+// DeleteCriterion handles DELETE /api/v1/hackathons/:id/criteria/:criterion_id
+func (h *JudgingHandler) DeleteCriterion(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	criterionID, ok := h.ParseIDParam(c, "criterion_id")
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkResultsUnpublished(tx, hackathonID); err != nil {
+			return err
+		}
+		if err := tx.Where("criterion_id = ?", criterionID).Delete(&database.CriterionScore{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("hackathon_id = ? AND id = ?", hackathonID, criterionID).Delete(&database.JudgingCriterion{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		handleJudgingError(c, err, "Failed to delete criterion")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Criterion deleted successfully"})
+}
+
+// ListJudgingSubmissions handles GET /api/v1/hackathons/:id/judging/submissions
+// Lists the submissions the current judge may score together with their score
+// sheets, leaving out the submission of any team the judge belongs to.
+func (h *JudgingHandler) ListJudgingSubmissions(c *gin.Context) {
+	judge, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var submissions []database.Submission
+	err := h.db.Where("hackathon_id = ?", hackathonID).
+		Where("team_id NOT IN (?)", judgeTeams(h.db, judge.ID)).
+		Preload("Team", selectTeamSummary).
+		Order("id").
+		Find(&submissions).Error
+	if err == nil {
+		err = loadSubmissionFiles(h.db, submissions)
+	}
+	var sheets []database.ScoreSheet
+	if err == nil && len(submissions) > 0 {
+		ids := make([]uint, len(submissions))
+		for i, s := range submissions {
+			ids[i] = s.ID
+		}
+		err = h.db.Preload("Scores").Where("judge_id = ? AND submission_id IN ?", judge.ID, ids).Find(&sheets).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve submissions"})
+		return
+	}
+
+	bySubmission := make(map[uint]*database.ScoreSheet, len(sheets))
+	for i := range sheets {
+		bySubmission[sheets[i].SubmissionID] = &sheets[i]
+	}
+	result := make([]JudgingSubmission, len(submissions))
+	for i, s := range submissions {
+		result[i] = JudgingSubmission{Submission: s, ScoreSheet: bySubmission[s.ID]}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"submissions": result,
+		"count":       len(result),
+	})
+}
+
+// GetScoreSheet handles GET /api/v1/hackathons/:id/submissions/:submission_id/score-sheet
+func (h *JudgingHandler) GetScoreSheet(c *gin.Context) {
+	judge, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	submission, ok := h.lookupSubmission(c)
+	if !ok {
+		return
+	}
+
+	var sheet database.ScoreSheet
+	if err := h.db.Preload("Scores").Where("submission_id = ? AND judge_id = ?", submission.ID, judge.ID).First(&sheet).Error; err != nil {
+		h.HandleDBError(c, err, "Score sheet")
+		return
+	}
+	c.JSON(http.StatusOK, sheet)
+}
+
+// SaveScoreSheet handles PUT /api/v1/hackathons/:id/submissions/:submission_id/score-sheet
+// Creates or replaces the current judge's score sheet for a submission. Judges
+// cannot score a team they belong to, and sheets lock once results are published.
+func (h *JudgingHandler) SaveScoreSheet(c *gin.Context) {
+	judge, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	submission, ok := h.lookupSubmission(c)
+	if !ok {
+		return
+	}
+	var req SaveScoreSheetRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	var sheet database.ScoreSheet
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkResultsUnpublished(tx, submission.HackathonID); err != nil {
+			return err
+		}
+		conflicted, err := judgeConflicted(tx, judge.ID, submission.TeamID)
+		if err != nil {
+			return err
+		}
+		if conflicted {
+			return errConflictOfInterest
+		}
+
+		criteria, err := hackathonCriteria(tx, submission.HackathonID)
+		if err != nil {
+			return err
+		}
+		maxScores := make(map[uint]int, len(criteria))
+		for _, criterion := range criteria {
+			maxScores[criterion.ID] = criterion.MaxScore
+		}
+		scores := make([]database.CriterionScore, 0, len(req.Scores))
+		seen := make(map[uint]bool, len(req.Scores))
+		for _, s := range req.Scores {
+			maxScore, ok := maxScores[s.CriterionID]
+			if !ok || seen[s.CriterionID] {
+				return errUnknownCriterion
+			}
+			if *s.Score < 0 || *s.Score > float64(maxScore) {
+				return errScoreOutOfRange
+			}
+			seen[s.CriterionID] = true
+			scores = append(scores, database.CriterionScore{CriterionID: s.CriterionID, Score: *s.Score})
+		}
+
+		err = tx.Where("submission_id = ? AND judge_id = ?", submission.ID, judge.ID).First(&sheet).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			sheet = database.ScoreSheet{SubmissionID: submission.ID, JudgeID: judge.ID}
+		case err != nil:
+			return err
+		}
+		sheet.Comment = req.Comment
+		if err := tx.Omit("Scores").Save(&sheet).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("score_sheet_id = ?", sheet.ID).Delete(&database.CriterionScore{}).Error; err != nil {
+			return err
+		}
+		for i := range scores {
+			scores[i].ScoreSheetID = sheet.ID
+		}
+		if len(scores) > 0 {
+			if err := tx.Create(&scores).Error; err != nil {
+				return err
+			}
+		}
+		sheet.Scores = scores
+		return nil
+	})
+	if err != nil {
+		handleJudgingError(c, err, "Failed to save score sheet")
+		return
+	}
+
+	c.JSON(http.StatusOK, sheet)
+}
+
+// GetResults handles GET /api/v1/hackathons/:id/results
+// The leaderboard is public once published; until then only the hackathon owner
+// and admins can preview it.
+func (h *JudgingHandler) GetResults(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var hackathon database.Hackathon
+	if err := h.db.Select("id", "owner_id", "results_published_at").First(&hackathon, hackathonID).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return
+	}
+	if hackathon.ResultsPublishedAt == nil {
+		user, ok := CurrentUser(c)
+		if !ok || !(user.IsAdmin() || hackathon.OwnerID != nil && *hackathon.OwnerID == user.ID) {
+			handleJudgingError(c, errResultsNotPublished, "Failed to retrieve results")
+			return
+		}
+	}
+
+	criteria, results, err := hackathonResults(h.db, hackathonID)
+	if err != nil {
+		handleJudgingError(c, err, "Failed to retrieve results")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hackathon_id": hackathonID,
+		"published_at": hackathon.ResultsPublishedAt,
+		"criteria":     criteria,
+		"results":      results,
+		"count":        len(results),
+	})
+}
+
+// PublishResults handles POST /api/v1/hackathons/:id/results/publish
+// Publishing requires submissions to be closed, makes the leaderboard public,
+// locks criteria and score sheets and notifies the members of submitting teams.
+func (h *JudgingHandler) PublishResults(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var hackathon database.Hackathon
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&hackathon, hackathonID).Error; err != nil {
+			return err
+		}
+		if hackathon.ResultsPublishedAt != nil {
+			return errResultsPublished
+		}
+		if domain.SubmissionsOpen(hackathon.Status, hackathon.SubmissionDeadline, hackathon.EndDate, time.Now()) {
+			return errSubmissionsStillOpen
+		}
+		var criteria int64
+		if err := tx.Model(&database.JudgingCriterion{}).Where("hackathon_id = ?", hackathonID).Count(&criteria).Error; err != nil {
+			return err
+		}
+		if criteria == 0 {
+			return errNoCriteria
+		}
+
+		now := time.Now()
+		if err := tx.Model(&hackathon).Update("results_published_at", now).Error; err != nil {
+			return err
+		}
+		hackathon.ResultsPublishedAt = &now
+
+		var userIDs []uint
+		err := tx.Model(&database.HackathonParticipant{}).
+			Where("hackathon_id = ? AND status NOT IN ?", hackathonID, inactiveParticipantStatuses).
+			Where("team_id IN (?)", tx.Model(&database.Submission{}).Select("team_id").Where("hackathon_id = ?", hackathonID)).
+			Pluck("user_id", &userIDs).Error
+		if err != nil {
+			return err
+		}
+		message := fmt.Sprintf("Results of %s have been published.", hackathon.Name)
+		for _, userID := range userIDs {
+			if err := notifyUser(tx, userID, userDB.NotificationResultsPublished, message, "hackathon", hackathonID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		handleJudgingError(c, err, "Failed to publish results")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Results published successfully",
+		"published_at": hackathon.ResultsPublishedAt,
+	})
+}
+
+// lookupSubmission loads the submission addressed by :submission_id within the hackathon addressed by :id
+func (h *JudgingHandler) lookupSubmission(c *gin.Context) (*database.Submission, bool) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+	submissionID, ok := h.ParseIDParam(c, "submission_id")
+	if !ok {
+		return nil, false
+	}
+
+	var submission database.Submission
+	if err := h.db.Select("id", "hackathon_id", "team_id").Where("hackathon_id = ? AND id = ?", hackathonID, submissionID).First(&submission).Error; err != nil {
+		h.HandleDBError(c, err, "Submission")
+		return nil, false
+	}
+	return &submission, true
+}
+
+// hackathonCriteria returns the judging criteria of a hackathon in creation order
+func hackathonCriteria(db *gorm.DB, hackathonID uint) ([]database.JudgingCriterion, error) {
+	var criteria []database.JudgingCriterion
+	err := db.Where("hackathon_id = ?", hackathonID).Order("id").Find(&criteria).Error
+	return criteria, err
+}
+
+// hackathonResults ranks the submissions of a hackathon using the score sheets
+// of its current judges. Sheets of judges who have since joined the scored team
+// are left out, like the sheets they can no longer submit.
+func hackathonResults(db *gorm.DB, hackathonID uint) ([]database.JudgingCriterion, []ResultEntry, error) {
+	criteria, err := hackathonCriteria(db, hackathonID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var submissions []database.Submission
+	if err := db.Where("hackathon_id = ?", hackathonID).Preload("Team", selectTeamSummary).Order("id").Find(&submissions).Error; err != nil {
+		return nil, nil, err
+	}
+	var sheets []database.ScoreSheet
+	err = db.Preload("Scores").
+		Joins("JOIN submissions ON submissions.id = score_sheets.submission_id").
+		Joins("JOIN hackathon_judges ON hackathon_judges.hackathon_id = submissions.hackathon_id AND hackathon_judges.user_id = score_sheets.judge_id").
+		Where("submissions.hackathon_id = ?", hackathonID).
+		Where("NOT EXISTS (?)", db.Model(&database.HackathonParticipant{}).
+			Select("1").
+			Where("hackathon_participants.user_id = score_sheets.judge_id AND hackathon_participants.team_id = submissions.team_id AND hackathon_participants.status NOT IN ?", inactiveParticipantStatuses)).
+		Find(&sheets).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	domainCriteria := make([]domain.JudgingCriterion, len(criteria))
+	for i, criterion := range criteria {
+		domainCriteria[i] = domain.JudgingCriterion{ID: criterion.ID, Weight: criterion.Weight, MaxScore: float64(criterion.MaxScore)}
+	}
+	submissionIDs := make([]uint, len(submissions))
+	bySubmission := make(map[uint]database.Submission, len(submissions))
+	for i, s := range submissions {
+		submissionIDs[i] = s.ID
+		bySubmission[s.ID] = s
+	}
+	domainSheets := make([]domain.ScoreSheet, len(sheets))
+	for i, sheet := range sheets {
+		scores := make(map[uint]float64, len(sheet.Scores))
+		for _, score := range sheet.Scores {
+			scores[score.CriterionID] = score.Score
+		}
+		domainSheets[i] = domain.ScoreSheet{SubmissionID: sheet.SubmissionID, JudgeID: sheet.JudgeID, Scores: scores}
+	}
+
+	rankings := domain.RankSubmissions(domainCriteria, submissionIDs, domainSheets)
+	results := make([]ResultEntry, len(rankings))
+	for i, ranking := range rankings {
+		submission := bySubmission[ranking.SubmissionID]
+		results[i] = ResultEntry{SubmissionRanking: ranking, TeamID: submission.TeamID, Title: submission.Title}
+		if submission.Team != nil {
+			results[i].TeamName = submission.Team.Name
+		}
+	}
+	return criteria, results, nil
+}
+
+// judgeTeams selects the teams a user is an active member of in any hackathon
+func judgeTeams(db *gorm.DB, userID uint) *gorm.DB {
+	return db.Model(&database.HackathonParticipant{}).
+		Select("team_id").
+		Where("user_id = ? AND team_id IS NOT NULL AND status NOT IN ?", userID, inactiveParticipantStatuses)
+}
+
+// judgeConflicted reports whether a judge is an active member of a team
+func judgeConflicted(tx *gorm.DB, judgeID, teamID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&database.HackathonParticipant{}).
+		Where("user_id = ? AND team_id = ? AND status NOT IN ?", judgeID, teamID, inactiveParticipantStatuses).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// checkResultsUnpublished returns errResultsPublished once the results of the hackathon are public
+func checkResultsUnpublished(tx *gorm.DB, hackathonID uint) error {
+	var hackathon database.Hackathon
+	if err := tx.Select("id", "results_published_at").First(&hackathon, hackathonID).Error; err != nil {
+		return err
+	}
+	if hackathon.ResultsPublishedAt != nil {
+		return errResultsPublished
+	}
+	return nil
+}
+
+// validateCriterion checks the name, weight and maximum score of a criterion
+func validateCriterion(criterion *database.JudgingCriterion) error {
+	if criterion.Name == "" || len(criterion.Name) > 100 || criterion.Weight <= 0 || criterion.MaxScore < 1 {
+		return errInvalidCriterion
+	}
+	return nil
+}
+
+// handleJudgingError maps judging errors to HTTP responses
+func handleJudgingError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, errInvalidCriterion):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Criterion needs a name of up to 100 characters, a positive weight and a max score of at least 1"})
+	case errors.Is(err, errUnknownCriterion):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scores must reference each criterion of the hackathon at most once"})
+	case errors.Is(err, errScoreOutOfRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Scores must be between 0 and the criterion's max score"})
+	case errors.Is(err, errConflictOfInterest):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Judges cannot score a team they belong to"})
+	case errors.Is(err, errResultsNotPublished):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Results have not been published yet"})
+	case errors.Is(err, errJudgeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "User is already a judge of this hackathon"})
+	case errors.Is(err, errResultsPublished):
+		c.JSON(http.StatusConflict, gin.H{"error": "Results have already been published"})
+	case errors.Is(err, errSubmissionsStillOpen):
+		c.JSON(http.StatusConflict, gin.H{"error": "Results can be published once submissions are closed"})
+	case errors.Is(err, errNoCriteria):
+		c.JSON(http.StatusConflict, gin.H{"error": "Add judging criteria before publishing results"})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,161 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJudgingHandler(t *testing.T) {
+	env := setupTeamTest(t, 5)
+	require.NoError(t, env.db.AutoMigrate(&database.Submission{}, &database.FileMetadata{}, &database.HackathonJudge{},
+		&database.JudgingCriterion{}, &database.ScoreSheet{}, &database.CriterionScore{}, &database.Notification{}))
+	organizer, judge, memberJudge := &env.users[0], &env.users[1], &env.users[2]
+	env.db.Model(&env.hackathon).Update("owner_id", organizer.ID)
+
+	// Teams A, B and C are led by users 3, 4 and 5, each with one submission
+	var submissions []database.Submission
+	for i, name := range []string{"A", "B", "C"} {
+		leader := env.users[i+2]
+		team := database.Team{HackathonID: env.hackathon.ID, Name: name, NameKey: name, LeaderID: leader.ID, MaxSize: 5, InviteCode: name}
+		require.NoError(t, env.db.Create(&team).Error)
+		env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", leader.ID).Update("team_id", team.ID)
+		submission := database.Submission{HackathonID: env.hackathon.ID, TeamID: team.ID, Title: "Project " + name, SubmittedBy: leader.ID}
+		require.NoError(t, env.db.Create(&submission).Error)
+		submissions = append(submissions, submission)
+	}
+
+	handler := NewJudgingHandler(env.db)
+	request := func(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.POST("/hackathons/:id/judges", env.policy.RequireOwner("hackathon", HackathonOwner), handler.AddJudge)
+		r.POST("/hackathons/:id/criteria", env.policy.RequireOwner("hackathon", HackathonOwner), handler.CreateCriterion)
+		r.GET("/hackathons/:id/judging/submissions", env.policy.RequireOwner("judging", HackathonJudges), handler.ListJudgingSubmissions)
+		r.PUT("/hackathons/:id/submissions/:submission_id/score-sheet", env.policy.RequireOwner("score sheet", HackathonJudges), handler.SaveScoreSheet)
+		r.GET("/hackathons/:id/results", handler.GetResults)
+		r.POST("/hackathons/:id/results/publish", env.policy.RequireOwner("hackathon", HackathonOwner), handler.PublishResults)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	base := fmt.Sprintf("/hackathons/%d", env.hackathon.ID)
+	sheetPath := func(s database.Submission) string {
+		return fmt.Sprintf("%s/submissions/%d/score-sheet", base, s.ID)
+	}
+
+	assert.Equal(t, http.StatusForbidden, request(judge, "POST", base+"/judges", AddJudgeRequest{UserID: judge.ID}).Code)
+	for _, user := range []*database.User{judge, memberJudge} {
+		require.Equal(t, http.StatusCreated, request(organizer, "POST", base+"/judges", AddJudgeRequest{UserID: user.ID}).Code)
+	}
+	assert.Equal(t, http.StatusConflict, request(organizer, "POST", base+"/judges", AddJudgeRequest{UserID: judge.ID}).Code)
+
+	zero := 0.0
+	assert.Equal(t, http.StatusBadRequest, request(organizer, "POST", base+"/criteria", CreateCriterionRequest{Name: "Bad", Weight: &zero}).Code)
+	var criteria [2]database.JudgingCriterion
+	techWeight, designMax := 2.0, 5
+	for i, req := range []CreateCriterionRequest{{Name: "Tech", Weight: &techWeight}, {Name: "Design", MaxScore: &designMax}} {
+		w := request(organizer, "POST", base+"/criteria", req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &criteria[i]))
+	}
+	tech, design := criteria[0].ID, criteria[1].ID
+	sheet := func(techScore, designScore float64) SaveScoreSheetRequest {
+		return SaveScoreSheetRequest{Scores: []CriterionScoreRequest{
+			{CriterionID: tech, Score: &techScore},
+			{CriterionID: design, Score: &designScore},
+		}}
+	}
+
+	t.Run("Judges score submissions within range", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(organizer, "PUT", sheetPath(submissions[0]), sheet(8, 4)).Code)
+		assert.Equal(t, http.StatusBadRequest, request(judge, "PUT", sheetPath(submissions[0]), sheet(8, 6)).Code)
+
+		// A and C total 80 each; A wins the tie on the heavier Tech criterion
+		require.Equal(t, http.StatusOK, request(judge, "PUT", sheetPath(submissions[0]), sheet(8, 4)).Code)
+		require.Equal(t, http.StatusOK, request(judge, "PUT", sheetPath(submissions[1]), sheet(6, 5)).Code)
+		require.Equal(t, http.StatusOK, request(judge, "PUT", sheetPath(submissions[2]), sheet(7, 5)).Code)
+	})
+
+	t.Run("Judges cannot score their own team", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(memberJudge, "PUT", sheetPath(submissions[0]), sheet(10, 5)).Code)
+
+		w := request(memberJudge, "GET", base+"/judging/submissions", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Submissions []JudgingSubmission `json:"submissions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Submissions, 2)
+		assert.Equal(t, submissions[1].ID, body.Submissions[0].ID)
+
+		require.Equal(t, http.StatusOK, request(memberJudge, "PUT", sheetPath(submissions[1]), sheet(10, 5)).Code)
+	})
+
+	t.Run("Results stay private until published", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(nil, "GET", base+"/results", nil).Code)
+		assert.Equal(t, http.StatusConflict, request(organizer, "POST", base+"/results/publish", nil).Code, "submissions are still open")
+
+		w := request(organizer, "GET", base+"/results", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Results []ResultEntry `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Results, 3)
+		ranks := make([]int, 3)
+		for i, result := range body.Results {
+			require.NotNil(t, result.Rank)
+			ranks[i] = *result.Rank
+		}
+		assert.Equal(t, []int{1, 2, 3}, ranks)
+		assert.Equal(t, []string{"B", "A", "C"}, []string{body.Results[0].TeamName, body.Results[1].TeamName, body.Results[2].TeamName})
+		assert.Equal(t, 86.67, body.Results[0].Score)
+		assert.Equal(t, 2, body.Results[0].JudgeCount)
+		assert.Equal(t, 80.0, body.Results[1].Score)
+		assert.Equal(t, 80.0, body.Results[2].Score)
+	})
+
+	t.Run("Sheets of judges who joined the scored team are ignored", func(t *testing.T) {
+		joined := env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", judge.ID).Update("team_id", submissions[2].TeamID)
+		require.NoError(t, joined.Error)
+		defer env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", judge.ID).Update("team_id", nil)
+
+		w := request(organizer, "GET", base+"/results", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Results []ResultEntry `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Results, 3)
+		assert.Equal(t, []string{"B", "A", "C"}, []string{body.Results[0].TeamName, body.Results[1].TeamName, body.Results[2].TeamName})
+		assert.Nil(t, body.Results[2].Rank, "C was only scored by its new member")
+		assert.Zero(t, body.Results[2].JudgeCount)
+	})
+
+	t.Run("Publishing makes results public and locks scoring", func(t *testing.T) {
+		env.db.Model(&env.hackathon).Update("submission_deadline", time.Now().Add(-time.Minute))
+		require.Equal(t, http.StatusOK, request(organizer, "POST", base+"/results/publish", nil).Code)
+		assert.Equal(t, http.StatusConflict, request(organizer, "POST", base+"/results/publish", nil).Code)
+
+		assert.Equal(t, http.StatusOK, request(nil, "GET", base+"/results", nil).Code)
+		assert.Equal(t, http.StatusConflict, request(judge, "PUT", sheetPath(submissions[2]), sheet(10, 5)).Code)
+		assert.Equal(t, http.StatusConflict, request(organizer, "POST", base+"/criteria", CreateCriterionRequest{Name: "Late"}).Code)
+
+		var notified int64
+		env.db.Model(&database.Notification{}).Where("type = ?", "results_published").Count(&notified)
+		assert.Equal(t, int64(3), notified)
+	})
+}
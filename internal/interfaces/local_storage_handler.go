@@ -0,0 +1,109 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// SignedURLVerifier verifies requests made to URLs issued by a domain.URLSigner
+type SignedURLVerifier interface {
+	Verify(method, fileID, contentType string, query url.Values) error
+}
+
+// LocalStorageHandler serves signed upload and download URLs for backends
+// that cannot serve them themselves, such as the local filesystem.
+type LocalStorageHandler struct {
+	verifier    SignedURLVerifier
+	fileService *application.FileService
+	fileHandler *FileHandler
+}
+
+// NewLocalStorageHandler creates a new LocalStorageHandler
+func NewLocalStorageHandler(verifier SignedURLVerifier, fileService *application.FileService, fileHandler *FileHandler) *LocalStorageHandler {
+	return &LocalStorageHandler{
+		verifier:    verifier,
+		fileService: fileService,
+		fileHandler: fileHandler,
+	}
+}
+
+// PutObject handles PUT /api/v1/storage/objects/:id
+// Each signed upload URL stores content once, up to its declared size.
+func (h *LocalStorageHandler) PutObject(c *gin.Context) {
+	id := c.Param("id")
+	contentType := c.GetHeader("Content-Type")
+	if !h.verify(c, http.MethodPut, id, contentType) {
+		return
+	}
+
+	file, err := h.fileService.StoreSignedUpload(c.Request.Context(), &domain.CreateFileStreamRequest{
+		Name:        id,
+		ContentType: contentType,
+		Content:     c.Request.Body,
+	})
+	if err != nil {
+		if handleUploadPolicyError(c, err) || handleScanError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrSignedUploadUsed):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Signed URL was already used"})
+			return
+		case errors.Is(err, domain.ErrUploadExpired):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Signed URL has expired"})
+			return
+		case errors.Is(err, domain.ErrUploadExceedsLength):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Upload exceeds the declared size"})
+			return
+		case errors.Is(err, domain.ErrFileAlreadyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "File already exists"})
+			return
+		case errors.Is(err, domain.ErrInvalidFileName):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
+			return
+		}
+		log.Printf("Failed to store object: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store object"})
+		return
+	}
+
+	if etag := fileETag(file); etag != "" {
+		c.Header("ETag", etag)
+	}
+	c.Status(http.StatusOK)
+}
+
+// GetObject handles GET /api/v1/storage/objects/:id
+// The signature stands in for the visibility check made when the URL was issued. The
+// scan verdict is checked on every download so content found infected or not yet
+// scanned after signing is not served.
+func (h *LocalStorageHandler) GetObject(c *gin.Context) {
+	if !h.verify(c, http.MethodGet, c.Param("id"), "") {
+		return
+	}
+	file, ok := h.fileHandler.lookupFile(c)
+	if !ok || !authorizeDownload(c, file) {
+		return
+	}
+	h.fileHandler.serveFile(c, file)
+}
+
+// verify rejects requests whose signature is invalid or expired
+func (h *LocalStorageHandler) verify(c *gin.Context, method, id, contentType string) bool {
+	err := h.verifier.Verify(method, id, contentType, c.Request.URL.Query())
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, domain.ErrSignatureExpired):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Signed URL has expired"})
+	default:
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Invalid signature"})
+	}
+	return false
+}
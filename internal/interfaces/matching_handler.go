@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
 	"github.com/TRu-S3/backend/internal/utils"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -20,7 +21,7 @@ func NewMatchingHandler(db *gorm.DB) *MatchingHandler {
 }
 
 type CreateMatchingRequest struct {
-	User1ID uint   `json:"user1_id" binding:"required"`
+	User1ID uint   `json:"user1_id"`
 	User2ID uint   `json:"user2_id" binding:"required"`
 	Status  string `json:"status"`
 }
@@ -36,6 +37,12 @@ func (h *MatchingHandler) CreateMatching(c *gin.Context) {
 		return
 	}
 
+	user1ID, ok := resolveActingUserID(c, req.User1ID)
+	if !ok {
+		return
+	}
+	req.User1ID = user1ID
+
 	// Validate that user1_id and user2_id are different
 	if req.User1ID == req.User2ID {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Cannot create matching with the same user")
@@ -64,13 +71,15 @@ func (h *MatchingHandler) CreateMatching(c *gin.Context) {
 
 	// Set default status if not provided
 	if req.Status == "" {
-		req.Status = "pending"
+		req.Status = domain.MatchingPending
 	}
 
-	// Validate status
-	validStatuses := []string{"pending", "accepted", "rejected", "blocked"}
-	if !isValidStatus(req.Status, validStatuses) {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid status. Must be one of: pending, accepted, rejected, blocked")
+	matching := database.Matching{
+		User1ID: req.User1ID,
+		User2ID: req.User2ID,
+		Status:  req.Status,
+	}
+	if respondTransitionError(c, matchingStatuses.Check(domain.StatusNone, req.Status, matchingActor(c, &matching))) {
 		return
 	}
 
@@ -84,12 +93,6 @@ func (h *MatchingHandler) CreateMatching(c *gin.Context) {
 		return
 	}
 
-	matching := database.Matching{
-		User1ID: req.User1ID,
-		User2ID: req.User2ID,
-		Status:  req.Status,
-	}
-
 	if err := h.GetDatabase().Create(&matching).Error; err != nil {
 		utils.InternalErrorResponse(c, "Failed to create matching")
 		return
@@ -192,17 +195,21 @@ func (h *MatchingHandler) UpdateMatching(c *gin.Context) {
 	}
 
 	// Update fields if provided
+	previousStatus := matching.Status
+	actor := matchingActor(c, &matching)
 	if req.Status != nil {
-		// Validate status
-		validStatuses := []string{"pending", "accepted", "rejected", "blocked"}
-		if !isValidStatus(*req.Status, validStatuses) {
-			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid status. Must be one of: pending, accepted, rejected, blocked")
+		if respondTransitionError(c, matchingStatuses.Check(previousStatus, *req.Status, actor)) {
 			return
 		}
 		matching.Status = *req.Status
 	}
 
-	if err := h.GetDatabase().Save(&matching).Error; err != nil {
+	err := h.GetDatabase().Transaction(func(tx *gorm.DB) error {
+		return matchingStatuses.Apply(matchingChange{tx: tx, matching: &matching}, previousStatus, matching.Status, actor, func() error {
+			return tx.Save(&matching).Error
+		})
+	})
+	if err != nil {
 		utils.InternalErrorResponse(c, "Failed to update matching")
 		return
 	}
@@ -272,14 +279,4 @@ func (h *MatchingHandler) GetUserMatches(c *gin.Context) {
 			"total": total,
 		},
 	})
-}
-
-// Helper function to validate status
-func isValidStatus(status string, validStatuses []string) bool {
-	for _, validStatus := range validStatuses {
-		if status == validStatus {
-			return true
-		}
-	}
-	return false
 }
\ No newline at end of file
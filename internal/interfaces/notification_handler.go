@@ -0,0 +1,93 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// NotificationHandler handles HTTP requests for in-app notifications
+type NotificationHandler struct {
+	*BaseHandler
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(db *gorm.DB) *NotificationHandler {
+	return &NotificationHandler{
+		BaseHandler: NewBaseHandler(db),
+	}
+}
+
+// ListNotifications handles GET /api/v1/notifications
+// Lists the notifications of the caller, newest first.
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	params := utils.ParsePagination(c)
+	query := h.db.Model(&database.Notification{}).Where("user_id = ?", user.ID)
+	if c.Query("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve notifications"})
+		return
+	}
+	var notifications []database.Notification
+	if err := query.Order("id DESC").Offset(params.Offset).Limit(params.Limit).Find(&notifications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+// MarkNotificationRead handles POST /api/v1/notifications/:id/read
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var notification database.Notification
+	if err := h.db.First(&notification, id).Error; err != nil {
+		h.HandleDBError(c, err, "Notification")
+		return
+	}
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := h.db.Model(&notification).Update("read_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, notification)
+}
+
+// notifyUser records a notification for a user as part of tx
+func notifyUser(tx *gorm.DB, userID uint, kind, message, resourceType string, resourceID uint) error {
+	return tx.Create(&database.Notification{
+		UserID:       userID,
+		Type:         kind,
+		Message:      message,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}).Error
+}
@@ -0,0 +1,56 @@
+package interfaces
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestNotificationHandler(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}, &database.Notification{}))
+
+	recipient := database.User{Name: "Recipient", Gmail: "recipient@example.com", Role: "user"}
+	other := database.User{Name: "Other", Gmail: "other@example.com", Role: "user"}
+	db.Create(&recipient)
+	db.Create(&other)
+	require.NoError(t, notifyUser(db, recipient.ID, "waitlist_promoted", "You are in", "hackathon", 1))
+	require.NoError(t, notifyUser(db, other.ID, "waitlist_promoted", "You are in", "hackathon", 1))
+
+	handler := NewNotificationHandler(db)
+	policy := NewPolicy(db)
+	gin.SetMode(gin.TestMode)
+	request := func(user *database.User, method, path string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.GET("/notifications", handler.ListNotifications)
+		r.POST("/notifications/:id/read", policy.RequireOwner("notification", NotificationRecipient), handler.MarkNotificationRead)
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(&recipient, "GET", "/notifications?unread=true")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	var notification database.Notification
+	require.NoError(t, db.Where("user_id = ?", recipient.ID).First(&notification).Error)
+	path := fmt.Sprintf("/notifications/%d/read", notification.ID)
+	assert.Equal(t, http.StatusForbidden, request(&other, "POST", path).Code)
+	require.Equal(t, http.StatusOK, request(&recipient, "POST", path).Code)
+
+	w = request(&recipient, "GET", "/notifications?unread=true")
+	assert.Contains(t, w.Body.String(), `"total":0`)
+	assert.Equal(t, http.StatusUnauthorized, request(nil, "GET", "/notifications").Code)
+}
@@ -0,0 +1,157 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TRu-S3/backend/internal/database"
+	userDB "github.com/TRu-S3/backend/internal/database/user"
+	"github.com/TRu-S3/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// seatHoldingStatuses are the participant states that count against MaxParticipants
+var seatHoldingStatuses = []string{domain.ParticipantRegistered, domain.ParticipantConfirmed, domain.ParticipantCheckedIn}
+
+var (
+	errAlreadyRegistered = errors.New("user is already registered for the hackathon")
+	errHackathonFull     = errors.New("hackathon is full")
+)
+
+// holdsSeat reports whether a participant in status counts against the capacity
+func holdsSeat(status string) bool {
+	for _, s := range seatHoldingStatuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// lockHackathon loads the hackathon with its row locked, serializing capacity
+// checks and seat changes for the rest of the transaction
+func lockHackathon(tx *gorm.DB, hackathonID uint) (*database.Hackathon, error) {
+	var hackathon database.Hackathon
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "name", "max_participants").
+		First(&hackathon, hackathonID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hackathon, nil
+}
+
+// seatAvailable reports whether a locked hackathon has room for another participant
+func seatAvailable(tx *gorm.DB, hackathon *database.Hackathon) (bool, error) {
+	if hackathon.MaxParticipants <= 0 {
+		return true, nil
+	}
+	var count int64
+	err := tx.Model(&database.HackathonParticipant{}).
+		Where("hackathon_id = ? AND status IN ?", hackathon.ID, seatHoldingStatuses).
+		Count(&count).Error
+	return count < int64(hackathon.MaxParticipants), err
+}
+
+// registerParticipant creates a registration, taking a seat when one is free and
+// joining the waitlist otherwise
+func registerParticipant(tx *gorm.DB, participant *database.HackathonParticipant) error {
+	hackathon, err := lockHackathon(tx, participant.HackathonID)
+	if err != nil {
+		return err
+	}
+
+	var existing int64
+	err = tx.Model(&database.HackathonParticipant{}).
+		Where("hackathon_id = ? AND user_id = ? AND status <> ?", hackathon.ID, participant.UserID, domain.ParticipantCancelled).
+		Count(&existing).Error
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		return errAlreadyRegistered
+	}
+
+	available, err := seatAvailable(tx, hackathon)
+	if err != nil {
+		return err
+	}
+	participant.Status = domain.ParticipantWaitlisted
+	if available {
+		participant.Status = domain.ParticipantRegistered
+	}
+	return tx.Create(participant).Error
+}
+
+// claimSeat returns errHackathonFull unless the hackathon has a free seat
+func claimSeat(tx *gorm.DB, hackathonID uint) error {
+	hackathon, err := lockHackathon(tx, hackathonID)
+	if err != nil {
+		return err
+	}
+	available, err := seatAvailable(tx, hackathon)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return errHackathonFull
+	}
+	return nil
+}
+
+// promoteWaitlisted fills free seats with waitlisted participants in registration
+// order and notifies each promoted user
+func promoteWaitlisted(tx *gorm.DB, hackathonID uint) ([]database.HackathonParticipant, error) {
+	hackathon, err := lockHackathon(tx, hackathonID)
+	if err != nil {
+		return nil, err
+	}
+
+	var promoted []database.HackathonParticipant
+	for {
+		available, err := seatAvailable(tx, hackathon)
+		if err != nil || !available {
+			return promoted, err
+		}
+
+		var next database.HackathonParticipant
+		err = tx.Where("hackathon_id = ? AND status = ?", hackathonID, domain.ParticipantWaitlisted).
+			Order("id").
+			First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return promoted, nil
+		}
+		if err != nil {
+			return promoted, err
+		}
+
+		if err := tx.Model(&next).Update("status", domain.ParticipantRegistered).Error; err != nil {
+			return promoted, err
+		}
+		message := fmt.Sprintf("A spot opened up in %s and your registration moved off the waitlist.", hackathon.Name)
+		if err := notifyUser(tx, next.UserID, userDB.NotificationWaitlistPromoted, message, "hackathon", hackathonID); err != nil {
+			return promoted, err
+		}
+		promoted = append(promoted, next)
+	}
+}
+
+// fillWaitlistPositions sets the queue position of the waitlisted participants
+func fillWaitlistPositions(db *gorm.DB, participants []database.HackathonParticipant) error {
+	for i := range participants {
+		p := &participants[i]
+		if p.Status != domain.ParticipantWaitlisted {
+			continue
+		}
+		var ahead int64
+		err := db.Model(&database.HackathonParticipant{}).
+			Where("hackathon_id = ? AND status = ? AND id < ?", p.HackathonID, domain.ParticipantWaitlisted, p.ID).
+			Count(&ahead).Error
+		if err != nil {
+			return err
+		}
+		p.WaitlistPosition = int(ahead) + 1
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupWaitlistTest(t *testing.T, maxParticipants int) (*gorm.DB, *HackathonHandler, database.Hackathon, []database.User) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}, &database.Hackathon{}, &database.HackathonParticipant{}, &database.Team{}, &database.TeamInvitation{}, &database.Notification{}, &database.HackathonStatusTransition{}))
+
+	var users []database.User
+	for i := 1; i <= 3; i++ {
+		user := database.User{Name: fmt.Sprintf("User %d", i), Gmail: fmt.Sprintf("user%d@example.com", i), Role: "user"}
+		require.NoError(t, db.Create(&user).Error)
+		users = append(users, user)
+	}
+
+	now := time.Now()
+	hackathon := database.Hackathon{
+		Name:                 "Hackathon",
+		StartDate:            now.Add(48 * time.Hour),
+		EndDate:              now.Add(72 * time.Hour),
+		RegistrationStart:    now.Add(-time.Hour),
+		RegistrationDeadline: now.Add(24 * time.Hour),
+		Organizer:            "Organizer",
+		MaxParticipants:      maxParticipants,
+		OwnerID:              &users[0].ID,
+	}
+	require.NoError(t, db.Create(&hackathon).Error)
+
+	gin.SetMode(gin.TestMode)
+	return db, NewHackathonHandler(db), hackathon, users
+}
+
+// participantRequest performs a hackathon or participant request against handler as user
+func participantRequest(handler *HackathonHandler, user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(withUser(user))
+	r.POST("/hackathons/:id/participants", handler.CreateParticipant)
+	r.GET("/hackathons/:id/participants", handler.ListParticipants)
+	r.PUT("/hackathons/:id/participants/:participant_id", handler.UpdateParticipant)
+	r.DELETE("/hackathons/:id/participants/:participant_id", handler.DeleteParticipant)
+	r.PUT("/hackathons/:id", handler.UpdateHackathon)
+	r.GET("/hackathons/:id/status-transitions", handler.ListStatusTransitions)
+
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHackathonHandler_Waitlist(t *testing.T) {
+	db, handler, hackathon, users := setupWaitlistTest(t, 1)
+	participantsPath := fmt.Sprintf("/hackathons/%d/participants", hackathon.ID)
+
+	register := func(user *database.User) database.HackathonParticipant {
+		w := participantRequest(handler, user, "POST", participantsPath, CreateParticipantRequest{HackathonID: hackathon.ID})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var participant database.HackathonParticipant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &participant))
+		return participant
+	}
+	status := func(id uint) string {
+		var participant database.HackathonParticipant
+		require.NoError(t, db.First(&participant, id).Error)
+		return participant.Status
+	}
+
+	first := register(&users[0])
+	assert.Equal(t, "registered", first.Status)
+	second := register(&users[1])
+	assert.Equal(t, "waitlisted", second.Status)
+	assert.Equal(t, 1, second.WaitlistPosition)
+	third := register(&users[2])
+	assert.Equal(t, 2, third.WaitlistPosition)
+
+	t.Run("Duplicate registration is rejected", func(t *testing.T) {
+		w := participantRequest(handler, &users[0], "POST", participantsPath, CreateParticipantRequest{HackathonID: hackathon.ID})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Waitlisted participants cannot take a seat while full", func(t *testing.T) {
+		registered := "registered"
+		w := participantRequest(handler, &users[0], "PUT", fmt.Sprintf("%s/%d", participantsPath, third.ID), UpdateParticipantRequest{Status: &registered})
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "Hackathon is full")
+	})
+
+	t.Run("Cancelling promotes the next in line", func(t *testing.T) {
+		cancelled := "cancelled"
+		w := participantRequest(handler, &users[0], "PUT", fmt.Sprintf("%s/%d", participantsPath, first.ID), UpdateParticipantRequest{Status: &cancelled})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "registered", status(second.ID))
+		assert.Equal(t, "waitlisted", status(third.ID))
+
+		var notifications []database.Notification
+		require.NoError(t, db.Where("user_id = ?", users[1].ID).Find(&notifications).Error)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, "waitlist_promoted", notifications[0].Type)
+		assert.Equal(t, hackathon.ID, notifications[0].ResourceID)
+
+		w = participantRequest(handler, nil, "GET", participantsPath+"?status=waitlisted", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"waitlist_position":1`)
+	})
+
+	t.Run("Deleting a seated participant promotes the next in line", func(t *testing.T) {
+		w := participantRequest(handler, &users[1], "DELETE", fmt.Sprintf("%s/%d", participantsPath, second.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "cancelled", status(second.ID), "participants cancel rather than delete their registration")
+		assert.Equal(t, "registered", status(third.ID))
+	})
+}
+
+func TestHackathonHandler_DeleteParticipant(t *testing.T) {
+	db, handler, hackathon, users := setupWaitlistTest(t, 0)
+	participantsPath := fmt.Sprintf("/hackathons/%d/participants", hackathon.ID)
+	owner, leader, member := &users[0], &users[1], &users[2]
+
+	register := func(user *database.User) database.HackathonParticipant {
+		w := participantRequest(handler, user, "POST", participantsPath, CreateParticipantRequest{HackathonID: hackathon.ID})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var participant database.HackathonParticipant
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &participant))
+		return participant
+	}
+	leaderSeat, memberSeat := register(leader), register(member)
+
+	team := database.Team{HackathonID: hackathon.ID, Name: "Team", NameKey: "team", LeaderID: leader.ID, MaxSize: 5, InviteCode: "invite"}
+	require.NoError(t, db.Create(&team).Error)
+	require.NoError(t, db.Model(&database.HackathonParticipant{}).Where("id IN ?", []uint{leaderSeat.ID, memberSeat.ID}).
+		Update("team_id", team.ID).Error)
+
+	t.Run("A leader cancelling hands the team to a member", func(t *testing.T) {
+		w := participantRequest(handler, leader, "DELETE", fmt.Sprintf("%s/%d", participantsPath, leaderSeat.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var participant database.HackathonParticipant
+		require.NoError(t, db.First(&participant, leaderSeat.ID).Error)
+		assert.Equal(t, "cancelled", participant.Status)
+		assert.Nil(t, participant.TeamID)
+		require.NoError(t, db.First(&team, team.ID).Error)
+		assert.Equal(t, member.ID, team.LeaderID)
+	})
+
+	t.Run("Disqualified participants leave their team and cannot remove themselves", func(t *testing.T) {
+		seat := register(leader)
+		require.NoError(t, db.Model(&seat).Update("team_id", team.ID).Error)
+		require.NoError(t, db.Model(&team).Update("leader_id", leader.ID).Error)
+
+		disqualified := "disqualified"
+		w := participantRequest(handler, owner, "PUT", fmt.Sprintf("%s/%d", participantsPath, seat.ID), UpdateParticipantRequest{Status: &disqualified})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, db.First(&seat, seat.ID).Error)
+		assert.Nil(t, seat.TeamID)
+		require.NoError(t, db.First(&team, team.ID).Error)
+		assert.Equal(t, member.ID, team.LeaderID, "the team passes to the remaining member")
+
+		w = participantRequest(handler, leader, "DELETE", fmt.Sprintf("%s/%d", participantsPath, seat.ID), nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		w = participantRequest(handler, leader, "POST", participantsPath, CreateParticipantRequest{HackathonID: hackathon.ID})
+		assert.Equal(t, http.StatusConflict, w.Code, "the disqualification stands")
+	})
+
+	t.Run("The owner deletes registrations outright", func(t *testing.T) {
+		w := participantRequest(handler, owner, "DELETE", fmt.Sprintf("%s/%d", participantsPath, memberSeat.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.ErrorIs(t, db.First(&database.HackathonParticipant{}, memberSeat.ID).Error, gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, db.First(&database.Team{}, team.ID).Error, gorm.ErrRecordNotFound, "the last member's team is disbanded")
+	})
+}
+
+func TestHackathonHandler_WaitlistCapacityIncrease(t *testing.T) {
+	db, handler, hackathon, users := setupWaitlistTest(t, 1)
+	participantsPath := fmt.Sprintf("/hackathons/%d/participants", hackathon.ID)
+	for i := range users {
+		w := participantRequest(handler, &users[i], "POST", participantsPath, CreateParticipantRequest{HackathonID: hackathon.ID})
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	capacity := 3
+	w := participantRequest(handler, nil, "PUT", fmt.Sprintf("/hackathons/%d", hackathon.ID), UpdateHackathonRequest{MaxParticipants: &capacity})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var waitlisted, notified int64
+	db.Model(&database.HackathonParticipant{}).Where("status = ?", "waitlisted").Count(&waitlisted)
+	db.Model(&database.Notification{}).Count(&notified)
+	assert.Zero(t, waitlisted)
+	assert.Equal(t, int64(2), notified)
+}
@@ -20,7 +20,7 @@ func NewProfileHandler(db *gorm.DB) *ProfileHandler {
 }
 
 type CreateProfileRequest struct {
-	UserID   uint   `json:"user_id" binding:"required"`
+	UserID   uint   `json:"user_id"`
 	TagID    *uint  `json:"tag_id,omitempty"`
 	Bio      string `json:"bio"`
 	Age      *int   `json:"age,omitempty"`
@@ -41,6 +41,12 @@ func (h *ProfileHandler) CreateProfile(c *gin.Context) {
 		return
 	}
 
+	userID, ok := resolveActingUserID(c, req.UserID)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
 	// Validate age if provided
 	if req.Age != nil && !utils.ValidatePositiveInt(c, *req.Age, "age") {
 		return
@@ -0,0 +1,215 @@
+package interfaces
+
+import (
+	"encoding/base64"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// tusVersion is the version of the tus resumable upload protocol the upload endpoints follow
+const tusVersion = "1.0.0"
+
+// offsetOctetStream is the content type tus requires for chunk uploads
+const offsetOctetStream = "application/offset+octet-stream"
+
+// CreateUpload handles requests to start a resumable upload. The total size comes
+// from Upload-Length and the file attributes from Upload-Metadata: filename,
+// filetype (or content_type), visibility, attachable_type and attachable_id.
+// POST /files/uploads
+func (h *FileHandler) CreateUpload(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Length must be a non-negative integer"})
+		return
+	}
+	metadata, err := parseUploadMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Upload-Metadata"})
+		return
+	}
+	attachment, err := parseAttachment(metadata["attachable_type"], metadata["attachable_id"])
+	if err != nil {
+		handleFileAccessError(c, err)
+		return
+	}
+	contentType := metadata["filetype"]
+	if contentType == "" {
+		contentType = metadata["content_type"]
+	}
+
+	session, err := h.fileService.CreateUploadSession(c.Request.Context(), &domain.CreateUploadSessionRequest{
+		Name:        metadata["filename"],
+		ContentType: contentType,
+		Length:      length,
+		Access: &domain.FileAccessRequest{
+			Uploader:   fileViewer(c),
+			Visibility: metadata["visibility"],
+			Attachment: attachment,
+		},
+	})
+	if err != nil {
+		if handleUploadSessionError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrFileAlreadyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "File already exists"})
+		case errors.Is(err, domain.ErrInvalidFileName):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
+		default:
+			log.Printf("Failed to create upload: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload"})
+		}
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+session.ID)
+	setUploadHeaders(c, session)
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetUploadOffset handles requests for the progress of a resumable upload
+// HEAD /files/uploads/:upload_id
+func (h *FileHandler) GetUploadOffset(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	c.Header("Cache-Control", "no-store")
+
+	session, err := h.fileService.GetUploadSession(c.Request.Context(), c.Param("upload_id"), fileViewer(c))
+	if err != nil {
+		if !handleUploadSessionError(c, err) {
+			log.Printf("Failed to get upload: %v", err)
+			c.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Status(http.StatusOK)
+}
+
+// UploadChunk handles requests storing the next chunk of a resumable upload. The
+// Upload-Offset header must match the offset reported by HEAD.
+// PATCH /files/uploads/:upload_id
+func (h *FileHandler) UploadChunk(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+
+	if mediaType, _, _ := strings.Cut(c.ContentType(), ";"); mediaType != offsetOctetStream {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Content-Type must be " + offsetOctetStream})
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Offset must be a non-negative integer"})
+		return
+	}
+
+	session, err := h.fileService.AppendUploadChunk(c.Request.Context(), c.Param("upload_id"), fileViewer(c), offset, c.Request.Body)
+	if err != nil {
+		if !handleUploadSessionError(c, err) {
+			log.Printf("Failed to store upload chunk: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store upload chunk"})
+		}
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Status(http.StatusNoContent)
+}
+
+// FinalizeUpload handles requests to assemble a complete resumable upload into a file
+// POST /files/uploads/:upload_id/finalize
+func (h *FileHandler) FinalizeUpload(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+
+	file, err := h.fileService.FinalizeUpload(c.Request.Context(), c.Param("upload_id"), fileViewer(c))
+	if err != nil {
+		if handleUploadSessionError(c, err) || handleScanError(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrFileAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "File already exists"})
+			return
+		}
+		log.Printf("Failed to finalize upload: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to finalize upload"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, file)
+}
+
+// CancelUpload handles requests to discard a resumable upload
+// DELETE /files/uploads/:upload_id
+func (h *FileHandler) CancelUpload(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+
+	if err := h.fileService.CancelUpload(c.Request.Context(), c.Param("upload_id"), fileViewer(c)); err != nil {
+		if !handleUploadSessionError(c, err) {
+			log.Printf("Failed to cancel upload: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel upload"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// setUploadHeaders reports the progress of a resumable upload in the tus headers
+func setUploadHeaders(c *gin.Context, session *domain.UploadSession) {
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Length, 10))
+	c.Header("Upload-Expires", session.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// parseUploadMetadata decodes an Upload-Metadata header: comma separated pairs of a
+// key and an optional base64 encoded value
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, err
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+// handleUploadSessionError maps resumable upload errors to responses and reports whether err was one
+func handleUploadSessionError(c *gin.Context, err error) bool {
+	if handleFileAccessError(c, err) || handleUploadPolicyError(c, err) {
+		return true
+	}
+	switch {
+	case errors.Is(err, domain.ErrUploadNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+	case errors.Is(err, domain.ErrUploadExpired):
+		c.JSON(http.StatusGone, gin.H{"error": "Upload expired"})
+	case errors.Is(err, domain.ErrUploadOffsetMismatch):
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload-Offset does not match the current offset"})
+	case errors.Is(err, domain.ErrUploadExceedsLength):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Chunk exceeds the declared Upload-Length"})
+	case errors.Is(err, domain.ErrUploadIncomplete):
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload is incomplete"})
+	case errors.Is(err, domain.ErrInvalidUploadLength):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload length"})
+	case errors.Is(err, domain.ErrUploadSessionsUnsupported):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "Resumable uploads are not configured"})
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package interfaces
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestFileHandler_ResumableUploads(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}, &database.UploadSession{}, &database.User{}))
+	user := database.User{Name: "Uploader", Gmail: "uploader@example.com", Role: "user"}
+	require.NoError(t, db.Create(&user).Error)
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	fileHandler := NewFileHandler(application.NewFileService(repo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		application.WithUploadSessions(infrastructure.NewGormUploadSessionRepository(db), time.Hour),
+	))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(withUser(&user))
+	router.POST("/api/v1/files/uploads", fileHandler.CreateUpload)
+	router.HEAD("/api/v1/files/uploads/:upload_id", fileHandler.GetUploadOffset)
+	router.PATCH("/api/v1/files/uploads/:upload_id", fileHandler.UploadChunk)
+	router.POST("/api/v1/files/uploads/:upload_id/finalize", fileHandler.FinalizeUpload)
+	router.DELETE("/api/v1/files/uploads/:upload_id", fileHandler.CancelUpload)
+	router.GET("/api/v1/files/:id/download", fileHandler.DownloadFile)
+
+	request := func(method, path string, headers map[string]string, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	patch := func(location, offset, body string) *httptest.ResponseRecorder {
+		return request("PATCH", location, map[string]string{
+			"Content-Type":  offsetOctetStream,
+			"Upload-Offset": offset,
+		}, body)
+	}
+	metadata := "filename " + base64.StdEncoding.EncodeToString([]byte("video.txt")) + ",visibility " + base64.StdEncoding.EncodeToString([]byte("private"))
+
+	w := request("POST", "/api/v1/files/uploads", map[string]string{"Upload-Length": "10", "Upload-Metadata": metadata}, "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, tusVersion, w.Header().Get("Tus-Resumable"))
+	assert.Equal(t, "0", w.Header().Get("Upload-Offset"))
+	assert.NotEmpty(t, w.Header().Get("Upload-Expires"))
+	location := w.Header().Get("Location")
+	require.True(t, strings.HasPrefix(location, "/api/v1/files/uploads/"), location)
+
+	t.Run("Chunks advance the offset", func(t *testing.T) {
+		w := request("PATCH", location, map[string]string{"Upload-Offset": "0"}, "01234")
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		w = patch(location, "0", "01234")
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		assert.Equal(t, "5", w.Header().Get("Upload-Offset"))
+
+		assert.Equal(t, http.StatusConflict, patch(location, "0", "01234").Code)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, patch(location, "5", "567890").Code)
+
+		w = request("HEAD", location, nil, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "5", w.Header().Get("Upload-Offset"))
+		assert.Equal(t, "10", w.Header().Get("Upload-Length"))
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("Complete uploads finalize into a file", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, request("POST", location+"/finalize", nil, "").Code)
+		require.Equal(t, http.StatusNoContent, patch(location, "5", "56789").Code)
+
+		w := request("POST", location+"/finalize", nil, "")
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"visibility":"private"`)
+
+		w = request("GET", "/api/v1/files/video.txt/download", nil, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0123456789", w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, request("HEAD", location, nil, "").Code)
+	})
+
+	t.Run("Cancelled uploads are gone", func(t *testing.T) {
+		metadata := "filename " + base64.StdEncoding.EncodeToString([]byte("draft.txt"))
+		w := request("POST", "/api/v1/files/uploads", map[string]string{"Upload-Length": "4", "Upload-Metadata": metadata}, "")
+		require.Equal(t, http.StatusCreated, w.Code)
+		location := w.Header().Get("Location")
+
+		assert.Equal(t, http.StatusNoContent, request("DELETE", location, nil, "").Code)
+		assert.Equal(t, http.StatusNotFound, patch(location, "0", "data").Code)
+	})
+
+	t.Run("Invalid creation requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, request("POST", "/api/v1/files/uploads", map[string]string{"Upload-Metadata": metadata}, "").Code)
+		assert.Equal(t, http.StatusBadRequest, request("POST", "/api/v1/files/uploads", map[string]string{"Upload-Length": "4", "Upload-Metadata": "filename !!"}, "").Code)
+	})
+}
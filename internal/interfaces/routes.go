@@ -1,100 +1,235 @@
 package interfaces
 
 import (
+	"github.com/TRu-S3/backend/internal/domain"
 	"github.com/gin-gonic/gin"
 )
 
 // SetupRoutes sets up all routes for the application
-func SetupRoutes(r *gin.Engine, fileHandler *FileHandler, contestHandler *ContestHandler, bookmarkHandler *BookmarkHandler, hackathonHandler *HackathonHandler, userHandler *UserHandler, tagHandler *TagHandler, profileHandler *ProfileHandler, matchingHandler *MatchingHandler) {
+func SetupRoutes(r *gin.Engine, auth *AuthMiddleware, policy *Policy, authHandler *AuthHandler, fileHandler *FileHandler, signedURLHandler *SignedURLHandler, storageHandler *LocalStorageHandler, contestHandler *ContestHandler, bookmarkHandler *BookmarkHandler, hackathonHandler *HackathonHandler, userHandler *UserHandler, tagHandler *TagHandler, profileHandler *ProfileHandler, matchingHandler *MatchingHandler, imageHandler *ImageHandler, teamHandler *TeamHandler, notificationHandler *NotificationHandler, submissionHandler *SubmissionHandler, judgingHandler *JudgingHandler, checkInHandler *CheckInHandler, calendarHandler *CalendarHandler, seriesHandler *SeriesHandler, contestApplicationHandler *ContestApplicationHandler) {
 	// API v1 routes
 	v1 := r.Group("/api/v1")
+	v1.Use(auth.OptionalAuth())
 	{
+		// Auth routes
+		authRoutes := v1.Group("/auth")
+		{
+			authRoutes.POST("/google", authHandler.GoogleLogin)                     // Exchange Google ID token for a session token
+			authRoutes.GET("/me", auth.RequireAuth(), authHandler.GetCurrentUser) // Get authenticated user
+		}
+
 		// User routes
 		users := v1.Group("/users")
 		{
-			users.POST("", userHandler.CreateUser)                    // Create user
+			users.POST("", auth.RequireAuth(), policy.RequireAdmin(), userHandler.CreateUser)                    // Create user
 			users.GET("", userHandler.ListUsers)                      // List users
 			users.GET("/:id", userHandler.GetUser)                    // Get user by ID
-			users.PUT("/:id", userHandler.UpdateUser)                 // Update user
-			users.DELETE("/:id", userHandler.DeleteUser)              // Delete user
+			users.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("user", UserSelf), userHandler.UpdateUser)                 // Update user
+			users.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("user", UserSelf), userHandler.DeleteUser)              // Delete user
 			users.GET("/:id/matches", matchingHandler.GetUserMatches) // Get user matches
+			users.GET("/:id/storage", auth.RequireAuth(), policy.RequireOwner("user", UserSelf), fileHandler.GetUserStorage) // Get storage usage and quota
+			users.GET("/:id/calendar-feed", auth.RequireAuth(), policy.RequireOwner("user", UserSelf), calendarHandler.GetCalendarFeed)       // Get calendar feed URL
+			users.POST("/:id/calendar-feed", auth.RequireAuth(), policy.RequireOwner("user", UserSelf), calendarHandler.CreateCalendarFeed)   // Enable or rotate calendar feed
+			users.DELETE("/:id/calendar-feed", auth.RequireAuth(), policy.RequireOwner("user", UserSelf), calendarHandler.DeleteCalendarFeed) // Disable calendar feed
 		}
 
 		// Tag routes
 		tags := v1.Group("/tags")
 		{
-			tags.POST("", tagHandler.CreateTag)        // Create tag
+			tags.POST("", auth.RequireAuth(), tagHandler.CreateTag)        // Create tag
 			tags.GET("", tagHandler.ListTags)          // List tags
 			tags.GET("/:id", tagHandler.GetTag)        // Get tag by ID
-			tags.PUT("/:id", tagHandler.UpdateTag)     // Update tag
-			tags.DELETE("/:id", tagHandler.DeleteTag)  // Delete tag
+			tags.PUT("/:id", auth.RequireAuth(), policy.RequireAdmin(), tagHandler.UpdateTag)     // Update tag
+			tags.DELETE("/:id", auth.RequireAuth(), policy.RequireAdmin(), tagHandler.DeleteTag)  // Delete tag
 		}
 
 		// Profile routes
 		profiles := v1.Group("/profiles")
 		{
-			profiles.POST("", profileHandler.CreateProfile)                    // Create profile
+			profiles.POST("", auth.RequireAuth(), profileHandler.CreateProfile)                    // Create profile
 			profiles.GET("", profileHandler.ListProfiles)                      // List profiles
 			profiles.GET("/:id", profileHandler.GetProfile)                    // Get profile by ID
-			profiles.PUT("/:id", profileHandler.UpdateProfile)                 // Update profile
-			profiles.DELETE("/:id", profileHandler.DeleteProfile)              // Delete profile
+			profiles.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("profile", ProfileOwner), profileHandler.UpdateProfile)                 // Update profile
+			profiles.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("profile", ProfileOwner), profileHandler.DeleteProfile)              // Delete profile
 			profiles.GET("/user/:user_id", profileHandler.GetProfileByUserID)  // Get profile by user ID
+			profiles.GET("/:id/files", fileHandler.ListAttachedFiles(domain.AttachmentProfile, "id")) // List profile files
+			profiles.GET("/:id/avatar", imageHandler.GetAvatar) // Download avatar
+			profiles.PUT("/:id/avatar", auth.RequireAuth(), policy.RequireOwner("profile", ProfileOwner), imageHandler.UploadAvatar)    // Upload avatar
+			profiles.DELETE("/:id/avatar", auth.RequireAuth(), policy.RequireOwner("profile", ProfileOwner), imageHandler.DeleteAvatar) // Delete avatar
 		}
 
 		// Matching routes
 		matchings := v1.Group("/matchings")
 		{
-			matchings.POST("", matchingHandler.CreateMatching)      // Create matching
+			matchings.POST("", auth.RequireAuth(), matchingHandler.CreateMatching)      // Create matching
 			matchings.GET("", matchingHandler.ListMatchings)        // List matchings
 			matchings.GET("/:id", matchingHandler.GetMatching)      // Get matching by ID
-			matchings.PUT("/:id", matchingHandler.UpdateMatching)   // Update matching
-			matchings.DELETE("/:id", matchingHandler.DeleteMatching) // Delete matching
+			matchings.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("matching", MatchingParties), matchingHandler.UpdateMatching)   // Update matching
+			matchings.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("matching", MatchingParties), matchingHandler.DeleteMatching) // Delete matching
 		}
 
 		// File routes
 		files := v1.Group("/files")
 		{
-			files.POST("", fileHandler.CreateFile)               // Create file
+			files.POST("", auth.RequireAuth(), fileHandler.CreateFile)               // Create file
 			files.GET("", fileHandler.ListFiles)                 // List files
 			files.GET("/:id", fileHandler.GetFile)               // Get file metadata
 			files.GET("/:id/download", fileHandler.DownloadFile) // Download file content
-			files.PUT("/:id", fileHandler.UpdateFile)            // Update file
-			files.DELETE("/:id", fileHandler.DeleteFile)         // Delete file
+			files.PUT("/:id", auth.RequireAuth(), fileHandler.UpdateFile)            // Update file
+			files.DELETE("/:id", auth.RequireAuth(), fileHandler.DeleteFile)         // Delete file
+			files.POST("/signed-uploads", auth.RequireAuth(), signedURLHandler.CreateSignedUpload)            // Issue signed upload URL
+			files.POST("/signed-uploads/complete", auth.RequireAuth(), signedURLHandler.CompleteSignedUpload) // Record a finished direct upload
+			files.GET("/:id/signed-url", signedURLHandler.GetSignedDownloadURL)                                // Issue signed download URL
+			files.GET("/:id/versions", fileHandler.ListFileVersions)                                            // List file versions
+			files.GET("/:id/versions/:generation/download", fileHandler.DownloadFileVersion)                    // Download a file version
+			files.POST("/:id/versions/:generation/restore", auth.RequireAuth(), fileHandler.RestoreFileVersion) // Restore a file version
+			files.POST("/:id/scan", auth.RequireAuth(), policy.RequireAdmin(), fileHandler.ScanFile)            // Rescan file content
+			files.POST("/uploads", auth.RequireAuth(), fileHandler.CreateUpload)                                 // Start a resumable upload
+			files.HEAD("/uploads/:upload_id", auth.RequireAuth(), fileHandler.GetUploadOffset)                   // Get resumable upload progress
+			files.PATCH("/uploads/:upload_id", auth.RequireAuth(), fileHandler.UploadChunk)                      // Upload a chunk
+			files.POST("/uploads/:upload_id/finalize", auth.RequireAuth(), fileHandler.FinalizeUpload)           // Assemble a resumable upload
+			files.DELETE("/uploads/:upload_id", auth.RequireAuth(), fileHandler.CancelUpload)                    // Cancel a resumable upload
+			files.POST("/archive", auth.RequireAuth(), fileHandler.ArchiveFiles)                                // Download files as a ZIP archive
+		}
+
+		// Personal calendar feeds, authenticated by the secret token in the URL
+		v1.GET("/calendar/feeds/:token", calendarHandler.GetUserCalendar)
+
+		// Signed URL targets for storage backends without native signed URLs
+		if storageHandler != nil {
+			storage := v1.Group("/storage/objects")
+			{
+				storage.PUT("/:id", storageHandler.PutObject) // Upload via signed URL
+				storage.GET("/:id", storageHandler.GetObject) // Download via signed URL
+			}
 		}
 
 		// Contest routes
 		contests := v1.Group("/contests")
 		{
-			contests.POST("", contestHandler.CreateContest)   // Create contest
+			contests.POST("", auth.RequireAuth(), contestHandler.CreateContest)   // Create contest
 			contests.GET("", contestHandler.ListContests)     // List contests
 			contests.GET("/:id", contestHandler.GetContest)   // Get contest by ID
-			contests.PUT("/:id", contestHandler.UpdateContest) // Update contest
-			contests.DELETE("/:id", contestHandler.DeleteContest) // Delete contest
+			contests.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("contest", ContestAuthor), contestHandler.UpdateContest) // Update contest
+			contests.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("contest", ContestAuthor), contestHandler.DeleteContest) // Delete contest
+			contests.GET("/:id/files", fileHandler.ListAttachedFiles(domain.AttachmentContest, "id")) // List contest files
+			contests.GET("/:id/calendar.ics", calendarHandler.GetContestCalendar)                  // Export contest deadline as iCalendar
+			contests.POST("/:id/applications", auth.RequireAuth(), contestApplicationHandler.Apply)                                                            // Apply for a contest role
+			contests.GET("/:id/applications", auth.RequireAuth(), policy.RequireOwner("contest", ContestAuthor), contestApplicationHandler.ListContestApplications) // List contest applications
+		}
+
+		// Contest application routes
+		contestApplications := v1.Group("/contest-applications")
+		{
+			contestApplications.GET("", auth.RequireAuth(), contestApplicationHandler.ListMyApplications)                                                                          // List my applications
+			contestApplications.POST("/:id/accept", auth.RequireAuth(), policy.RequireOwner("contest application", ApplicationContestAuthor), contestApplicationHandler.AcceptApplication) // Accept application
+			contestApplications.POST("/:id/reject", auth.RequireAuth(), policy.RequireOwner("contest application", ApplicationContestAuthor), contestApplicationHandler.RejectApplication) // Reject application
+			contestApplications.POST("/:id/withdraw", auth.RequireAuth(), policy.RequireOwner("contest application", ContestApplicant), contestApplicationHandler.WithdrawApplication)      // Withdraw application
 		}
 
 		// Bookmark routes
 		bookmarks := v1.Group("/bookmarks")
 		{
-			bookmarks.POST("", bookmarkHandler.CreateBookmark)     // Create bookmark
+			bookmarks.POST("", auth.RequireAuth(), bookmarkHandler.CreateBookmark)     // Create bookmark
 			bookmarks.GET("", bookmarkHandler.ListBookmarks)       // List bookmarks
-			bookmarks.PUT("/:id", bookmarkHandler.UpdateBookmark)  // Update bookmark
-			bookmarks.DELETE("/:id", bookmarkHandler.DeleteBookmark) // Delete bookmark
+			bookmarks.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("bookmark", BookmarkOwner), bookmarkHandler.UpdateBookmark)  // Update bookmark
+			bookmarks.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("bookmark", BookmarkOwner), bookmarkHandler.DeleteBookmark) // Delete bookmark
 		}
 
 		// Hackathon routes
 		hackathons := v1.Group("/hackathons")
 		{
-			hackathons.POST("", hackathonHandler.CreateHackathon)   // Create hackathon
+			hackathons.POST("", auth.RequireAuth(), hackathonHandler.CreateHackathon)   // Create hackathon
 			hackathons.GET("", hackathonHandler.ListHackathons)     // List hackathons
 			hackathons.GET("/:id", hackathonHandler.GetHackathon)   // Get hackathon by ID
-			hackathons.PUT("/:id", hackathonHandler.UpdateHackathon) // Update hackathon
-			hackathons.DELETE("/:id", hackathonHandler.DeleteHackathon) // Delete hackathon
+			hackathons.GET("/:id/calendar.ics", calendarHandler.GetHackathonCalendar) // Export hackathon as iCalendar
+			hackathons.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), hackathonHandler.UpdateHackathon) // Update hackathon
+			hackathons.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), hackathonHandler.DeleteHackathon) // Delete hackathon
+			hackathons.GET("/:id/files", fileHandler.ListAttachedFiles(domain.AttachmentHackathon, "id")) // List hackathon files
+			hackathons.GET("/:id/banner", imageHandler.GetBanner) // Download banner
+			hackathons.PUT("/:id/banner", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), imageHandler.UploadBanner)    // Upload banner
+			hackathons.DELETE("/:id/banner", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), imageHandler.DeleteBanner) // Delete banner
+			hackathons.GET("/:id/status-transitions", hackathonHandler.ListStatusTransitions) // List status history
 			
 			// Participant routes
-			hackathons.POST("/:id/participants", hackathonHandler.CreateParticipant)      // Register for hackathon
+			hackathons.POST("/:id/participants", auth.RequireAuth(), hackathonHandler.CreateParticipant)      // Register for hackathon
 			hackathons.GET("/:id/participants", hackathonHandler.ListParticipants)        // List participants
-			hackathons.PUT("/:id/participants/:participant_id", hackathonHandler.UpdateParticipant) // Update participant
-			hackathons.DELETE("/:id/participants/:participant_id", hackathonHandler.DeleteParticipant) // Remove participant
+			hackathons.PUT("/:id/participants/:participant_id", auth.RequireAuth(), policy.RequireOwner("participant", ParticipantOrHackathonOwner), hackathonHandler.UpdateParticipant) // Update participant
+			hackathons.DELETE("/:id/participants/:participant_id", auth.RequireAuth(), policy.RequireOwner("participant", ParticipantOrHackathonOwner), hackathonHandler.DeleteParticipant) // Remove participant
+			hackathons.GET("/:id/participants/:participant_id/files", fileHandler.ListAttachedFiles(domain.AttachmentParticipant, "participant_id")) // List participant team files
+
+			// Check-in routes
+			hackathons.GET("/:id/participants/:participant_id/check-in/qr", auth.RequireAuth(), policy.RequireOwner("participant", ParticipantOrHackathonOwner), checkInHandler.GetCheckInQRCode) // Check-in QR code (PNG)
+			hackathons.POST("/:id/check-in", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), checkInHandler.CheckIn)                                                     // Scan check-in code
+			hackathons.GET("/:id/attendance", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), checkInHandler.GetAttendance)                                             // Attendance report
+
+			// Team routes
+			hackathons.POST("/:id/teams", auth.RequireAuth(), teamHandler.CreateTeam)                  // Create team as its leader
+			hackathons.GET("/:id/teams", teamHandler.ListTeams)                                         // List teams
+			hackathons.POST("/:id/teams/join", auth.RequireAuth(), teamHandler.JoinTeam)               // Join team by invite code
+			hackathons.GET("/:id/teams/:team_id", teamHandler.GetTeam)                                  // Get team
+			hackathons.PUT("/:id/teams/:team_id", auth.RequireAuth(), policy.RequireOwner("team", TeamLeaderOrHackathonOwner), teamHandler.UpdateTeam)    // Update team
+			hackathons.DELETE("/:id/teams/:team_id", auth.RequireAuth(), policy.RequireOwner("team", TeamLeaderOrHackathonOwner), teamHandler.DeleteTeam) // Delete team
+			hackathons.POST("/:id/teams/:team_id/invite-code", auth.RequireAuth(), policy.RequireOwner("team", TeamLeaderOrHackathonOwner), teamHandler.RegenerateInviteCode) // Regenerate invite code
+			hackathons.POST("/:id/teams/:team_id/invitations", auth.RequireAuth(), policy.RequireOwner("team", TeamLeaderOrHackathonOwner), teamHandler.CreateTeamInvitation) // Invite participant
+			hackathons.GET("/:id/teams/:team_id/invitations", auth.RequireAuth(), policy.RequireOwner("team", TeamLeaderOrHackathonOwner), teamHandler.ListTeamInvitations)   // List team invitations
+			hackathons.DELETE("/:id/teams/:team_id/members/:user_id", auth.RequireAuth(), policy.RequireOwner("team member", TeamMemberSelfOrLeader), teamHandler.RemoveTeamMember) // Leave or remove member
+
+			// Submission routes
+			hackathons.GET("/:id/submissions", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), submissionHandler.ListSubmissions)          // List submissions
+			hackathons.GET("/:id/submissions/export", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), submissionHandler.ExportSubmissions) // Export submissions as CSV
+			hackathons.POST("/:id/teams/:team_id/submission", auth.RequireAuth(), policy.RequireOwner("submission", TeamMembers), submissionHandler.CreateSubmission)               // Submit project
+			hackathons.GET("/:id/teams/:team_id/submission", auth.RequireAuth(), policy.RequireOwner("submission", TeamMembersOrHackathonOwner), submissionHandler.GetSubmission) // Get team submission
+			hackathons.PUT("/:id/teams/:team_id/submission", auth.RequireAuth(), policy.RequireOwner("submission", TeamMembers), submissionHandler.UpdateSubmission)                // Update submission
+			hackathons.DELETE("/:id/teams/:team_id/submission", auth.RequireAuth(), policy.RequireOwner("submission", TeamMembers), submissionHandler.DeleteSubmission)             // Withdraw submission
+			hackathons.GET("/:id/submissions/:submission_id/files", fileHandler.ListAttachedFiles(domain.AttachmentSubmission, "submission_id"))                                   // List submission files
+
+			// Judging routes
+			hackathons.POST("/:id/judges", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.AddJudge)                     // Assign judge
+			hackathons.GET("/:id/judges", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.ListJudges)                    // List judges
+			hackathons.DELETE("/:id/judges/:user_id", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.RemoveJudge)       // Remove judge
+			hackathons.POST("/:id/criteria", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.CreateCriterion)            // Add judging criterion
+			hackathons.GET("/:id/criteria", judgingHandler.ListCriteria)                                                                                      // List judging criteria
+			hackathons.PUT("/:id/criteria/:criterion_id", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.UpdateCriterion)    // Update judging criterion
+			hackathons.DELETE("/:id/criteria/:criterion_id", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.DeleteCriterion) // Delete judging criterion
+			hackathons.GET("/:id/judging/submissions", auth.RequireAuth(), policy.RequireOwner("judging", HackathonJudges), judgingHandler.ListJudgingSubmissions)                  // Submissions to score
+			hackathons.GET("/:id/submissions/:submission_id/score-sheet", auth.RequireAuth(), policy.RequireOwner("score sheet", HackathonJudges), judgingHandler.GetScoreSheet)   // Get own score sheet
+			hackathons.PUT("/:id/submissions/:submission_id/score-sheet", auth.RequireAuth(), policy.RequireOwner("score sheet", HackathonJudges), judgingHandler.SaveScoreSheet)  // Score submission
+			hackathons.GET("/:id/results", judgingHandler.GetResults)                                                                                          // Get leaderboard
+			hackathons.POST("/:id/results/publish", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), judgingHandler.PublishResults)       // Publish results
+
+			// Series stage routes
+			hackathons.POST("/:id/advance", auth.RequireAuth(), policy.RequireOwner("hackathon", HackathonOwner), seriesHandler.AdvanceParticipants) // Advance participants to the next stage
+		}
+
+		// Hackathon series routes
+		series := v1.Group("/series")
+		{
+			series.POST("", auth.RequireAuth(), seriesHandler.CreateSeries) // Create series
+			series.GET("", seriesHandler.ListSeries)                        // List series
+			series.GET("/:id", seriesHandler.GetSeries)                     // Get series page with editions and results
+			series.PUT("/:id", auth.RequireAuth(), policy.RequireOwner("series", SeriesOwner), seriesHandler.UpdateSeries)    // Update series
+			series.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("series", SeriesOwner), seriesHandler.DeleteSeries) // Delete series
+			series.POST("/:id/stages", auth.RequireAuth(), policy.RequireOwner("series", SeriesOwner), seriesHandler.AddStage)                  // Add stage
+			series.DELETE("/:id/stages/:stage_id", auth.RequireAuth(), policy.RequireOwner("series", SeriesOwner), seriesHandler.RemoveStage)   // Remove stage
+			series.POST("/:id/hackathons", auth.RequireAuth(), policy.RequireOwner("series", SeriesOwner), seriesHandler.AttachHackathon)       // Add hackathon to an edition
+			series.DELETE("/:id/hackathons/:hackathon_id", auth.RequireAuth(), policy.RequireOwner("series", SeriesOwner), seriesHandler.DetachHackathon) // Remove hackathon from series
+		}
+
+		// Team invitation routes
+		teamInvitations := v1.Group("/team-invitations")
+		{
+			teamInvitations.GET("", auth.RequireAuth(), teamHandler.ListMyTeamInvitations)                                                          // List my invitations
+			teamInvitations.POST("/:id/accept", auth.RequireAuth(), policy.RequireOwner("team invitation", TeamInvitee), teamHandler.AcceptTeamInvitation)   // Accept invitation
+			teamInvitations.POST("/:id/decline", auth.RequireAuth(), policy.RequireOwner("team invitation", TeamInvitee), teamHandler.DeclineTeamInvitation) // Decline invitation
+			teamInvitations.DELETE("/:id", auth.RequireAuth(), policy.RequireOwner("team invitation", TeamInvitationSender), teamHandler.CancelTeamInvitation) // Cancel invitation
+		}
+
+		// Notification routes
+		notifications := v1.Group("/notifications")
+		{
+			notifications.GET("", auth.RequireAuth(), notificationHandler.ListNotifications)                                                                 // List my notifications
+			notifications.POST("/:id/read", auth.RequireAuth(), policy.RequireOwner("notification", NotificationRecipient), notificationHandler.MarkNotificationRead) // Mark notification as read
 		}
 	}
 }
@@ -0,0 +1,750 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	userDB "github.com/TRu-S3/backend/internal/database/user"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// seriesPodiumSize is how many top-ranked teams of each edition the series page shows
+const seriesPodiumSize = 3
+
+var (
+	errInvalidSeries         = errors.New("invalid series")
+	errInvalidStage          = errors.New("invalid series stage")
+	errStageInUse            = errors.New("series stage is in use")
+	errStageRequired         = errors.New("hackathons of a staged series need a stage")
+	errEditionStageTaken     = errors.New("edition already has a hackathon for the stage")
+	errNotHackathonOwner     = errors.New("user does not own the hackathon")
+	errNotSeriesStage        = errors.New("hackathon is not a stage of a series")
+	errFinalStage            = errors.New("hackathon is the final stage")
+	errNextStageMissing      = errors.New("next stage has not been scheduled")
+	errNextStageClosed       = errors.New("next stage is over")
+	errNothingToAdvance      = errors.New("no participants to advance")
+	errParticipantNotInStage = errors.New("participant cannot advance from the hackathon")
+)
+
+// SeriesHandler handles HTTP requests for recurring and multi-stage hackathon series
+type SeriesHandler struct {
+	*BaseHandler
+}
+
+// NewSeriesHandler creates a new SeriesHandler
+func NewSeriesHandler(db *gorm.DB) *SeriesHandler {
+	return &SeriesHandler{
+		BaseHandler: NewBaseHandler(db),
+	}
+}
+
+type SeriesStageRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type CreateSeriesRequest struct {
+	Name        string               `json:"name" binding:"required"`
+	Description string               `json:"description"`
+	Stages      []SeriesStageRequest `json:"stages" binding:"dive"`
+}
+
+type UpdateSeriesRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type AttachHackathonRequest struct {
+	HackathonID uint  `json:"hackathon_id" binding:"required"`
+	Edition     int   `json:"edition" binding:"required,min=1"`
+	StageID     *uint `json:"stage_id,omitempty"`
+}
+
+type AdvanceParticipantsRequest struct {
+	ParticipantIDs []uint `json:"participant_ids"`
+	TeamIDs        []uint `json:"team_ids"`
+}
+
+// SeriesEditionHackathon summarizes the hackathon held for one stage of an edition
+type SeriesEditionHackathon struct {
+	ID                 uint       `json:"id"`
+	Name               string     `json:"name"`
+	StageID            *uint      `json:"stage_id"`
+	Status             string     `json:"status"`
+	StartDate          time.Time  `json:"start_date"`
+	EndDate            time.Time  `json:"end_date"`
+	ParticipantCount   int64      `json:"participant_count"`
+	ResultsPublishedAt *time.Time `json:"results_published_at"`
+}
+
+// SeriesEditionResults are the published results of the last judged stage of an edition
+type SeriesEditionResults struct {
+	HackathonID uint          `json:"hackathon_id"`
+	PublishedAt *time.Time    `json:"published_at"`
+	Winners     []ResultEntry `json:"winners"`
+}
+
+// SeriesEdition is one edition of a series with its hackathons in stage order
+type SeriesEdition struct {
+	Edition    int                      `json:"edition"`
+	Hackathons []SeriesEditionHackathon `json:"hackathons"`
+	Results    *SeriesEditionResults    `json:"results"`
+}
+
+// CreateSeries handles POST /api/v1/series
+// The creator owns the series; stages are held in the order they are given.
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req CreateSeriesRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	series := database.HackathonSeries{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	if user, ok := CurrentUser(c); ok {
+		series.OwnerID = &user.ID
+	}
+	for i, stage := range req.Stages {
+		series.Stages = append(series.Stages, database.SeriesStage{
+			Name:        strings.TrimSpace(stage.Name),
+			Description: stage.Description,
+			Position:    i + 1,
+		})
+	}
+
+	err := validateSeries(&series)
+	for i := 0; err == nil && i < len(series.Stages); i++ {
+		err = validateStage(&series.Stages[i])
+	}
+	if err == nil {
+		err = h.db.Create(&series).Error
+	}
+	if err != nil {
+		handleSeriesError(c, err, "Failed to create series")
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// ListSeries handles GET /api/v1/series
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	params := utils.ParsePagination(c)
+
+	query := h.db.Model(&database.HackathonSeries{})
+	if name := c.Query("name"); name != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(name)+"%")
+	}
+
+	var total int64
+	query.Count(&total)
+
+	var series []database.HackathonSeries
+	err := query.Preload("Stages", orderStages).
+		Order("name ASC").
+		Offset(params.Offset).Limit(params.Limit).
+		Find(&series).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"series": series,
+		"pagination": gin.H{
+			"page":  params.Page,
+			"limit": params.Limit,
+			"total": total,
+		},
+	})
+}
+
+// GetSeries handles GET /api/v1/series/:id
+// Returns the series page: its stages and every edition, newest first, with the
+// hackathon of each stage and the winners of the edition's last judged stage.
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var series database.HackathonSeries
+	if err := h.db.Preload("Stages", orderStages).First(&series, id).Error; err != nil {
+		h.HandleDBError(c, err, "Series")
+		return
+	}
+	editions, err := seriesEditions(h.db, &series)
+	if err != nil {
+		log.Printf("Failed to aggregate series %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":          series.ID,
+		"name":        series.Name,
+		"description": series.Description,
+		"owner_id":    series.OwnerID,
+		"stages":      series.Stages,
+		"editions":    editions,
+		"created_at":  series.CreatedAt,
+		"updated_at":  series.UpdatedAt,
+	})
+}
+
+// UpdateSeries handles PUT /api/v1/series/:id
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req UpdateSeriesRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	var series database.HackathonSeries
+	if err := h.db.First(&series, id).Error; err != nil {
+		h.HandleDBError(c, err, "Series")
+		return
+	}
+	if req.Name != nil {
+		series.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		series.Description = *req.Description
+	}
+
+	err := validateSeries(&series)
+	if err == nil {
+		err = h.db.Save(&series).Error
+	}
+	if err != nil {
+		handleSeriesError(c, err, "Failed to update series")
+		return
+	}
+
+	h.db.Preload("Stages", orderStages).First(&series, series.ID)
+	c.JSON(http.StatusOK, series)
+}
+
+// DeleteSeries handles DELETE /api/v1/series/:id
+// The hackathons of the series are kept and detached from it.
+func (h *SeriesHandler) DeleteSeries(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var series database.HackathonSeries
+		if err := tx.Select("id").First(&series, id).Error; err != nil {
+			return err
+		}
+		if err := detachHackathons(tx.Where("series_id = ?", id)); err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", id).Delete(&database.SeriesStage{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&series).Error
+	})
+	if err != nil {
+		handleSeriesError(c, err, "Failed to delete series")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Series deleted successfully"})
+}
+
+// AddStage handles POST /api/v1/series/:id/stages
+// The new stage follows the current final stage.
+func (h *SeriesHandler) AddStage(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req SeriesStageRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	stage := database.SeriesStage{SeriesID: id, Name: strings.TrimSpace(req.Name), Description: req.Description}
+	err := validateStage(&stage)
+	if err == nil {
+		err = h.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Select("id").First(&database.HackathonSeries{}, id).Error; err != nil {
+				return err
+			}
+			var last struct{ Position int }
+			if err := tx.Model(&database.SeriesStage{}).Select("COALESCE(MAX(position), 0) AS position").Where("series_id = ?", id).Scan(&last).Error; err != nil {
+				return err
+			}
+			stage.Position = last.Position + 1
+			return tx.Create(&stage).Error
+		})
+	}
+	if err != nil {
+		handleSeriesError(c, err, "Failed to add stage")
+		return
+	}
+
+	c.JSON(http.StatusCreated, stage)
+}
+
+// RemoveStage handles DELETE /api/v1/series/:id/stages/:stage_id
+// Stages that hackathons are held for cannot be removed.
+func (h *SeriesHandler) RemoveStage(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	stageID, ok := h.ParseIDParam(c, "stage_id")
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var stage database.SeriesStage
+		if err := tx.Where("series_id = ? AND id = ?", id, stageID).First(&stage).Error; err != nil {
+			return err
+		}
+		var hackathons int64
+		if err := tx.Model(&database.Hackathon{}).Where("stage_id = ?", stageID).Count(&hackathons).Error; err != nil {
+			return err
+		}
+		if hackathons > 0 {
+			return errStageInUse
+		}
+		return tx.Delete(&stage).Error
+	})
+	if err != nil {
+		handleSeriesError(c, err, "Failed to remove stage")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Stage removed successfully"})
+}
+
+// AttachHackathon handles POST /api/v1/series/:id/hackathons
+// Adds a hackathon to an edition of the series. The requester must own both the
+// series and the hackathon, and each stage of an edition holds one hackathon.
+func (h *SeriesHandler) AttachHackathon(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req AttachHackathonRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	var hackathon database.Hackathon
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var series database.HackathonSeries
+		if err := tx.Preload("Stages").First(&series, id).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&hackathon, req.HackathonID).Error; err != nil {
+			return err
+		}
+		user, ok := CurrentUser(c)
+		if !ok || !(user.IsAdmin() || hackathon.OwnerID != nil && *hackathon.OwnerID == user.ID) {
+			return errNotHackathonOwner
+		}
+
+		if len(series.Stages) > 0 && req.StageID == nil {
+			return errStageRequired
+		}
+		if req.StageID != nil {
+			found := false
+			for _, stage := range series.Stages {
+				found = found || stage.ID == *req.StageID
+			}
+			if !found {
+				return errInvalidStage
+			}
+		}
+
+		taken := tx.Model(&database.Hackathon{}).Where("series_id = ? AND edition = ? AND id <> ?", id, req.Edition, hackathon.ID)
+		if req.StageID != nil {
+			taken = taken.Where("stage_id = ?", *req.StageID)
+		} else {
+			taken = taken.Where("stage_id IS NULL")
+		}
+		var count int64
+		if err := taken.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errEditionStageTaken
+		}
+
+		hackathon.SeriesID, hackathon.Edition, hackathon.StageID = &series.ID, &req.Edition, req.StageID
+		return tx.Model(&hackathon).Select("series_id", "edition", "stage_id").Updates(&hackathon).Error
+	})
+	if err != nil {
+		handleSeriesError(c, err, "Failed to add hackathon to series")
+		return
+	}
+
+	c.JSON(http.StatusOK, hackathon)
+}
+
+// DetachHackathon handles DELETE /api/v1/series/:id/hackathons/:hackathon_id
+func (h *SeriesHandler) DetachHackathon(c *gin.Context) {
+	id, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	hackathonID, ok := h.ParseIDParam(c, "hackathon_id")
+	if !ok {
+		return
+	}
+
+	var hackathon database.Hackathon
+	err := h.db.Where("series_id = ? AND id = ?", id, hackathonID).Select("id").First(&hackathon).Error
+	if err == nil {
+		err = detachHackathons(h.db.Where("id = ?", hackathonID))
+	}
+	if err != nil {
+		handleSeriesError(c, err, "Failed to remove hackathon from series")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hackathon removed from series successfully"})
+}
+
+// AdvanceParticipants handles POST /api/v1/hackathons/:id/advance
+// Registers the given participants, and the active members of the given teams,
+// for the hackathon of the next stage of the same edition and notifies them.
+// Registration there ignores its registration window but not its capacity, so
+// advancing into a full stage puts participants on its waitlist.
+func (h *SeriesHandler) AdvanceParticipants(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req AdvanceParticipantsRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	if len(req.ParticipantIDs) == 0 && len(req.TeamIDs) == 0 {
+		handleSeriesError(c, errNothingToAdvance, "Failed to advance participants")
+		return
+	}
+
+	var next database.Hackathon
+	var registered []database.HackathonParticipant
+	alreadyRegistered := []uint{}
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		var current database.Hackathon
+		if err := tx.Select("id", "name", "series_id", "edition", "stage_id").First(&current, hackathonID).Error; err != nil {
+			return err
+		}
+		nextHackathon, err := nextStageHackathon(tx, &current)
+		if err != nil {
+			return err
+		}
+		next = *nextHackathon
+
+		advancing, err := advancingParticipants(tx, hackathonID, req)
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("You advanced from %s to %s and have been registered.", current.Name, next.Name)
+		waitlistedMessage := fmt.Sprintf("You advanced from %s to %s, which is full, and have been added to its waitlist.", current.Name, next.Name)
+		seen := make(map[uint]bool, len(advancing))
+		for _, source := range advancing {
+			if seen[source.UserID] {
+				continue
+			}
+			seen[source.UserID] = true
+
+			participant := database.HackathonParticipant{HackathonID: next.ID, UserID: source.UserID, Role: source.Role}
+			err := registerParticipant(tx, &participant)
+			if errors.Is(err, errAlreadyRegistered) {
+				alreadyRegistered = append(alreadyRegistered, source.UserID)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			text := message
+			if participant.Status == domain.ParticipantWaitlisted {
+				text = waitlistedMessage
+			}
+			if err := notifyUser(tx, source.UserID, userDB.NotificationStageAdvanced, text, "hackathon", next.ID); err != nil {
+				return err
+			}
+			registered = append(registered, participant)
+		}
+		return nil
+	})
+	if err != nil {
+		handleSeriesError(c, err, "Failed to advance participants")
+		return
+	}
+	if registered == nil {
+		registered = []database.HackathonParticipant{}
+	}
+	fillWaitlistPositions(h.db, registered)
+
+	c.JSON(http.StatusOK, gin.H{
+		"next_hackathon_id":  next.ID,
+		"registered":         registered,
+		"already_registered": alreadyRegistered,
+	})
+}
+
+// nextStageHackathon returns the hackathon held for the stage after the one of
+// current, in the same edition
+func nextStageHackathon(tx *gorm.DB, current *database.Hackathon) (*database.Hackathon, error) {
+	if current.SeriesID == nil || current.Edition == nil || current.StageID == nil {
+		return nil, errNotSeriesStage
+	}
+	var stages []database.SeriesStage
+	if err := tx.Where("series_id = ?", *current.SeriesID).Find(&stages).Error; err != nil {
+		return nil, err
+	}
+	domainStages := make([]domain.SeriesStage, len(stages))
+	for i, stage := range stages {
+		domainStages[i] = domain.SeriesStage{ID: stage.ID, Position: stage.Position}
+	}
+	stage, ok := domain.NextStage(domainStages, *current.StageID)
+	if !ok {
+		return nil, errFinalStage
+	}
+
+	var next database.Hackathon
+	err := tx.Select("id", "name", "status").
+		Where("series_id = ? AND edition = ? AND stage_id = ?", *current.SeriesID, *current.Edition, stage.ID).
+		First(&next).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errNextStageMissing
+	}
+	if err != nil {
+		return nil, err
+	}
+	if next.Status == domain.HackathonStatusCompleted || next.Status == domain.HackathonStatusCancelled {
+		return nil, errNextStageClosed
+	}
+	return &next, nil
+}
+
+// advancingParticipants loads the participants of a hackathon selected by req.
+// Only participants holding a seat can advance.
+func advancingParticipants(tx *gorm.DB, hackathonID uint, req AdvanceParticipantsRequest) ([]database.HackathonParticipant, error) {
+	var participants []database.HackathonParticipant
+	if len(req.ParticipantIDs) > 0 {
+		err := tx.Where("hackathon_id = ? AND id IN ? AND status IN ?", hackathonID, req.ParticipantIDs, seatHoldingStatuses).
+			Order("id").
+			Find(&participants).Error
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[uint]bool, len(participants))
+		for _, p := range participants {
+			found[p.ID] = true
+		}
+		for _, id := range req.ParticipantIDs {
+			if !found[id] {
+				return nil, fmt.Errorf("%w: participant %d", errParticipantNotInStage, id)
+			}
+		}
+	}
+
+	if len(req.TeamIDs) > 0 {
+		var teamIDs []uint
+		if err := tx.Model(&database.Team{}).Where("hackathon_id = ? AND id IN ?", hackathonID, req.TeamIDs).Pluck("id", &teamIDs).Error; err != nil {
+			return nil, err
+		}
+		found := make(map[uint]bool, len(teamIDs))
+		for _, id := range teamIDs {
+			found[id] = true
+		}
+		for _, id := range req.TeamIDs {
+			if !found[id] {
+				return nil, fmt.Errorf("%w: team %d", gorm.ErrRecordNotFound, id)
+			}
+		}
+		var members []database.HackathonParticipant
+		err := tx.Where("hackathon_id = ? AND team_id IN ? AND status IN ?", hackathonID, req.TeamIDs, seatHoldingStatuses).
+			Order("id").
+			Find(&members).Error
+		if err != nil {
+			return nil, err
+		}
+		participants = append(participants, members...)
+	}
+	return participants, nil
+}
+
+// seriesEditions groups the hackathons of a series by edition, newest first,
+// with their stage's hackathons in stage order
+func seriesEditions(db *gorm.DB, series *database.HackathonSeries) ([]SeriesEdition, error) {
+	var hackathons []database.Hackathon
+	err := db.Select("id", "name", "status", "start_date", "end_date", "edition", "stage_id", "results_published_at").
+		Where("series_id = ?", series.ID).
+		Order("edition DESC, start_date ASC").
+		Find(&hackathons).Error
+	if err != nil || len(hackathons) == 0 {
+		return []SeriesEdition{}, err
+	}
+
+	ids := make([]uint, len(hackathons))
+	for i, hackathon := range hackathons {
+		ids[i] = hackathon.ID
+	}
+	var counts []struct {
+		HackathonID uint
+		Count       int64
+	}
+	err = db.Model(&database.HackathonParticipant{}).
+		Select("hackathon_id, COUNT(*) AS count").
+		Where("hackathon_id IN ? AND status IN ?", ids, seatHoldingStatuses).
+		Group("hackathon_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	participantCounts := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		participantCounts[count.HackathonID] = count.Count
+	}
+	positions := make(map[uint]int, len(series.Stages))
+	for _, stage := range series.Stages {
+		positions[stage.ID] = stage.Position
+	}
+	position := func(hackathon SeriesEditionHackathon) int {
+		if hackathon.StageID == nil {
+			return 0
+		}
+		return positions[*hackathon.StageID]
+	}
+
+	var editions []SeriesEdition
+	for _, hackathon := range hackathons {
+		edition := 0
+		if hackathon.Edition != nil {
+			edition = *hackathon.Edition
+		}
+		if len(editions) == 0 || editions[len(editions)-1].Edition != edition {
+			editions = append(editions, SeriesEdition{Edition: edition})
+		}
+		current := &editions[len(editions)-1]
+		current.Hackathons = append(current.Hackathons, SeriesEditionHackathon{
+			ID:                 hackathon.ID,
+			Name:               hackathon.Name,
+			StageID:            hackathon.StageID,
+			Status:             hackathon.Status,
+			StartDate:          hackathon.StartDate,
+			EndDate:            hackathon.EndDate,
+			ParticipantCount:   participantCounts[hackathon.ID],
+			ResultsPublishedAt: hackathon.ResultsPublishedAt,
+		})
+	}
+
+	for i := range editions {
+		edition := &editions[i]
+		sort.SliceStable(edition.Hackathons, func(a, b int) bool {
+			return position(edition.Hackathons[a]) < position(edition.Hackathons[b])
+		})
+		for j := len(edition.Hackathons) - 1; j >= 0; j-- {
+			judged := edition.Hackathons[j]
+			if judged.ResultsPublishedAt == nil {
+				continue
+			}
+			_, results, err := hackathonResults(db, judged.ID)
+			if err != nil {
+				return nil, err
+			}
+			winners := []ResultEntry{}
+			for _, result := range results {
+				if result.Rank != nil && *result.Rank <= seriesPodiumSize {
+					winners = append(winners, result)
+				}
+			}
+			edition.Results = &SeriesEditionResults{HackathonID: judged.ID, PublishedAt: judged.ResultsPublishedAt, Winners: winners}
+			break
+		}
+	}
+	return editions, nil
+}
+
+// detachHackathons removes the hackathons selected by query from their series
+func detachHackathons(query *gorm.DB) error {
+	return query.Model(&database.Hackathon{}).Updates(map[string]interface{}{
+		"series_id": nil,
+		"edition":   nil,
+		"stage_id":  nil,
+	}).Error
+}
+
+// orderStages preloads the stages of a series in position order
+func orderStages(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+// validateSeries checks the name of a series
+func validateSeries(series *database.HackathonSeries) error {
+	if series.Name == "" || len(series.Name) > 255 {
+		return errInvalidSeries
+	}
+	return nil
+}
+
+// validateStage checks the name of a series stage
+func validateStage(stage *database.SeriesStage) error {
+	if stage.Name == "" || len(stage.Name) > 100 {
+		return errInvalidStage
+	}
+	return nil
+}
+
+// handleSeriesError maps series errors to HTTP responses
+func handleSeriesError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, errInvalidSeries):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Series needs a name of up to 255 characters"})
+	case errors.Is(err, errInvalidStage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Stages need a name of up to 100 characters and must belong to the series"})
+	case errors.Is(err, errStageRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "stage_id is required for a series with stages"})
+	case errors.Is(err, errNothingToAdvance):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "participant_ids or team_ids is required"})
+	case errors.Is(err, errParticipantNotInStage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only registered, confirmed or checked-in participants of this hackathon can advance", "details": err.Error()})
+	case errors.Is(err, errNotHackathonOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to modify this hackathon"})
+	case errors.Is(err, errStageInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": "Stage has hackathons and cannot be removed"})
+	case errors.Is(err, errEditionStageTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": "This edition already has a hackathon for the stage"})
+	case errors.Is(err, errNotSeriesStage):
+		c.JSON(http.StatusConflict, gin.H{"error": "Hackathon is not a stage of a series"})
+	case errors.Is(err, errFinalStage):
+		c.JSON(http.StatusConflict, gin.H{"error": "Hackathon is the final stage of its series"})
+	case errors.Is(err, errNextStageMissing):
+		c.JSON(http.StatusConflict, gin.H{"error": "The next stage of this edition has not been scheduled"})
+	case errors.Is(err, errNextStageClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "The next stage of this edition is over"})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,164 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesHandler(t *testing.T) {
+	env := setupTeamTest(t, 4)
+	require.NoError(t, env.db.AutoMigrate(&database.HackathonSeries{}, &database.SeriesStage{}, &database.Notification{}, &database.Submission{},
+		&database.HackathonJudge{}, &database.JudgingCriterion{}, &database.ScoreSheet{}, &database.CriterionScore{}))
+	organizer, solo, leader, dropout := &env.users[0], &env.users[1], &env.users[2], &env.users[3]
+	qualifier := env.hackathon
+	env.db.Model(&qualifier).Update("owner_id", organizer.ID)
+
+	var seats []database.HackathonParticipant
+	require.NoError(t, env.db.Order("id").Find(&seats).Error)
+	soloSeat := seats[1]
+	team := database.Team{HackathonID: qualifier.ID, Name: "Team", NameKey: "team", LeaderID: leader.ID, MaxSize: 5, InviteCode: "team"}
+	require.NoError(t, env.db.Create(&team).Error)
+	env.db.Model(&database.HackathonParticipant{}).Where("user_id IN ?", []uint{leader.ID, dropout.ID}).Update("team_id", team.ID)
+	env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", dropout.ID).Update("status", domain.ParticipantCancelled)
+
+	final := database.Hackathon{
+		Name:                 "Final",
+		StartDate:            qualifier.StartDate.Add(7 * 24 * time.Hour),
+		EndDate:              qualifier.EndDate.Add(7 * 24 * time.Hour),
+		RegistrationStart:    qualifier.RegistrationStart,
+		RegistrationDeadline: qualifier.RegistrationDeadline,
+		Organizer:            "Organizer",
+		MaxParticipants:      1,
+		OwnerID:              &organizer.ID,
+	}
+	require.NoError(t, env.db.Create(&final).Error)
+
+	handler := NewSeriesHandler(env.db)
+	request := func(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.POST("/series", handler.CreateSeries)
+		r.GET("/series/:id", handler.GetSeries)
+		r.DELETE("/series/:id", env.policy.RequireOwner("series", SeriesOwner), handler.DeleteSeries)
+		r.POST("/series/:id/stages", env.policy.RequireOwner("series", SeriesOwner), handler.AddStage)
+		r.DELETE("/series/:id/stages/:stage_id", env.policy.RequireOwner("series", SeriesOwner), handler.RemoveStage)
+		r.POST("/series/:id/hackathons", env.policy.RequireOwner("series", SeriesOwner), handler.AttachHackathon)
+		r.POST("/hackathons/:id/advance", env.policy.RequireOwner("hackathon", HackathonOwner), handler.AdvanceParticipants)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(organizer, "POST", "/series", CreateSeriesRequest{Name: "Annual Hack", Stages: []SeriesStageRequest{{Name: "Online qualifier"}, {Name: "Offline final"}}})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var series database.HackathonSeries
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &series))
+	require.Len(t, series.Stages, 2)
+	qualifierStage, finalStage := series.Stages[0].ID, series.Stages[1].ID
+	seriesPath := fmt.Sprintf("/series/%d", series.ID)
+	advancePath := fmt.Sprintf("/hackathons/%d/advance", qualifier.ID)
+
+	t.Run("Hackathons join an edition stage", func(t *testing.T) {
+		attach := func(user *database.User, hackathon database.Hackathon, stageID *uint) int {
+			return request(user, "POST", seriesPath+"/hackathons", AttachHackathonRequest{HackathonID: hackathon.ID, Edition: 2026, StageID: stageID}).Code
+		}
+		assert.Equal(t, http.StatusForbidden, attach(solo, qualifier, &qualifierStage))
+		assert.Equal(t, http.StatusBadRequest, attach(organizer, qualifier, nil), "staged series need a stage")
+		require.Equal(t, http.StatusOK, attach(organizer, qualifier, &qualifierStage))
+		assert.Equal(t, http.StatusConflict, attach(organizer, final, &qualifierStage), "one hackathon per stage and edition")
+		require.Equal(t, http.StatusOK, attach(organizer, final, &finalStage))
+
+		assert.Equal(t, http.StatusConflict, request(organizer, "DELETE", fmt.Sprintf("%s/stages/%d", seriesPath, finalStage), nil).Code)
+	})
+
+	t.Run("Advancing registers participants for the next stage", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, request(organizer, "POST", advancePath, AdvanceParticipantsRequest{}).Code)
+		cancelled := AdvanceParticipantsRequest{ParticipantIDs: []uint{seats[3].ID}}
+		assert.Equal(t, http.StatusBadRequest, request(organizer, "POST", advancePath, cancelled).Code)
+
+		w := request(organizer, "POST", advancePath, AdvanceParticipantsRequest{ParticipantIDs: []uint{soloSeat.ID}, TeamIDs: []uint{team.ID}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			NextHackathonID uint                            `json:"next_hackathon_id"`
+			Registered      []database.HackathonParticipant `json:"registered"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, final.ID, body.NextHackathonID)
+		require.Len(t, body.Registered, 2, "cancelled team members stay behind")
+		assert.Equal(t, solo.ID, body.Registered[0].UserID)
+		assert.Equal(t, domain.ParticipantRegistered, body.Registered[0].Status)
+		assert.Equal(t, leader.ID, body.Registered[1].UserID)
+		assert.Equal(t, domain.ParticipantWaitlisted, body.Registered[1].Status, "the final's capacity still applies")
+
+		var notifications int64
+		env.db.Model(&database.Notification{}).Where("type = ? AND resource_id = ?", "stage_advanced", final.ID).Count(&notifications)
+		assert.Equal(t, int64(2), notifications)
+
+		w = request(organizer, "POST", advancePath, AdvanceParticipantsRequest{ParticipantIDs: []uint{soloSeat.ID}})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), fmt.Sprintf(`"already_registered":[%d]`, solo.ID))
+
+		finalPath := fmt.Sprintf("/hackathons/%d/advance", final.ID)
+		var finalSeat database.HackathonParticipant
+		require.NoError(t, env.db.Where("hackathon_id = ? AND user_id = ?", final.ID, solo.ID).First(&finalSeat).Error)
+		assert.Equal(t, http.StatusConflict, request(organizer, "POST", finalPath, AdvanceParticipantsRequest{ParticipantIDs: []uint{finalSeat.ID}}).Code)
+	})
+
+	t.Run("Series page aggregates editions and results", func(t *testing.T) {
+		finalTeam := database.Team{HackathonID: final.ID, Name: "Champions", NameKey: "champions", LeaderID: solo.ID, MaxSize: 5, InviteCode: "champions"}
+		require.NoError(t, env.db.Create(&finalTeam).Error)
+		submission := database.Submission{HackathonID: final.ID, TeamID: finalTeam.ID, Title: "Winning project", SubmittedBy: solo.ID}
+		require.NoError(t, env.db.Create(&submission).Error)
+		criterion := database.JudgingCriterion{HackathonID: final.ID, Name: "Overall", Weight: 1, MaxScore: 10}
+		require.NoError(t, env.db.Create(&criterion).Error)
+		require.NoError(t, env.db.Create(&database.HackathonJudge{HackathonID: final.ID, UserID: organizer.ID}).Error)
+		sheet := database.ScoreSheet{SubmissionID: submission.ID, JudgeID: organizer.ID, Scores: []database.CriterionScore{{CriterionID: criterion.ID, Score: 9}}}
+		require.NoError(t, env.db.Create(&sheet).Error)
+		env.db.Model(&final).Update("results_published_at", time.Now())
+
+		w := request(nil, "GET", seriesPath, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var page struct {
+			Stages   []database.SeriesStage `json:"stages"`
+			Editions []SeriesEdition        `json:"editions"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.Len(t, page.Stages, 2)
+		require.Len(t, page.Editions, 1)
+		edition := page.Editions[0]
+		assert.Equal(t, 2026, edition.Edition)
+		require.Len(t, edition.Hackathons, 2)
+		assert.Equal(t, qualifier.ID, edition.Hackathons[0].ID, "hackathons follow the stage order")
+		assert.Equal(t, int64(3), edition.Hackathons[0].ParticipantCount)
+		assert.Equal(t, int64(1), edition.Hackathons[1].ParticipantCount)
+		require.NotNil(t, edition.Results)
+		assert.Equal(t, final.ID, edition.Results.HackathonID)
+		require.Len(t, edition.Results.Winners, 1)
+		assert.Equal(t, "Champions", edition.Results.Winners[0].TeamName)
+	})
+
+	t.Run("Deleting a series keeps its hackathons", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(solo, "DELETE", seriesPath, nil).Code)
+		require.Equal(t, http.StatusOK, request(organizer, "DELETE", seriesPath, nil).Code)
+
+		var detached database.Hackathon
+		require.NoError(t, env.db.First(&detached, final.ID).Error)
+		assert.Nil(t, detached.SeriesID)
+		assert.Nil(t, detached.StageID)
+		assert.Equal(t, http.StatusNotFound, request(nil, "GET", seriesPath, nil).Code)
+	})
+}
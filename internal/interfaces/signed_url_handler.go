@@ -0,0 +1,161 @@
+package interfaces
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// SignedURLHandler handles the direct-to-storage upload and download flow
+type SignedURLHandler struct {
+	fileService *application.FileService
+}
+
+// NewSignedURLHandler creates a new SignedURLHandler
+func NewSignedURLHandler(fileService *application.FileService) *SignedURLHandler {
+	return &SignedURLHandler{fileService: fileService}
+}
+
+// CreateSignedUploadRequest represents a request for a signed upload URL.
+// Size is the declared size in bytes; the upload may not exceed it.
+type CreateSignedUploadRequest struct {
+	Name           string `json:"name" binding:"required"`
+	ContentType    string `json:"content_type"`
+	Size           int64  `json:"size" binding:"required"`
+	Visibility     string `json:"visibility"`
+	AttachableType string `json:"attachable_type"`
+	AttachableID   uint   `json:"attachable_id"`
+}
+
+// CompleteSignedUploadRequest represents the callback sent after a direct upload finished
+type CompleteSignedUploadRequest struct {
+	FileID string `json:"file_id" binding:"required"`
+}
+
+// SignedUploadResponse represents an issued signed upload URL
+type SignedUploadResponse struct {
+	FileID string            `json:"file_id"`
+	Upload *domain.SignedURL `json:"upload"`
+}
+
+// CreateSignedUpload handles POST /api/v1/files/signed-uploads
+func (h *SignedURLHandler) CreateSignedUpload(c *gin.Context) {
+	var req CreateSignedUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	access := &domain.FileAccessRequest{
+		Uploader:   fileViewer(c),
+		Visibility: req.Visibility,
+	}
+	if req.AttachableType != "" || req.AttachableID != 0 {
+		access.Attachment = &domain.FileAttachment{Type: req.AttachableType, ID: req.AttachableID}
+	}
+
+	signedURL, err := h.fileService.IssueUploadURL(c.Request.Context(), &domain.CreateSignedUploadRequest{
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Length:      req.Size,
+		Access:      access,
+	})
+	if err != nil {
+		if handleFileAccessError(c, err) || handleUploadPolicyError(c, err) {
+			return
+		}
+		h.handleSignError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SignedUploadResponse{FileID: req.Name, Upload: signedURL})
+}
+
+// CompleteSignedUpload handles POST /api/v1/files/signed-uploads/complete
+// It records the uploaded object in file_metadata with the access declared when
+// the upload URL was issued; only the user the URL was issued to may complete it.
+func (h *SignedURLHandler) CompleteSignedUpload(c *gin.Context) {
+	var req CompleteSignedUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := h.fileService.RecordFile(c.Request.Context(), req.FileID, fileViewer(c))
+	if err != nil {
+		if handleFileAccessError(c, err) || handleUploadPolicyError(c, err) || handleScanError(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrUploadNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Signed upload not found"})
+			return
+		}
+		if errors.Is(err, domain.ErrUploadExpired) {
+			c.JSON(http.StatusGone, gin.H{"error": "Signed upload expired"})
+			return
+		}
+		if errors.Is(err, domain.ErrFileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Uploaded file not found in storage"})
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidFileName) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
+			return
+		}
+		log.Printf("Failed to record uploaded file: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record file metadata"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, file)
+}
+
+// GetSignedDownloadURL handles GET /api/v1/files/:id/signed-url
+func (h *SignedURLHandler) GetSignedDownloadURL(c *gin.Context) {
+	file, err := h.fileService.GetFile(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleSignError(c, err)
+		return
+	}
+	if err := h.fileService.AuthorizeRead(c.Request.Context(), file, fileViewer(c)); err != nil {
+		if !handleFileAccessError(c, err) {
+			log.Printf("Failed to authorize file access: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authorize request"})
+		}
+		return
+	}
+	if !authorizeDownload(c, file) {
+		return
+	}
+
+	signedURL, err := h.fileService.IssueDownloadURL(c.Request.Context(), file.ID)
+	if err != nil {
+		h.handleSignError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, signedURL)
+}
+
+// handleSignError maps signed URL issuance errors to responses
+func (h *SignedURLHandler) handleSignError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSignedURLUnsupported):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "Signed URLs are not available for this storage backend"})
+	case errors.Is(err, domain.ErrInvalidFileName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
+	case errors.Is(err, domain.ErrInvalidUploadLength):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload size"})
+	case errors.Is(err, domain.ErrFileAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "File already exists"})
+	case errors.Is(err, domain.ErrFileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+	default:
+		log.Printf("Failed to issue signed URL: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue signed URL"})
+	}
+}
@@ -0,0 +1,192 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSignedURLTestRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.FileMetadata{}, &database.SignedUpload{}))
+
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	signer := infrastructure.NewHMACURLSigner([]byte("test-secret"), "http://example.com")
+	fileService := application.NewFileService(repo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(db)),
+		application.WithURLSigner(signer, time.Minute),
+		application.WithSignedUploads(infrastructure.NewGormSignedUploadRepository(db)),
+	)
+	fileHandler := NewFileHandler(fileService)
+	signedURLHandler := NewSignedURLHandler(fileService)
+	storageHandler := NewLocalStorageHandler(signer, fileService, fileHandler)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(withUser(&database.User{ID: 1, Role: "user"}))
+	r.POST("/api/v1/files/signed-uploads", signedURLHandler.CreateSignedUpload)
+	r.POST("/api/v1/files/signed-uploads/complete", signedURLHandler.CompleteSignedUpload)
+	r.GET("/api/v1/files/:id/signed-url", signedURLHandler.GetSignedDownloadURL)
+	r.PUT("/api/v1/storage/objects/:id", storageHandler.PutObject)
+	r.GET("/api/v1/storage/objects/:id", storageHandler.GetObject)
+	return r, db
+}
+
+func postJSON(router *gin.Engine, path string, body interface{}) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", path, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// requestURI strips the scheme and host from a signed URL so it can be served by the test router
+func requestURI(t *testing.T, signedURL string) string {
+	parsed, err := url.Parse(signedURL)
+	require.NoError(t, err)
+	return parsed.RequestURI()
+}
+
+func TestSignedURLHandler_UploadAndDownload(t *testing.T) {
+	router, db := setupSignedURLTestRouter(t)
+
+	w := postJSON(router, "/api/v1/files/signed-uploads", CreateSignedUploadRequest{Name: "slides.pdf", Size: 8})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var issued SignedUploadResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+	assert.Equal(t, "slides.pdf", issued.FileID)
+	assert.Equal(t, http.MethodPut, issued.Upload.Method)
+	assert.Equal(t, "application/pdf", issued.Upload.Headers["Content-Type"])
+	uploadURI := requestURI(t, issued.Upload.URL)
+
+	t.Run("Upload with wrong content type is rejected", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", uploadURI, bytes.NewBufferString("%PDF"))
+		req.Header.Set("Content-Type", "text/html")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Upload through signed URL", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", uploadURI, bytes.NewBufferString("%PDF-1.7"))
+		req.Header.Set("Content-Type", "application/pdf")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("Signed URL is single use", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", uploadURI, bytes.NewBufferString("%PDF-2.0"))
+		req.Header.Set("Content-Type", "application/pdf")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Completion records file metadata", func(t *testing.T) {
+		w := postJSON(router, "/api/v1/files/signed-uploads/complete", CompleteSignedUploadRequest{FileID: "slides.pdf"})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var metadata database.FileMetadata
+		require.NoError(t, db.Where("name = ?", "slides.pdf").First(&metadata).Error)
+		assert.Equal(t, int64(8), metadata.Size)
+		assert.Equal(t, "application/pdf", metadata.ContentType)
+
+		// Repeated callbacks do not duplicate the record
+		w = postJSON(router, "/api/v1/files/signed-uploads/complete", CompleteSignedUploadRequest{FileID: "slides.pdf"})
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var count int64
+		db.Model(&database.FileMetadata{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Download through signed URL", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/files/slides.pdf/signed-url", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var signed struct {
+			URL string `json:"url"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &signed))
+
+		req, _ = http.NewRequest("GET", requestURI(t, signed.URL), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "%PDF-1.7", w.Body.String())
+
+		// A verdict recorded after signing still applies to the issued URL
+		require.NoError(t, db.Model(&database.FileMetadata{}).Where("name = ?", "slides.pdf").Update("scan_status", "infected").Error)
+		req, _ = http.NewRequest("GET", requestURI(t, signed.URL), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		require.NoError(t, db.Model(&database.FileMetadata{}).Where("name = ?", "slides.pdf").Update("scan_status", "").Error)
+	})
+
+	t.Run("Upload URL cannot be used for download", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", uploadURI, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Existing file cannot get a new upload URL", func(t *testing.T) {
+		w := postJSON(router, "/api/v1/files/signed-uploads", CreateSignedUploadRequest{Name: "slides.pdf", Size: 8})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Upload larger than declared is rejected", func(t *testing.T) {
+		w := postJSON(router, "/api/v1/files/signed-uploads", CreateSignedUploadRequest{Name: "notes.txt", Size: 4})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var issued SignedUploadResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+
+		req, _ := http.NewRequest("PUT", requestURI(t, issued.Upload.URL), bytes.NewBufferString("more than four bytes"))
+		req.Header.Set("Content-Type", "text/plain")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		w = postJSON(router, "/api/v1/files/signed-uploads/complete", CompleteSignedUploadRequest{FileID: "notes.txt"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Completion for missing object", func(t *testing.T) {
+		w := postJSON(router, "/api/v1/files/signed-uploads/complete", CompleteSignedUploadRequest{FileID: "never-uploaded.pdf"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestSignedURLHandler_Unsupported(t *testing.T) {
+	repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+	require.NoError(t, err)
+	handler := NewSignedURLHandler(application.NewFileService(repo))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/files/signed-uploads", handler.CreateSignedUpload)
+
+	w := postJSON(r, "/files/signed-uploads", CreateSignedUploadRequest{Name: "a.txt", Size: 1})
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
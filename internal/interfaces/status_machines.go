@@ -0,0 +1,229 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	userDB "github.com/TRu-S3/backend/internal/database/user"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// hackathonChange is the subject of hackathon status hooks. c is nil for changes
+// made by the scheduler, which records them itself.
+type hackathonChange struct {
+	c         *gin.Context
+	tx        *gorm.DB
+	hackathon *database.Hackathon
+}
+
+// participantChange is the subject of participant status hooks
+type participantChange struct {
+	tx          *gorm.DB
+	participant *database.HackathonParticipant
+}
+
+// matchingChange is the subject of matching status hooks
+type matchingChange struct {
+	tx       *gorm.DB
+	matching *database.Matching
+}
+
+// applicationChange is the subject of contest application status hooks
+type applicationChange struct {
+	tx          *gorm.DB
+	contest     *database.Contest
+	application *database.ContestApplication
+}
+
+// ApplyScheduledHackathonStatus makes a status change of the hackathon scheduler
+// through hackathonStatuses as the system, so that it runs the same hooks as a
+// change made through the API
+func ApplyScheduledHackathonStatus(tx *gorm.DB, hackathon *database.Hackathon, from, to string, persist func() error) error {
+	change := hackathonChange{tx: tx, hackathon: hackathon}
+	return hackathonStatuses.Apply(change, from, to, domain.ActorSystem, persist)
+}
+
+// hackathonStatuses records every manual status change and tells participants
+// when a hackathon is cancelled
+var hackathonStatuses = domain.NewStatusMachine[hackathonChange]("hackathon", domain.HackathonStatuses, domain.HackathonTransitions...).
+	After(func(change domain.StatusChange[hackathonChange]) error {
+		s := change.Subject
+		if s.c == nil {
+			return nil
+		}
+		return recordStatusTransition(s.c, s.tx, s.hackathon.ID, change.From, change.To)
+	}).
+	After(notifyHackathonCancelled)
+
+// participantStatuses makes taking a seat require a free one, promotes the
+// waitlist when a seat is given up, stamps check-ins and takes cancelled and
+// disqualified participants out of their team
+var participantStatuses = domain.NewStatusMachine[participantChange]("participant", domain.ParticipantStatuses, domain.ParticipantTransitions...).
+	Before(func(change domain.StatusChange[participantChange]) error {
+		participant := change.Subject.participant
+		switch {
+		case change.To == domain.ParticipantCheckedIn:
+			now := time.Now()
+			participant.CheckedInAt = &now
+		case change.From == domain.ParticipantCheckedIn && change.To == domain.ParticipantConfirmed:
+			// Undoing a mistaken scan
+			participant.CheckedInAt = nil
+		}
+		return nil
+	}).
+	Before(func(change domain.StatusChange[participantChange]) error {
+		if holdsSeat(change.From) || !holdsSeat(change.To) {
+			return nil
+		}
+		return claimSeat(change.Subject.tx, change.Subject.participant.HackathonID)
+	}).
+	After(func(change domain.StatusChange[participantChange]) error {
+		if !holdsSeat(change.From) || holdsSeat(change.To) {
+			return nil
+		}
+		_, err := promoteWaitlisted(change.Subject.tx, change.Subject.participant.HackathonID)
+		return err
+	}).
+	After(func(change domain.StatusChange[participantChange]) error {
+		if change.To != domain.ParticipantCancelled && change.To != domain.ParticipantDisqualified {
+			return nil
+		}
+		return leaveTeam(change.Subject.tx, change.Subject.participant)
+	})
+
+// matchingStatuses tells the requester when a matching is accepted
+var matchingStatuses = domain.NewStatusMachine[matchingChange]("matching", domain.MatchingStatuses, domain.MatchingTransitions...).
+	After(func(change domain.StatusChange[matchingChange]) error {
+		if change.To != domain.MatchingAccepted {
+			return nil
+		}
+		m := change.Subject.matching
+		return notifyUser(change.Subject.tx, m.User1ID, userDB.NotificationMatchingAccepted,
+			"Your matching request was accepted.", "matching", m.ID)
+	})
+
+// applicationStatuses makes accepting an application require a free slot of its
+// role, stamps decisions and tells the other party about them
+var applicationStatuses = domain.NewStatusMachine[applicationChange]("application", domain.ApplicationStatuses, domain.ApplicationTransitions...).
+	Before(func(change domain.StatusChange[applicationChange]) error {
+		application := change.Subject.application
+		switch change.To {
+		case domain.ApplicationAccepted, domain.ApplicationRejected:
+			now := time.Now()
+			application.DecidedAt = &now
+		}
+		if change.To != domain.ApplicationPending && change.To != domain.ApplicationAccepted {
+			return nil
+		}
+		return claimContestSlot(change.Subject.tx, application.ContestID, application.Role)
+	}).
+	After(notifyApplicationChange)
+
+// notifyHackathonCancelled notifies every participant still registered or
+// waitlisted that the hackathon was cancelled
+func notifyHackathonCancelled(change domain.StatusChange[hackathonChange]) error {
+	if change.To != domain.HackathonStatusCancelled {
+		return nil
+	}
+	s := change.Subject
+	var userIDs []uint
+	err := s.tx.Model(&database.HackathonParticipant{}).
+		Where("hackathon_id = ? AND status IN ?", s.hackathon.ID, []string{domain.ParticipantRegistered, domain.ParticipantConfirmed, domain.ParticipantCheckedIn, domain.ParticipantWaitlisted}).
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("%s has been cancelled.", s.hackathon.Name)
+	for _, userID := range userIDs {
+		if err := notifyUser(s.tx, userID, userDB.NotificationHackathonCancelled, message, "hackathon", s.hackathon.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyApplicationChange tells the contest author about new applications and
+// applicants about the decision on theirs
+func notifyApplicationChange(change domain.StatusChange[applicationChange]) error {
+	s := change.Subject
+	a := s.application
+	switch change.To {
+	case domain.ApplicationPending:
+		return notifyUser(s.tx, s.contest.AuthorID, userDB.NotificationApplicationReceived,
+			fmt.Sprintf("New %s application for your contest.", a.Role), "contest", a.ContestID)
+	case domain.ApplicationAccepted:
+		return notifyUser(s.tx, a.UserID, userDB.NotificationApplicationAccepted,
+			fmt.Sprintf("Your %s application was accepted.", a.Role), "contest", a.ContestID)
+	case domain.ApplicationRejected:
+		return notifyUser(s.tx, a.UserID, userDB.NotificationApplicationRejected,
+			fmt.Sprintf("Your %s application was rejected.", a.Role), "contest", a.ContestID)
+	}
+	return nil
+}
+
+// transitionActor returns the role in which the current user changes a record
+// belonging to self whose parent resource is owned by owner. Unauthenticated
+// requests have no role and may make no transition.
+func transitionActor(c *gin.Context, self uint, owner *uint) domain.Actor {
+	user, ok := CurrentUser(c)
+	switch {
+	case !ok:
+		return ""
+	case user.IsAdmin():
+		return domain.ActorAdmin
+	case owner != nil && *owner == user.ID:
+		return domain.ActorOwner
+	case user.ID == self:
+		return domain.ActorSelf
+	}
+	return ""
+}
+
+// matchingActor returns the role in which the current user changes matching
+func matchingActor(c *gin.Context, matching *database.Matching) domain.Actor {
+	user, ok := CurrentUser(c)
+	switch {
+	case !ok:
+		return ""
+	case user.IsAdmin():
+		return domain.ActorAdmin
+	case user.ID == matching.User1ID:
+		return domain.ActorRequester
+	case user.ID == matching.User2ID:
+		return domain.ActorRecipient
+	}
+	return ""
+}
+
+// respondTransitionError writes the response for a rejected status change and
+// reports whether err was one. Unknown statuses are a 400, transitions the user
+// may not make a 403 and transitions that do not exist a 409.
+func respondTransitionError(c *gin.Context, err error) bool {
+	var transitionErr *domain.TransitionError
+	if !errors.As(err, &transitionErr) {
+		return false
+	}
+
+	code := http.StatusConflict
+	message := fmt.Sprintf("Cannot change %s status from %s to %s", transitionErr.Entity, transitionErr.From, transitionErr.To)
+	switch {
+	case errors.Is(err, domain.ErrUnknownStatus):
+		code = http.StatusBadRequest
+		message = "Invalid status. Must be one of: " + strings.Join(transitionErr.Allowed, ", ")
+	case errors.Is(err, domain.ErrTransitionForbidden):
+		code = http.StatusForbidden
+		message = fmt.Sprintf("You may not change %s status from %s to %s", transitionErr.Entity, transitionErr.From, transitionErr.To)
+	}
+	c.JSON(code, gin.H{
+		"error":            message,
+		"current_status":   transitionErr.From,
+		"allowed_statuses": transitionErr.Allowed,
+	})
+	return true
+}
@@ -0,0 +1,129 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// transitionResponse is the body of a rejected status change
+type transitionResponse struct {
+	Error           string   `json:"error"`
+	CurrentStatus   string   `json:"current_status"`
+	AllowedStatuses []string `json:"allowed_statuses"`
+}
+
+func decodeTransition(t *testing.T, w *httptest.ResponseRecorder) transitionResponse {
+	var body transitionResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func TestHackathonHandler_StatusMachine(t *testing.T) {
+	db, handler, hackathon, users := setupWaitlistTest(t, 0)
+	hackathonPath := fmt.Sprintf("/hackathons/%d", hackathon.ID)
+	participantsPath := hackathonPath + "/participants"
+
+	w := participantRequest(handler, &users[1], "POST", participantsPath, CreateParticipantRequest{HackathonID: hackathon.ID})
+	require.Equal(t, http.StatusCreated, w.Code)
+	var participant database.HackathonParticipant
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &participant))
+	participantPath := fmt.Sprintf("%s/%d", participantsPath, participant.ID)
+
+	t.Run("Participants cannot confirm themselves", func(t *testing.T) {
+		confirmed := "confirmed"
+		w := participantRequest(handler, &users[1], "PUT", participantPath, UpdateParticipantRequest{Status: &confirmed})
+		require.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, []string{"cancelled"}, decodeTransition(t, w).AllowedStatuses)
+
+		w = participantRequest(handler, &users[0], "PUT", participantPath, UpdateParticipantRequest{Status: &confirmed})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("Unknown statuses are rejected", func(t *testing.T) {
+		unknown := "winner"
+		w := participantRequest(handler, &users[0], "PUT", participantPath, UpdateParticipantRequest{Status: &unknown})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Cancelling notifies participants and is final", func(t *testing.T) {
+		cancelled := "cancelled"
+		w := participantRequest(handler, &users[0], "PUT", hackathonPath, UpdateHackathonRequest{Status: &cancelled})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var notifications []database.Notification
+		require.NoError(t, db.Where("user_id = ?", users[1].ID).Find(&notifications).Error)
+		require.Len(t, notifications, 1)
+		assert.Equal(t, "hackathon_cancelled", notifications[0].Type)
+
+		upcoming := "upcoming"
+		w = participantRequest(handler, &users[0], "PUT", hackathonPath, UpdateHackathonRequest{Status: &upcoming})
+		require.Equal(t, http.StatusConflict, w.Code)
+		body := decodeTransition(t, w)
+		assert.Equal(t, "cancelled", body.CurrentStatus)
+		assert.Empty(t, body.AllowedStatuses)
+	})
+}
+
+func TestMatchingHandler_StatusMachine(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}, &database.Matching{}, &database.Notification{}))
+
+	requester := database.User{Name: "Requester", Gmail: "requester@example.com", Role: "user"}
+	recipient := database.User{Name: "Recipient", Gmail: "recipient@example.com", Role: "user"}
+	db.Create(&requester)
+	db.Create(&recipient)
+
+	handler := NewMatchingHandler(db)
+	gin.SetMode(gin.TestMode)
+	request := func(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.POST("/matchings", handler.CreateMatching)
+		r.PUT("/matchings/:id", handler.UpdateMatching)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(&requester, "POST", "/matchings", CreateMatchingRequest{User2ID: recipient.ID, Status: "accepted"})
+	require.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, []string{"pending", "blocked"}, decodeTransition(t, w).AllowedStatuses)
+
+	w = request(&requester, "POST", "/matchings", CreateMatchingRequest{User2ID: recipient.ID})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var matching database.Matching
+	require.NoError(t, db.First(&matching).Error)
+	path := fmt.Sprintf("/matchings/%d", matching.ID)
+
+	accepted, rejected := "accepted", "rejected"
+	w = request(&requester, "PUT", path, UpdateMatchingRequest{Status: &accepted})
+	require.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, []string{"blocked"}, decodeTransition(t, w).AllowedStatuses)
+
+	require.Equal(t, http.StatusOK, request(&recipient, "PUT", path, UpdateMatchingRequest{Status: &rejected}).Code)
+
+	w = request(&recipient, "PUT", path, UpdateMatchingRequest{Status: &accepted})
+	require.Equal(t, http.StatusConflict, w.Code)
+	body := decodeTransition(t, w)
+	assert.Equal(t, "rejected", body.CurrentStatus)
+	assert.Equal(t, []string{"blocked"}, body.AllowedStatuses)
+
+	var count int64
+	db.Model(&database.Notification{}).Count(&count)
+	assert.Zero(t, count, "only accepted matchings notify the requester")
+}
@@ -0,0 +1,380 @@
+package interfaces
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var (
+	errSubmissionsClosed = errors.New("submissions are closed")
+	errSubmissionExists  = errors.New("team has already submitted")
+	errInvalidTitle      = errors.New("submission title is required")
+)
+
+// submissionExportColumns is the header row of the CSV export
+var submissionExportColumns = []string{
+	"id", "team_id", "team_name", "title", "description", "repository_url", "demo_url",
+	"files", "submitted_by", "created_at", "updated_at",
+}
+
+// SubmissionHandler handles HTTP requests for team submissions to hackathons
+type SubmissionHandler struct {
+	*BaseHandler
+}
+
+// NewSubmissionHandler creates a new SubmissionHandler
+func NewSubmissionHandler(db *gorm.DB) *SubmissionHandler {
+	return &SubmissionHandler{
+		BaseHandler: NewBaseHandler(db),
+	}
+}
+
+type CreateSubmissionRequest struct {
+	Title         string `json:"title" binding:"required"`
+	Description   string `json:"description"`
+	RepositoryURL string `json:"repository_url" binding:"omitempty,url"`
+	DemoURL       string `json:"demo_url" binding:"omitempty,url"`
+}
+
+type UpdateSubmissionRequest struct {
+	Title         *string `json:"title,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	RepositoryURL *string `json:"repository_url,omitempty" binding:"omitempty,url"`
+	DemoURL       *string `json:"demo_url,omitempty" binding:"omitempty,url"`
+}
+
+// CreateSubmission handles POST /api/v1/hackathons/:id/teams/:team_id/submission
+// Each team submits once; the submission can be edited until the deadline.
+func (h *SubmissionHandler) CreateSubmission(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	var req CreateSubmissionRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	var submission database.Submission
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkSubmissionsOpen(tx, team.HackathonID); err != nil {
+			return err
+		}
+		var existing int64
+		if err := tx.Model(&database.Submission{}).Where("team_id = ?", team.ID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errSubmissionExists
+		}
+
+		title := strings.TrimSpace(req.Title)
+		if title == "" {
+			return errInvalidTitle
+		}
+		submission = database.Submission{
+			HackathonID:   team.HackathonID,
+			TeamID:        team.ID,
+			Title:         title,
+			Description:   req.Description,
+			RepositoryURL: req.RepositoryURL,
+			DemoURL:       req.DemoURL,
+			SubmittedBy:   user.ID,
+		}
+		return tx.Create(&submission).Error
+	})
+	if err != nil {
+		handleSubmissionError(c, err, "Failed to create submission")
+		return
+	}
+
+	h.respondSubmission(c, http.StatusCreated, team.ID)
+}
+
+// GetSubmission handles GET /api/v1/hackathons/:id/teams/:team_id/submission
+func (h *SubmissionHandler) GetSubmission(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	h.respondSubmission(c, http.StatusOK, team.ID)
+}
+
+// UpdateSubmission handles PUT /api/v1/hackathons/:id/teams/:team_id/submission
+func (h *SubmissionHandler) UpdateSubmission(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	var req UpdateSubmissionRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkSubmissionsOpen(tx, team.HackathonID); err != nil {
+			return err
+		}
+		var submission database.Submission
+		if err := tx.Where("team_id = ?", team.ID).First(&submission).Error; err != nil {
+			return err
+		}
+
+		if req.Title != nil {
+			submission.Title = strings.TrimSpace(*req.Title)
+			if submission.Title == "" {
+				return errInvalidTitle
+			}
+		}
+		if req.Description != nil {
+			submission.Description = *req.Description
+		}
+		if req.RepositoryURL != nil {
+			submission.RepositoryURL = *req.RepositoryURL
+		}
+		if req.DemoURL != nil {
+			submission.DemoURL = *req.DemoURL
+		}
+		return tx.Save(&submission).Error
+	})
+	if err != nil {
+		handleSubmissionError(c, err, "Failed to update submission")
+		return
+	}
+
+	h.respondSubmission(c, http.StatusOK, team.ID)
+}
+
+// DeleteSubmission handles DELETE /api/v1/hackathons/:id/teams/:team_id/submission
+func (h *SubmissionHandler) DeleteSubmission(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkSubmissionsOpen(tx, team.HackathonID); err != nil {
+			return err
+		}
+		result := tx.Where("team_id = ?", team.ID).Delete(&database.Submission{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		handleSubmissionError(c, err, "Failed to delete submission")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Submission deleted successfully"})
+}
+
+// ListSubmissions handles GET /api/v1/hackathons/:id/submissions
+// Lists every submission of the hackathon for its organizer.
+func (h *SubmissionHandler) ListSubmissions(c *gin.Context) {
+	_, submissions, ok := h.hackathonSubmissions(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"submissions": submissions,
+		"count":       len(submissions),
+	})
+}
+
+// ExportSubmissions handles GET /api/v1/hackathons/:id/submissions/export
+// Streams every submission of the hackathon as CSV, one row per team.
+func (h *SubmissionHandler) ExportSubmissions(c *gin.Context) {
+	hackathonID, submissions, ok := h.hackathonSubmissions(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", contentDisposition(fmt.Sprintf("hackathon-%d-submissions.csv", hackathonID)))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	w.Write(submissionExportColumns)
+	for _, s := range submissions {
+		files := make([]string, len(s.Files))
+		for i, file := range s.Files {
+			files[i] = file.Name
+		}
+		teamName := ""
+		if s.Team != nil {
+			teamName = s.Team.Name
+		}
+		w.Write([]string{
+			strconv.FormatUint(uint64(s.ID), 10),
+			strconv.FormatUint(uint64(s.TeamID), 10),
+			csvCell(teamName),
+			csvCell(s.Title),
+			csvCell(s.Description),
+			csvCell(s.RepositoryURL),
+			csvCell(s.DemoURL),
+			csvCell(strings.Join(files, ";")),
+			strconv.FormatUint(uint64(s.SubmittedBy), 10),
+			s.CreatedAt.UTC().Format(time.RFC3339),
+			s.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Printf("Failed to export submissions: %v", err)
+	}
+}
+
+// csvCell escapes user input that spreadsheets would otherwise evaluate as a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// hackathonSubmissions loads the submissions of the hackathon addressed by :id with their teams and files
+func (h *SubmissionHandler) hackathonSubmissions(c *gin.Context) (uint, []database.Submission, bool) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return 0, nil, false
+	}
+	if err := h.db.Select("id").First(&database.Hackathon{}, hackathonID).Error; err != nil {
+		h.HandleDBError(c, err, "Hackathon")
+		return 0, nil, false
+	}
+
+	var submissions []database.Submission
+	err := h.db.Where("hackathon_id = ?", hackathonID).
+		Preload("Team", selectTeamSummary).
+		Order("id").
+		Find(&submissions).Error
+	if err == nil {
+		err = loadSubmissionFiles(h.db, submissions)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve submissions"})
+		return 0, nil, false
+	}
+	return hackathonID, submissions, true
+}
+
+// lookupTeam loads the team addressed by :team_id within the hackathon addressed by :id
+func (h *SubmissionHandler) lookupTeam(c *gin.Context) (*database.Team, bool) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+	teamID, ok := h.ParseIDParam(c, "team_id")
+	if !ok {
+		return nil, false
+	}
+
+	var team database.Team
+	if err := h.db.Select("id", "hackathon_id").Where("hackathon_id = ? AND id = ?", hackathonID, teamID).First(&team).Error; err != nil {
+		h.HandleDBError(c, err, "Team")
+		return nil, false
+	}
+	return &team, true
+}
+
+// respondSubmission responds with the submission of a team, its team and its files
+func (h *SubmissionHandler) respondSubmission(c *gin.Context, status int, teamID uint) {
+	var submission database.Submission
+	if err := h.db.Preload("Team", selectTeamSummary).Where("team_id = ?", teamID).First(&submission).Error; err != nil {
+		h.HandleDBError(c, err, "Submission")
+		return
+	}
+	submissions := []database.Submission{submission}
+	if err := loadSubmissionFiles(h.db, submissions); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve submission files"})
+		return
+	}
+	c.JSON(status, submissions[0])
+}
+
+// selectTeamSummary limits a preloaded team to the fields shown with a submission
+func selectTeamSummary(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "hackathon_id", "name", "description", "leader_id", "max_size", "created_at", "updated_at")
+}
+
+// loadSubmissionFiles fills in the files attached to each submission
+func loadSubmissionFiles(db *gorm.DB, submissions []database.Submission) error {
+	if len(submissions) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(submissions))
+	for i, s := range submissions {
+		ids[i] = s.ID
+	}
+
+	var files []database.FileMetadata
+	err := db.Where("attachable_type = ? AND attachable_id IN ?", domain.AttachmentSubmission, ids).
+		Order("id").
+		Find(&files).Error
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uint][]database.FileMetadata, len(submissions))
+	for _, file := range files {
+		byID[file.AttachableID] = append(byID[file.AttachableID], file)
+	}
+	for i := range submissions {
+		submissions[i].Files = byID[submissions[i].ID]
+		if submissions[i].Files == nil {
+			submissions[i].Files = []database.FileMetadata{}
+		}
+	}
+	return nil
+}
+
+// checkSubmissionsOpen returns errSubmissionsClosed once the submission deadline
+// of the hackathon has passed or the hackathon was cancelled
+func checkSubmissionsOpen(tx *gorm.DB, hackathonID uint) error {
+	var hackathon database.Hackathon
+	if err := tx.Select("id", "status", "end_date", "submission_deadline").First(&hackathon, hackathonID).Error; err != nil {
+		return err
+	}
+	if !domain.SubmissionsOpen(hackathon.Status, hackathon.SubmissionDeadline, hackathon.EndDate, time.Now()) {
+		return errSubmissionsClosed
+	}
+	return nil
+}
+
+// handleSubmissionError maps submission errors to HTTP responses
+func handleSubmissionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+	case errors.Is(err, errInvalidTitle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Submission title is required"})
+	case errors.Is(err, errSubmissionExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Team has already submitted; update the existing submission"})
+	case errors.Is(err, errSubmissionsClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": "Submissions are closed for this hackathon"})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
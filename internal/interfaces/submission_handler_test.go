@@ -0,0 +1,133 @@
+package interfaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/application"
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/TRu-S3/backend/internal/infrastructure"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionHandler(t *testing.T) {
+	env := setupTeamTest(t, 3)
+	require.NoError(t, env.db.AutoMigrate(&database.Submission{}, &database.FileMetadata{}))
+	organizer, member, outsider := &env.users[0], &env.users[1], &env.users[2]
+	env.db.Model(&env.hackathon).Update("owner_id", organizer.ID)
+
+	team := database.Team{HackathonID: env.hackathon.ID, Name: "Builders", NameKey: "builders", LeaderID: member.ID, MaxSize: 5, InviteCode: "code"}
+	require.NoError(t, env.db.Create(&team).Error)
+	env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", member.ID).Update("team_id", team.ID)
+
+	handler := NewSubmissionHandler(env.db)
+	request := func(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.Use(withUser(user))
+		r.GET("/hackathons/:id/submissions", env.policy.RequireOwner("hackathon", HackathonOwner), handler.ListSubmissions)
+		r.GET("/hackathons/:id/submissions/export", env.policy.RequireOwner("hackathon", HackathonOwner), handler.ExportSubmissions)
+		r.POST("/hackathons/:id/teams/:team_id/submission", env.policy.RequireOwner("submission", TeamMembers), handler.CreateSubmission)
+		r.GET("/hackathons/:id/teams/:team_id/submission", env.policy.RequireOwner("submission", TeamMembersOrHackathonOwner), handler.GetSubmission)
+		r.PUT("/hackathons/:id/teams/:team_id/submission", env.policy.RequireOwner("submission", TeamMembers), handler.UpdateSubmission)
+		data, _ := json.Marshal(body)
+		req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	submissionPath := fmt.Sprintf("/hackathons/%d/teams/%d/submission", env.hackathon.ID, team.ID)
+	submissionsPath := fmt.Sprintf("/hackathons/%d/submissions", env.hackathon.ID)
+
+	create := CreateSubmissionRequest{Title: "=Tracker", RepositoryURL: "https://github.com/example/tracker"}
+	assert.Equal(t, http.StatusForbidden, request(outsider, "POST", submissionPath, create).Code)
+	assert.Equal(t, http.StatusBadRequest, request(member, "POST", submissionPath, CreateSubmissionRequest{Title: "Tracker", DemoURL: "not a url"}).Code)
+
+	w := request(member, "POST", submissionPath, create)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var submission database.Submission
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submission))
+	assert.Equal(t, member.ID, submission.SubmittedBy)
+	assert.Equal(t, http.StatusConflict, request(member, "POST", submissionPath, create).Code)
+
+	require.NoError(t, env.db.Create(&database.FileMetadata{
+		Name: "demo.mp4", Path: "demo.mp4", Size: 1, ContentType: "video/mp4",
+		AttachableType: "submission", AttachableID: submission.ID,
+	}).Error)
+
+	demo := "https://example.com/demo"
+	require.Equal(t, http.StatusOK, request(member, "PUT", submissionPath, UpdateSubmissionRequest{DemoURL: &demo}).Code)
+	w = request(organizer, "GET", submissionPath, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &submission))
+	assert.Equal(t, demo, submission.DemoURL)
+	require.Len(t, submission.Files, 1)
+	assert.Equal(t, "demo.mp4", submission.Files[0].Name)
+
+	t.Run("Organizers list and export submissions", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(member, "GET", submissionsPath, nil).Code)
+
+		w := request(organizer, "GET", submissionsPath, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"count":1`)
+
+		w = request(organizer, "GET", submissionsPath+"/export", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Disposition"), fmt.Sprintf("hackathon-%d-submissions.csv", env.hackathon.ID))
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, submissionExportColumns, rows[0])
+		assert.Equal(t, []string{"Builders", "'=Tracker"}, rows[1][2:4], "formulas are escaped")
+		assert.Equal(t, "demo.mp4", rows[1][7])
+	})
+
+	t.Run("Submissions lock at the deadline", func(t *testing.T) {
+		env.db.Model(&env.hackathon).Update("submission_deadline", time.Now().Add(-time.Minute))
+		title := "Late change"
+		w := request(member, "PUT", submissionPath, UpdateSubmissionRequest{Title: &title})
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, http.StatusOK, request(member, "GET", submissionPath, nil).Code)
+	})
+
+	t.Run("Submission files lock at the deadline", func(t *testing.T) {
+		repo, err := infrastructure.NewLocalFileRepository(t.TempDir(), "test")
+		require.NoError(t, err)
+		_, err = repo.Create(context.Background(), &domain.CreateFileRequest{Name: "slides.pdf", Content: []byte("slides"), ContentType: "application/pdf"})
+		require.NoError(t, err)
+		require.NoError(t, env.db.Create(&database.FileMetadata{
+			Name: "slides.pdf", Path: "slides.pdf", Size: 6, ContentType: "application/pdf", UploaderID: &member.ID,
+			AttachableType: "submission", AttachableID: submission.ID,
+		}).Error)
+
+		fileHandler := NewFileHandler(application.NewFileService(repo,
+			application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(env.db)),
+			application.WithAccessRepository(infrastructure.NewGormFileAccessRepository(env.db)),
+		))
+		r := gin.New()
+		r.Use(withUser(member))
+		r.PUT("/files/:id", fileHandler.UpdateFile)
+		r.DELETE("/files/:id", fileHandler.DeleteFile)
+		r.POST("/files/:id/versions/:generation/restore", fileHandler.RestoreFileVersion)
+
+		for _, route := range [][2]string{{"PUT", "/files/slides.pdf"}, {"DELETE", "/files/slides.pdf"}, {"POST", "/files/slides.pdf/versions/1/restore"}} {
+			req, _ := http.NewRequest(route[0], route[1], nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusConflict, w.Code, route[0]+" "+route[1])
+		}
+		exists, err := repo.Exists(context.Background(), "slides.pdf")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
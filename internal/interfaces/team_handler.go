@@ -0,0 +1,734 @@
+package interfaces
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	hackathonDB "github.com/TRu-S3/backend/internal/database/hackathon"
+	"github.com/TRu-S3/backend/internal/domain"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultTeamMaxSize is the team size used when a team is created without one
+const defaultTeamMaxSize = 5
+
+// inactiveParticipantStatuses are the participant states that cannot belong to a team
+var inactiveParticipantStatuses = []string{
+	domain.ParticipantWaitlisted,
+	domain.ParticipantCancelled,
+	domain.ParticipantDisqualified,
+}
+
+var (
+	errNotParticipant       = errors.New("user is not a participant of the hackathon")
+	errAlreadyInTeam        = errors.New("participant already belongs to a team")
+	errTeamFull             = errors.New("team is full")
+	errTeamNameTaken        = errors.New("team name is already taken")
+	errInvitationNotPending = errors.New("invitation is no longer pending")
+	errLeaderCannotLeave    = errors.New("leader cannot leave a team with other members")
+	errInvalidTeamName      = errors.New("team name is required")
+	errTeamTooSmall         = errors.New("max size is below the current member count")
+	errLeaderNotMember      = errors.New("new leader must be a team member")
+	errInvalidInviteCode    = errors.New("invalid invite code")
+	errNotTeamMember        = errors.New("user is not a member of the team")
+	errInvitationExists     = errors.New("user already has a pending invitation to the team")
+)
+
+// TeamHandler handles HTTP requests for hackathon teams and their invitations
+type TeamHandler struct {
+	*BaseHandler
+}
+
+// NewTeamHandler creates a new TeamHandler
+func NewTeamHandler(db *gorm.DB) *TeamHandler {
+	return &TeamHandler{
+		BaseHandler: NewBaseHandler(db),
+	}
+}
+
+type CreateTeamRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	MaxSize     int    `json:"max_size" binding:"min=0"`
+}
+
+type UpdateTeamRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	MaxSize     *int    `json:"max_size,omitempty" binding:"omitempty,min=1"`
+	LeaderID    *uint   `json:"leader_id,omitempty"`
+}
+
+type JoinTeamRequest struct {
+	InviteCode string `json:"invite_code" binding:"required"`
+}
+
+type CreateTeamInvitationRequest struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// CreateTeam handles POST /api/v1/hackathons/:id/teams
+// The caller must be a participant without a team and becomes the team leader.
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req CreateTeamRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Team name is required"})
+		return
+	}
+	if req.MaxSize == 0 {
+		req.MaxSize = defaultTeamMaxSize
+	}
+
+	var team database.Team
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		participant, err := activeParticipant(tx, hackathonID, user.ID)
+		if err != nil {
+			return err
+		}
+		if participant.TeamID != nil {
+			return errAlreadyInTeam
+		}
+		if err := checkTeamName(tx, hackathonID, 0, name); err != nil {
+			return err
+		}
+
+		code, err := newInviteCode()
+		if err != nil {
+			return err
+		}
+		team = database.Team{
+			HackathonID: hackathonID,
+			Name:        name,
+			NameKey:     teamNameKey(name),
+			Description: req.Description,
+			LeaderID:    user.ID,
+			MaxSize:     req.MaxSize,
+			InviteCode:  code,
+		}
+		if err := tx.Create(&team).Error; err != nil {
+			return err
+		}
+		return tx.Model(participant).Update("team_id", team.ID).Error
+	})
+	if err != nil {
+		handleTeamError(c, err, "Failed to create team")
+		return
+	}
+
+	h.respondTeam(c, http.StatusCreated, team.ID)
+}
+
+// ListTeams handles GET /api/v1/hackathons/:id/teams
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var teams []database.Team
+	if err := h.db.Where("hackathon_id = ?", hackathonID).Preload("Members").Order("id").Find(&teams).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve teams"})
+		return
+	}
+	for i := range teams {
+		h.redactInviteCode(c, &teams[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"teams": teams,
+		"count": len(teams),
+	})
+}
+
+// GetTeam handles GET /api/v1/hackathons/:id/teams/:team_id
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	h.respondTeam(c, http.StatusOK, team.ID)
+}
+
+// UpdateTeam handles PUT /api/v1/hackathons/:id/teams/:team_id
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	var req UpdateTeamRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(team, team.ID).Error; err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			name := strings.TrimSpace(*req.Name)
+			if name == "" {
+				return errInvalidTeamName
+			}
+			if err := checkTeamName(tx, team.HackathonID, team.ID, name); err != nil {
+				return err
+			}
+			team.Name = name
+			team.NameKey = teamNameKey(name)
+		}
+		if req.Description != nil {
+			team.Description = *req.Description
+		}
+		if req.MaxSize != nil {
+			members, err := teamMemberCount(tx, team.ID)
+			if err != nil {
+				return err
+			}
+			if int64(*req.MaxSize) < members {
+				return errTeamTooSmall
+			}
+			team.MaxSize = *req.MaxSize
+		}
+		if req.LeaderID != nil {
+			var count int64
+			if err := tx.Model(&database.HackathonParticipant{}).Where("team_id = ? AND user_id = ?", team.ID, *req.LeaderID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errLeaderNotMember
+			}
+			team.LeaderID = *req.LeaderID
+		}
+		return tx.Save(team).Error
+	})
+	if err != nil {
+		handleTeamError(c, err, "Failed to update team")
+		return
+	}
+
+	h.respondTeam(c, http.StatusOK, team.ID)
+}
+
+// DeleteTeam handles DELETE /api/v1/hackathons/:id/teams/:team_id
+// Members stay registered for the hackathon without a team.
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.db.Transaction(func(tx *gorm.DB) error { return disbandTeam(tx, team.ID) }); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete team"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Team deleted successfully"})
+}
+
+// RegenerateInviteCode handles POST /api/v1/hackathons/:id/teams/:team_id/invite-code
+// The previous code stops working.
+func (h *TeamHandler) RegenerateInviteCode(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+
+	code, err := newInviteCode()
+	if err == nil {
+		err = h.db.Model(team).Update("invite_code", code).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to regenerate invite code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invite_code": code})
+}
+
+// JoinTeam handles POST /api/v1/hackathons/:id/teams/join
+// The caller joins the team of the invite code if they have no team and it is not full.
+func (h *TeamHandler) JoinTeam(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var req JoinTeamRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+
+	var team database.Team
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		code := strings.ToLower(strings.TrimSpace(req.InviteCode))
+		if err := tx.Where("hackathon_id = ? AND invite_code = ?", hackathonID, code).First(&team).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errInvalidInviteCode
+			}
+			return err
+		}
+		participant, err := activeParticipant(tx, hackathonID, user.ID)
+		if err != nil {
+			return err
+		}
+		return joinTeam(tx, &team, participant)
+	})
+	if err != nil {
+		handleTeamError(c, err, "Failed to join team")
+		return
+	}
+
+	h.respondTeam(c, http.StatusOK, team.ID)
+}
+
+// RemoveTeamMember handles DELETE /api/v1/hackathons/:id/teams/:team_id/members/:user_id
+// Members may leave on their own; the leader can only leave as the last member, which disbands the team.
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.ParseIDParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(team, team.ID).Error; err != nil {
+			return err
+		}
+		var participant database.HackathonParticipant
+		if err := tx.Where("team_id = ? AND user_id = ?", team.ID, userID).First(&participant).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errNotTeamMember
+			}
+			return err
+		}
+
+		if userID == team.LeaderID {
+			members, err := teamMemberCount(tx, team.ID)
+			if err != nil {
+				return err
+			}
+			if members > 1 {
+				return errLeaderCannotLeave
+			}
+			return disbandTeam(tx, team.ID)
+		}
+		return tx.Model(&participant).Update("team_id", nil).Error
+	})
+	if err != nil {
+		handleTeamError(c, err, "Failed to remove team member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Team member removed successfully"})
+}
+
+// CreateTeamInvitation handles POST /api/v1/hackathons/:id/teams/:team_id/invitations
+// The invitee must be a participant of the hackathon without a team.
+func (h *TeamHandler) CreateTeamInvitation(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+	var req CreateTeamInvitationRequest
+	if !h.BindJSON(c, &req) {
+		return
+	}
+	inviterID := team.LeaderID
+	if user, ok := CurrentUser(c); ok {
+		inviterID = user.ID
+	}
+
+	var invitation database.TeamInvitation
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		participant, err := activeParticipant(tx, team.HackathonID, req.UserID)
+		if err != nil {
+			return err
+		}
+		if participant.TeamID != nil {
+			return errAlreadyInTeam
+		}
+		members, err := teamMemberCount(tx, team.ID)
+		if err != nil {
+			return err
+		}
+		if members >= int64(team.MaxSize) {
+			return errTeamFull
+		}
+
+		var pending int64
+		err = tx.Model(&database.TeamInvitation{}).
+			Where("team_id = ? AND user_id = ? AND status = ?", team.ID, req.UserID, hackathonDB.TeamInvitationPending).
+			Count(&pending).Error
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return errInvitationExists
+		}
+
+		invitation = database.TeamInvitation{
+			TeamID:    team.ID,
+			UserID:    req.UserID,
+			InvitedBy: inviterID,
+			Status:    hackathonDB.TeamInvitationPending,
+		}
+		return tx.Create(&invitation).Error
+	})
+	if err != nil {
+		handleTeamError(c, err, "Failed to create invitation")
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListTeamInvitations handles GET /api/v1/hackathons/:id/teams/:team_id/invitations
+func (h *TeamHandler) ListTeamInvitations(c *gin.Context) {
+	team, ok := h.lookupTeam(c)
+	if !ok {
+		return
+	}
+
+	query := h.db.Where("team_id = ?", team.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var invitations []database.TeamInvitation
+	if err := query.Order("created_at DESC").Find(&invitations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+		"count":       len(invitations),
+	})
+}
+
+// ListMyTeamInvitations handles GET /api/v1/team-invitations
+// Lists the invitations of the caller, pending ones unless status is given.
+func (h *TeamHandler) ListMyTeamInvitations(c *gin.Context) {
+	user, ok := requireCurrentUser(c)
+	if !ok {
+		return
+	}
+
+	status := c.DefaultQuery("status", hackathonDB.TeamInvitationPending)
+	var invitations []database.TeamInvitation
+	err := h.db.Where("user_id = ? AND status = ?", user.ID, status).
+		Preload("Team").
+		Order("created_at DESC").
+		Find(&invitations).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+		return
+	}
+	for i := range invitations {
+		invitations[i].Team.InviteCode = ""
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+		"count":       len(invitations),
+	})
+}
+
+// AcceptTeamInvitation handles POST /api/v1/team-invitations/:id/accept
+func (h *TeamHandler) AcceptTeamInvitation(c *gin.Context) {
+	h.respondToInvitation(c, hackathonDB.TeamInvitationAccepted)
+}
+
+// DeclineTeamInvitation handles POST /api/v1/team-invitations/:id/decline
+func (h *TeamHandler) DeclineTeamInvitation(c *gin.Context) {
+	h.respondToInvitation(c, hackathonDB.TeamInvitationDeclined)
+}
+
+// CancelTeamInvitation handles DELETE /api/v1/team-invitations/:id
+func (h *TeamHandler) CancelTeamInvitation(c *gin.Context) {
+	h.respondToInvitation(c, hackathonDB.TeamInvitationCancelled)
+}
+
+// respondToInvitation moves a pending invitation to status. Accepting adds the
+// invitee to the team while the team is locked so it cannot overfill.
+func (h *TeamHandler) respondToInvitation(c *gin.Context, status string) {
+	invitationID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var invitation database.TeamInvitation
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invitation, invitationID).Error; err != nil {
+			return err
+		}
+		if invitation.Status != hackathonDB.TeamInvitationPending {
+			return errInvitationNotPending
+		}
+
+		if status == hackathonDB.TeamInvitationAccepted {
+			var team database.Team
+			if err := tx.First(&team, invitation.TeamID).Error; err != nil {
+				return err
+			}
+			participant, err := activeParticipant(tx, team.HackathonID, invitation.UserID)
+			if err != nil {
+				return err
+			}
+			if err := joinTeam(tx, &team, participant); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		invitation.Status = status
+		invitation.RespondedAt = &now
+		return tx.Save(&invitation).Error
+	})
+	if err != nil {
+		handleTeamError(c, err, "Failed to update invitation")
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// lookupTeam loads the team addressed by :team_id within the hackathon addressed by :id
+func (h *TeamHandler) lookupTeam(c *gin.Context) (*database.Team, bool) {
+	hackathonID, ok := h.ParseIDParam(c, "id")
+	if !ok {
+		return nil, false
+	}
+	teamID, ok := h.ParseIDParam(c, "team_id")
+	if !ok {
+		return nil, false
+	}
+
+	var team database.Team
+	if err := h.db.Where("hackathon_id = ? AND id = ?", hackathonID, teamID).First(&team).Error; err != nil {
+		h.HandleDBError(c, err, "Team")
+		return nil, false
+	}
+	return &team, true
+}
+
+// respondTeam responds with the team and its members
+func (h *TeamHandler) respondTeam(c *gin.Context, status int, teamID uint) {
+	var team database.Team
+	if err := h.db.Preload("Members").First(&team, teamID).Error; err != nil {
+		h.HandleDBError(c, err, "Team")
+		return
+	}
+	h.redactInviteCode(c, &team)
+	c.JSON(status, team)
+}
+
+// redactInviteCode hides the invite code from everyone but the team members,
+// the hackathon owner and admins
+func (h *TeamHandler) redactInviteCode(c *gin.Context, team *database.Team) {
+	user, ok := CurrentUser(c)
+	if ok && user.IsAdmin() {
+		return
+	}
+	if ok {
+		for _, member := range team.Members {
+			if member.UserID == user.ID {
+				return
+			}
+		}
+		var hackathon database.Hackathon
+		if h.db.Select("id", "owner_id").First(&hackathon, team.HackathonID).Error == nil &&
+			hackathon.OwnerID != nil && *hackathon.OwnerID == user.ID {
+			return
+		}
+	}
+	team.InviteCode = ""
+}
+
+// handleTeamError maps team errors to responses
+func handleTeamError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, errNotParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": "User must be registered for the hackathon"})
+	case errors.Is(err, errInvalidTeamName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Team name is required"})
+	case errors.Is(err, errLeaderNotMember):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "New leader must be a team member"})
+	case errors.Is(err, errInvalidInviteCode):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invalid invite code"})
+	case errors.Is(err, errNotTeamMember):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+	case errors.Is(err, errAlreadyInTeam):
+		c.JSON(http.StatusConflict, gin.H{"error": "User already belongs to a team in this hackathon"})
+	case errors.Is(err, errTeamFull):
+		c.JSON(http.StatusConflict, gin.H{"error": "Team is full"})
+	case errors.Is(err, errTeamNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": "Team name is already taken in this hackathon"})
+	case errors.Is(err, errTeamTooSmall):
+		c.JSON(http.StatusConflict, gin.H{"error": "Max size cannot be below the current member count"})
+	case errors.Is(err, errInvitationExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "User already has a pending invitation to this team"})
+	case errors.Is(err, errInvitationNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": "Invitation is no longer pending"})
+	case errors.Is(err, errLeaderCannotLeave):
+		c.JSON(http.StatusConflict, gin.H{"error": "Transfer leadership before the leader leaves the team"})
+	default:
+		log.Printf("%s: %v", message, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// requireCurrentUser returns the authenticated user, responding with 401 without one
+func requireCurrentUser(c *gin.Context) (*database.User, bool) {
+	user, ok := CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+	}
+	return user, ok
+}
+
+// activeParticipant loads and locks the registration of a user for a hackathon
+func activeParticipant(tx *gorm.DB, hackathonID, userID uint) (*database.HackathonParticipant, error) {
+	var participant database.HackathonParticipant
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("hackathon_id = ? AND user_id = ? AND status NOT IN ?", hackathonID, userID, inactiveParticipantStatuses).
+		First(&participant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errNotParticipant
+		}
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// joinTeam adds a participant to a team. The team row is locked while its members
+// are counted so concurrent joins cannot exceed the max size.
+func joinTeam(tx *gorm.DB, team *database.Team, participant *database.HackathonParticipant) error {
+	if participant.TeamID != nil {
+		return errAlreadyInTeam
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(team, team.ID).Error; err != nil {
+		return err
+	}
+	members, err := teamMemberCount(tx, team.ID)
+	if err != nil {
+		return err
+	}
+	if members >= int64(team.MaxSize) {
+		return errTeamFull
+	}
+	return tx.Model(participant).Update("team_id", team.ID).Error
+}
+
+// teamMemberCount counts the active participants of a team
+func teamMemberCount(tx *gorm.DB, teamID uint) (int64, error) {
+	var count int64
+	err := tx.Model(&database.HackathonParticipant{}).
+		Where("team_id = ? AND status NOT IN ?", teamID, inactiveParticipantStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+// disbandTeam removes a team, its invitations and the team of its members
+func disbandTeam(tx *gorm.DB, teamID uint) error {
+	if err := tx.Model(&database.HackathonParticipant{}).Where("team_id = ?", teamID).Update("team_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("team_id = ?", teamID).Delete(&database.TeamInvitation{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&database.Team{}, teamID).Error
+}
+
+// leaveTeam takes a participant whose registration ends out of their team. A leaving
+// leader hands the team to the earliest registered remaining member, or disbands it
+// as the last one, so a team never keeps a leader who is not a member.
+func leaveTeam(tx *gorm.DB, participant *database.HackathonParticipant) error {
+	if participant.TeamID == nil {
+		return nil
+	}
+	var team database.Team
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&team, *participant.TeamID).Error; err != nil {
+		return err
+	}
+
+	if team.LeaderID == participant.UserID {
+		var successor database.HackathonParticipant
+		err := tx.Where("team_id = ? AND id <> ? AND status NOT IN ?", team.ID, participant.ID, inactiveParticipantStatuses).
+			Order("id").First(&successor).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := disbandTeam(tx, team.ID); err != nil {
+				return err
+			}
+			participant.TeamID = nil
+			return nil
+		case err != nil:
+			return err
+		}
+		if err := tx.Model(&team).Update("leader_id", successor.UserID).Error; err != nil {
+			return err
+		}
+	}
+
+	participant.TeamID = nil
+	return tx.Model(participant).Update("team_id", nil).Error
+}
+
+// checkTeamName rejects names already used by another team of the hackathon,
+// ignoring case and surrounding whitespace
+func checkTeamName(tx *gorm.DB, hackathonID, teamID uint, name string) error {
+	var count int64
+	err := tx.Model(&database.Team{}).
+		Where("hackathon_id = ? AND name_key = ? AND id <> ?", hackathonID, teamNameKey(name), teamID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errTeamNameTaken
+	}
+	return nil
+}
+
+// teamNameKey normalizes a team name for uniqueness checks
+func teamNameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// newInviteCode returns a random code for joining a team
+func newInviteCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,218 @@
+package interfaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TRu-S3/backend/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type teamTestEnv struct {
+	db        *gorm.DB
+	handler   *TeamHandler
+	policy    *Policy
+	hackathon database.Hackathon
+	users     []database.User
+}
+
+func setupTeamTest(t *testing.T, users int) *teamTestEnv {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.User{}, &database.Hackathon{}, &database.HackathonParticipant{}, &database.Team{}, &database.TeamInvitation{}))
+
+	env := &teamTestEnv{db: db, handler: NewTeamHandler(db), policy: NewPolicy(db)}
+	for i := 0; i < users; i++ {
+		user := database.User{Name: fmt.Sprintf("User %d", i+1), Gmail: fmt.Sprintf("user%d@example.com", i+1), Role: "user"}
+		require.NoError(t, db.Create(&user).Error)
+		env.users = append(env.users, user)
+	}
+
+	now := time.Now()
+	env.hackathon = database.Hackathon{
+		Name:                 "Hackathon",
+		StartDate:            now.Add(48 * time.Hour),
+		EndDate:              now.Add(72 * time.Hour),
+		RegistrationStart:    now.Add(-time.Hour),
+		RegistrationDeadline: now.Add(24 * time.Hour),
+		Organizer:            "Organizer",
+	}
+	require.NoError(t, db.Create(&env.hackathon).Error)
+	for _, user := range env.users {
+		require.NoError(t, db.Create(&database.HackathonParticipant{HackathonID: env.hackathon.ID, UserID: user.ID, Status: "registered"}).Error)
+	}
+
+	gin.SetMode(gin.TestMode)
+	return env
+}
+
+// do performs a request against the team routes as user
+func (e *teamTestEnv) do(user *database.User, method, path string, body interface{}) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(withUser(user))
+	r.POST("/hackathons/:id/teams", e.handler.CreateTeam)
+	r.GET("/hackathons/:id/teams/:team_id", e.handler.GetTeam)
+	r.POST("/hackathons/:id/teams/join", e.handler.JoinTeam)
+	r.PUT("/hackathons/:id/teams/:team_id", e.policy.RequireOwner("team", TeamLeaderOrHackathonOwner), e.handler.UpdateTeam)
+	r.POST("/hackathons/:id/teams/:team_id/invitations", e.policy.RequireOwner("team", TeamLeaderOrHackathonOwner), e.handler.CreateTeamInvitation)
+	r.DELETE("/hackathons/:id/teams/:team_id/members/:user_id", e.policy.RequireOwner("team member", TeamMemberSelfOrLeader), e.handler.RemoveTeamMember)
+	r.GET("/team-invitations", e.handler.ListMyTeamInvitations)
+	r.POST("/team-invitations/:id/accept", e.policy.RequireOwner("team invitation", TeamInvitee), e.handler.AcceptTeamInvitation)
+	r.POST("/team-invitations/:id/decline", e.policy.RequireOwner("team invitation", TeamInvitee), e.handler.DeclineTeamInvitation)
+
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func (e *teamTestEnv) teamPath(teamID uint) string {
+	return fmt.Sprintf("/hackathons/%d/teams/%d", e.hackathon.ID, teamID)
+}
+
+func (e *teamTestEnv) createTeam(t *testing.T, leader *database.User, name string, maxSize int) database.Team {
+	w := e.do(leader, "POST", fmt.Sprintf("/hackathons/%d/teams", e.hackathon.ID), CreateTeamRequest{Name: name, MaxSize: maxSize})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var team database.Team
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &team))
+	return team
+}
+
+func TestTeamHandler_CreateAndJoin(t *testing.T) {
+	env := setupTeamTest(t, 4)
+	leader, member, late, outsider := &env.users[0], &env.users[1], &env.users[2], &env.users[3]
+	joinPath := fmt.Sprintf("/hackathons/%d/teams/join", env.hackathon.ID)
+
+	team := env.createTeam(t, leader, "  Team A ", 2)
+	assert.Equal(t, "Team A", team.Name)
+	assert.Equal(t, leader.ID, team.LeaderID)
+	assert.NotEmpty(t, team.InviteCode)
+	require.Len(t, team.Members, 1)
+
+	t.Run("Names are unique ignoring case", func(t *testing.T) {
+		w := env.do(member, "POST", fmt.Sprintf("/hackathons/%d/teams", env.hackathon.ID), CreateTeamRequest{Name: "team a"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Leader cannot create a second team", func(t *testing.T) {
+		w := env.do(leader, "POST", fmt.Sprintf("/hackathons/%d/teams", env.hackathon.ID), CreateTeamRequest{Name: "Team B"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invite code is hidden from non-members", func(t *testing.T) {
+		w := env.do(outsider, "GET", env.teamPath(team.ID), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), team.InviteCode)
+	})
+
+	t.Run("Join by invite code", func(t *testing.T) {
+		w := env.do(member, "POST", joinPath, JoinTeamRequest{InviteCode: team.InviteCode})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var joined database.Team
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &joined))
+		assert.Len(t, joined.Members, 2)
+	})
+
+	t.Run("Full team rejects joins", func(t *testing.T) {
+		w := env.do(late, "POST", joinPath, JoinTeamRequest{InviteCode: team.InviteCode})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid invite code", func(t *testing.T) {
+		w := env.do(late, "POST", joinPath, JoinTeamRequest{InviteCode: "nope"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Non-participants cannot join", func(t *testing.T) {
+		stranger := database.User{Name: "Stranger", Gmail: "stranger@example.com", Role: "user"}
+		require.NoError(t, env.db.Create(&stranger).Error)
+		w := env.do(&stranger, "POST", joinPath, JoinTeamRequest{InviteCode: team.InviteCode})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Max size cannot drop below the member count", func(t *testing.T) {
+		size := 1
+		w := env.do(leader, "PUT", env.teamPath(team.ID), UpdateTeamRequest{MaxSize: &size})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = env.do(member, "PUT", env.teamPath(team.ID), UpdateTeamRequest{MaxSize: &size})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestTeamHandler_Invitations(t *testing.T) {
+	env := setupTeamTest(t, 3)
+	leader, invitee, other := &env.users[0], &env.users[1], &env.users[2]
+	team := env.createTeam(t, leader, "Team", 3)
+	invitationsPath := env.teamPath(team.ID) + "/invitations"
+
+	invite := func(userID uint) database.TeamInvitation {
+		w := env.do(leader, "POST", invitationsPath, CreateTeamInvitationRequest{UserID: userID})
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var invitation database.TeamInvitation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &invitation))
+		return invitation
+	}
+
+	invitation := invite(invitee.ID)
+	assert.Equal(t, "pending", invitation.Status)
+
+	w := env.do(leader, "POST", invitationsPath, CreateTeamInvitationRequest{UserID: invitee.ID})
+	assert.Equal(t, http.StatusConflict, w.Code, "duplicate pending invitation")
+
+	w = env.do(invitee, "GET", "/team-invitations", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+
+	acceptPath := fmt.Sprintf("/team-invitations/%d/accept", invitation.ID)
+	assert.Equal(t, http.StatusForbidden, env.do(other, "POST", acceptPath, nil).Code)
+	require.Equal(t, http.StatusOK, env.do(invitee, "POST", acceptPath, nil).Code)
+	assert.Equal(t, http.StatusConflict, env.do(invitee, "POST", acceptPath, nil).Code)
+
+	var participant database.HackathonParticipant
+	require.NoError(t, env.db.Where("user_id = ?", invitee.ID).First(&participant).Error)
+	require.NotNil(t, participant.TeamID)
+	assert.Equal(t, team.ID, *participant.TeamID)
+
+	declined := invite(other.ID)
+	w = env.do(other, "POST", fmt.Sprintf("/team-invitations/%d/decline", declined.ID), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"declined"`)
+}
+
+func TestTeamHandler_RemoveMember(t *testing.T) {
+	env := setupTeamTest(t, 3)
+	leader, member, other := &env.users[0], &env.users[1], &env.users[2]
+	team := env.createTeam(t, leader, "Team", 3)
+	require.NoError(t, env.db.Model(&database.HackathonParticipant{}).Where("user_id = ?", member.ID).Update("team_id", team.ID).Error)
+
+	memberPath := func(userID uint) string {
+		return fmt.Sprintf("%s/members/%d", env.teamPath(team.ID), userID)
+	}
+
+	assert.Equal(t, http.StatusForbidden, env.do(other, "DELETE", memberPath(member.ID), nil).Code)
+	assert.Equal(t, http.StatusConflict, env.do(leader, "DELETE", memberPath(leader.ID), nil).Code, "leader must transfer leadership first")
+	require.Equal(t, http.StatusOK, env.do(member, "DELETE", memberPath(member.ID), nil).Code)
+
+	// The last member leaving disbands the team
+	require.Equal(t, http.StatusOK, env.do(leader, "DELETE", memberPath(leader.ID), nil).Code)
+	var count int64
+	env.db.Model(&database.Team{}).Count(&count)
+	assert.Zero(t, count)
+	env.db.Model(&database.HackathonParticipant{}).Where("team_id IS NOT NULL").Count(&count)
+	assert.Zero(t, count)
+}
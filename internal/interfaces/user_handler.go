@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/TRu-S3/backend/internal/database"
 	"github.com/TRu-S3/backend/internal/utils"
@@ -30,6 +31,7 @@ type UpdateUserRequest struct {
 }
 
 // CreateUser handles POST /api/v1/users
+// Users normally sign up by logging in; admins may create accounts ahead of time.
 func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req CreateUserRequest
 	if !h.BindJSON(c, &req) {
@@ -142,6 +144,11 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		user.Name = *req.Name
 	}
 	if req.Gmail != nil {
+		// Logins are bound to the Google account, so only admins may correct the address
+		if current, ok := CurrentUser(c); !ok || !current.IsAdmin() {
+			utils.ErrorResponse(c, http.StatusForbidden, "Only admins can change the Gmail address")
+			return
+		}
 		if !utils.ValidateEmail(c, *req.Gmail) {
 			return
 		}
@@ -187,7 +194,7 @@ func isUniqueConstraintError(err error, field string) bool {
 	if err == nil {
 		return false
 	}
-	errStr := err.Error()
+	errStr := strings.ToLower(err.Error())
 	return (field == "gmail" && (contains(errStr, "duplicate key") || contains(errStr, "unique constraint"))) &&
 		contains(errStr, field)
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"log"
 	"net/http"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/TRu-S3/backend/internal/application"
 	"github.com/TRu-S3/backend/internal/config"
 	"github.com/TRu-S3/backend/internal/database"
+	"github.com/TRu-S3/backend/internal/domain"
 	"github.com/TRu-S3/backend/internal/infrastructure"
 	"github.com/TRu-S3/backend/internal/interfaces"
 	"github.com/gin-gonic/gin"
@@ -35,8 +37,13 @@ func main() {
 	log.Printf("Configuration loaded:")
 	log.Printf("  Port: %s", cfg.Port)
 	log.Printf("  Gin Mode: %s", cfg.GinMode)
-	log.Printf("  GCS Bucket: %s", cfg.GCSBucketName)
-	log.Printf("  GCS Folder: %s", cfg.GCSFolder)
+	log.Printf("  Storage Backend: %s", cfg.StorageBackend)
+	if cfg.StorageBackend == config.StorageBackendLocal {
+		log.Printf("  Local Storage Dir: %s", cfg.LocalStorageDir)
+	} else {
+		log.Printf("  GCS Bucket: %s", cfg.GCSBucketName)
+	}
+	log.Printf("  Storage Folder: %s", cfg.GCSFolder)
 	log.Printf("  Database: %s:%s/%s", cfg.DBHost, cfg.DBPort, cfg.DBName)
 	if cfg.GoogleCloudProject != "" {
 		log.Printf("  GCP Project: %s", cfg.GoogleCloudProject)
@@ -48,33 +55,103 @@ func main() {
 	}
 	defer database.Close()
 
+	// Handle the migrate subcommand and exit without starting the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(os.Args[2:]); err != nil {
+			log.Fatalf("Migration command failed: %v", err)
+		}
+		return
+	}
+
 	// Run database migrations
-	if err := database.Migrate(database.GetDB()); err != nil {
-		log.Fatalf("Failed to migrate database: %v", err)
+	if cfg.AutoMigrate {
+		if err := database.Migrate(database.GetDB()); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
 	}
 
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Get configuration from config instead of environment variables directly
-	bucketName := cfg.GCSBucketName
-	folder := cfg.GCSFolder
-
-	// Create GCS client
-	gcsClient, err := infrastructure.NewGCSClient(ctx)
-	if err != nil {
-		log.Fatal("Failed to create GCS client:", err)
+	// Create file repository and URL signer for the configured storage backend
+	var fileRepo domain.FileRepository
+	var versionRepo domain.FileVersionRepository
+	var urlSigner domain.URLSigner
+	var hmacSigner *infrastructure.HMACURLSigner
+	switch cfg.StorageBackend {
+	case config.StorageBackendLocal:
+		localRepo, err := infrastructure.NewLocalFileRepository(cfg.LocalStorageDir, cfg.GCSFolder)
+		if err != nil {
+			log.Fatal("Failed to create local file repository:", err)
+		}
+		fileRepo = localRepo
+		versionRepo = localRepo
+
+		signedURLSecret := []byte(cfg.SignedURLSecret)
+		if len(signedURLSecret) == 0 {
+			log.Printf("Warning: SIGNED_URL_SECRET is not set; using an ephemeral secret (signed URLs will not survive restarts)")
+			signedURLSecret = make([]byte, 32)
+			if _, err := rand.Read(signedURLSecret); err != nil {
+				log.Fatal("Failed to generate signed URL secret:", err)
+			}
+		}
+		hmacSigner = infrastructure.NewHMACURLSigner(signedURLSecret, cfg.PublicBaseURL)
+		urlSigner = hmacSigner
+	default:
+		gcsClient, err := infrastructure.NewGCSClient(ctx)
+		if err != nil {
+			log.Fatal("Failed to create GCS client:", err)
+		}
+		defer infrastructure.CloseGCSClient(gcsClient)
+		gcsRepo := infrastructure.NewGCSFileRepository(gcsClient, cfg.GCSBucketName, cfg.GCSFolder)
+		fileRepo = gcsRepo
+		versionRepo = gcsRepo
+		if enabled, err := gcsRepo.VersioningEnabled(ctx); err != nil {
+			log.Printf("Warning: Could not check GCS bucket versioning: %v", err)
+		} else if !enabled {
+			log.Printf("Warning: Object Versioning is disabled on bucket %s; overwritten files cannot be restored", cfg.GCSBucketName)
+		}
+		urlSigner = infrastructure.NewGCSURLSigner(gcsRepo)
 	}
 
-	// Create repository
-	fileRepo := infrastructure.NewGCSFileRepository(gcsClient, bucketName, folder)
+	// Select the content scanner; none disables scanning
+	var scanner domain.FileScanner
+	switch cfg.FileScanner {
+	case config.FileScannerClamAV:
+		scanner = infrastructure.NewClamAVScanner(cfg.ClamAVAddress, cfg.ClamAVTimeout)
+	case config.FileScannerNoop:
+		scanner = infrastructure.NoopScanner{}
+	}
 
 	// Create service
-	fileService := application.NewFileService(fileRepo)
+	fileService := application.NewFileService(fileRepo,
+		application.WithMetadataRepository(infrastructure.NewGormFileMetadataRepository(database.GetDB())),
+		application.WithAccessRepository(infrastructure.NewGormFileAccessRepository(database.GetDB())),
+		application.WithURLSigner(urlSigner, cfg.SignedURLTTL),
+		application.WithVersioning(versionRepo, domain.VersionRetention{MaxVersions: cfg.FileVersionMaxCount, MaxAge: cfg.FileVersionMaxAge}),
+		application.WithImageProcessor(infrastructure.NewImageProcessor(infrastructure.DefaultThumbnailSizes, infrastructure.DefaultMaxImageBytes, infrastructure.DefaultMaxImagePixels)),
+		application.WithUploadPolicy(domain.UploadPolicy{MaxFileSize: cfg.UploadMaxFileSize, UserQuota: cfg.UserStorageQuota, AllowedTypes: cfg.UploadAllowedTypes}),
+		application.WithScanner(scanner, cfg.FileQuarantinePrefix),
+		application.WithUploadSessions(infrastructure.NewGormUploadSessionRepository(database.GetDB()), cfg.UploadSessionTTL),
+		application.WithSignedUploads(infrastructure.NewGormSignedUploadRepository(database.GetDB())),
+	)
+
+	// Handle the reconcile-files subcommand and exit without starting the server
+	if len(os.Args) > 1 && os.Args[1] == "reconcile-files" {
+		if err := runReconcileFilesCommand(ctx, fileService, os.Args[2:]); err != nil {
+			log.Fatalf("File reconciliation failed: %v", err)
+		}
+		return
+	}
 
 	// Create handler
 	fileHandler := interfaces.NewFileHandler(fileService)
+	signedURLHandler := interfaces.NewSignedURLHandler(fileService)
+	var storageHandler *interfaces.LocalStorageHandler
+	if hmacSigner != nil {
+		storageHandler = interfaces.NewLocalStorageHandler(hmacSigner, fileService, fileHandler)
+	}
 
 	// Create user handler
 	userHandler := interfaces.NewUserHandler(database.GetDB())
@@ -97,6 +174,53 @@ func main() {
 	// Create hackathon handler
 	hackathonHandler := interfaces.NewHackathonHandler(database.GetDB())
 
+	// Create image handler
+	imageHandler := interfaces.NewImageHandler(database.GetDB(), fileService)
+
+	// Create team handler
+	teamHandler := interfaces.NewTeamHandler(database.GetDB())
+
+	// Create notification handler
+	notificationHandler := interfaces.NewNotificationHandler(database.GetDB())
+
+	// Create submission handler
+	submissionHandler := interfaces.NewSubmissionHandler(database.GetDB())
+
+	// Create judging handler
+	judgingHandler := interfaces.NewJudgingHandler(database.GetDB())
+
+	// Create calendar handler
+	calendarHandler := interfaces.NewCalendarHandler(database.GetDB(), cfg.PublicBaseURL)
+
+	// Create series handler
+	seriesHandler := interfaces.NewSeriesHandler(database.GetDB())
+
+	// Create contest application handler
+	contestApplicationHandler := interfaces.NewContestApplicationHandler(database.GetDB())
+
+	// Create authentication components
+	sessionSecret := []byte(cfg.SessionSecret)
+	if len(sessionSecret) == 0 {
+		log.Printf("Warning: SESSION_SECRET is not set; using an ephemeral secret (sessions will not survive restarts)")
+		sessionSecret = make([]byte, 32)
+		if _, err := rand.Read(sessionSecret); err != nil {
+			log.Fatal("Failed to generate session secret:", err)
+		}
+	}
+	sessionManager := infrastructure.NewJWTSessionManager(sessionSecret, cfg.SessionTTL)
+	idTokenVerifier := infrastructure.NewGoogleIDTokenVerifier(cfg.GoogleJWKSURL, cfg.GoogleClientID, nil)
+	authMiddleware := interfaces.NewAuthMiddleware(sessionManager, database.GetDB())
+	policy := interfaces.NewPolicy(database.GetDB())
+	authHandler := interfaces.NewAuthHandler(database.GetDB(), idTokenVerifier, sessionManager, cfg.AdminEmails)
+
+	// Create check-in handler; without CHECKIN_SECRET check-in tokens are signed
+	// with a key derived from the session secret
+	checkInSecret := []byte(cfg.CheckInSecret)
+	if len(checkInSecret) == 0 {
+		checkInSecret = infrastructure.DeriveCheckInSecret(sessionSecret)
+	}
+	checkInHandler := interfaces.NewCheckInHandler(database.GetDB(), infrastructure.NewHMACCheckInSigner(checkInSecret))
+
 	// Set Gin mode from configuration
 	gin.SetMode(cfg.GinMode)
 
@@ -106,8 +230,9 @@ func main() {
 	// Add CORS middleware
 	r.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, Tus-Resumable, Upload-Length, Upload-Offset, Upload-Metadata")
+		c.Header("Access-Control-Expose-Headers", "Location, Tus-Resumable, Upload-Length, Upload-Offset, Upload-Expires")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -133,7 +258,7 @@ func main() {
 	})
 
 	// Setup API routes
-	interfaces.SetupRoutes(r, fileHandler, contestHandler, bookmarkHandler, hackathonHandler, userHandler, tagHandler, profileHandler, matchingHandler)
+	interfaces.SetupRoutes(r, authMiddleware, policy, authHandler, fileHandler, signedURLHandler, storageHandler, contestHandler, bookmarkHandler, hackathonHandler, userHandler, tagHandler, profileHandler, matchingHandler, imageHandler, teamHandler, notificationHandler, submissionHandler, judgingHandler, checkInHandler, calendarHandler, seriesHandler, contestApplicationHandler)
 
 	// Create HTTP server with port from configuration
 	srv := &http.Server{
@@ -149,6 +274,16 @@ func main() {
 		}
 	}()
 
+	// Discard abandoned resumable uploads in the background
+	go expireUploadSessions(ctx, fileService, cfg.UploadSessionCleanupInterval)
+
+	// Discard signed uploads that were never completed in the background
+	go expireSignedUploads(ctx, fileService, cfg.UploadSessionCleanupInterval)
+
+	// Move hackathons through their lifecycle as their schedule passes
+	hackathonScheduler := application.NewHackathonScheduler(infrastructure.NewGormHackathonScheduleRepository(database.GetDB(), interfaces.ApplyScheduledHackathonStatus))
+	go advanceHackathonStatuses(ctx, hackathonScheduler, cfg.HackathonStatusInterval)
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -165,3 +300,68 @@ func main() {
 
 	log.Println("Server exited")
 }
+
+// expireUploadSessions periodically discards expired resumable uploads until ctx is cancelled
+func expireUploadSessions(ctx context.Context, fileService *application.FileService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := fileService.ExpireUploadSessions(ctx)
+			if err != nil {
+				log.Printf("Failed to expire upload sessions: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("Discarded %d expired upload sessions", expired)
+			}
+		}
+	}
+}
+
+// expireSignedUploads periodically discards signed uploads that were not completed in time until ctx is cancelled
+func expireSignedUploads(ctx context.Context, fileService *application.FileService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := fileService.ExpireSignedUploads(ctx)
+			if err != nil {
+				log.Printf("Failed to expire signed uploads: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("Discarded %d expired signed uploads", expired)
+			}
+		}
+	}
+}
+
+// advanceHackathonStatuses applies due hackathon status transitions at startup and
+// then periodically until ctx is cancelled
+func advanceHackathonStatuses(ctx context.Context, scheduler *application.HackathonScheduler, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		transitions, err := scheduler.AdvanceStatuses(ctx)
+		if err != nil {
+			log.Printf("Failed to advance hackathon statuses: %v", err)
+		}
+		for _, t := range transitions {
+			log.Printf("Hackathon %d moved from %s to %s", t.HackathonID, t.FromStatus, t.ToStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
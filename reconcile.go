@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/TRu-S3/backend/internal/application"
+)
+
+// runReconcileFilesCommand handles the "reconcile-files" subcommand, repairing
+// drift between stored objects and the file_metadata table
+func runReconcileFilesCommand(ctx context.Context, fileService *application.FileService, args []string) error {
+	flags := flag.NewFlagSet("reconcile-files", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report drift without changing file_metadata")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := fileService.ReconcileMetadata(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range []struct {
+		label string
+		ids   []string
+	}{
+		{"added", report.Added},
+		{"updated", report.Updated},
+		{"removed", report.Removed},
+	} {
+		for _, id := range change.ids {
+			fmt.Printf("%-8s %s\n", change.label, id)
+		}
+	}
+
+	mode := ""
+	if *dryRun {
+		mode = " (dry run)"
+	}
+	fmt.Printf("Reconciled file metadata%s: %d added, %d updated, %d removed\n",
+		mode, len(report.Added), len(report.Updated), len(report.Removed))
+	return nil
+}